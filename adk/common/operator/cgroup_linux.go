@@ -0,0 +1,87 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package operator
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"syscall"
+)
+
+const cgroupSupported = true
+
+var cgroupSeq atomic.Int64
+
+// cgroup is a cgroup v2 child created for one command.
+type cgroup struct {
+	dir string
+	fd  *os.File
+}
+
+func newCgroup(parent string, memoryBytes int64, maxProcesses int) (*cgroup, error) {
+	dir := filepath.Join(parent, fmt.Sprintf("eino-op-%d-%d", os.Getpid(), cgroupSeq.Add(1)))
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return nil, err
+	}
+	cg := &cgroup{dir: dir}
+	limits := map[string]string{}
+	if memoryBytes > 0 {
+		limits["memory.max"] = strconv.FormatInt(memoryBytes, 10)
+	}
+	if maxProcesses > 0 {
+		limits["pids.max"] = strconv.Itoa(maxProcesses)
+	}
+	for file, v := range limits {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(v), 0o644); err != nil {
+			cg.close()
+			return nil, fmt.Errorf("set %s (is the controller enabled in %s/cgroup.subtree_control?): %w", file, parent, err)
+		}
+	}
+	if memoryBytes > 0 {
+		// only present when swap accounting is enabled
+		_ = os.WriteFile(filepath.Join(dir, "memory.swap.max"), []byte("0"), 0o644)
+	}
+	fd, err := os.Open(dir)
+	if err != nil {
+		cg.close()
+		return nil, err
+	}
+	cg.fd = fd
+	return cg, nil
+}
+
+// apply starts cmd directly inside the cgroup.
+func (c *cgroup) apply(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.UseCgroupFD = true
+	cmd.SysProcAttr.CgroupFD = int(c.fd.Fd())
+}
+
+// close kills whatever the command left running and removes the cgroup.
+func (c *cgroup) close() {
+	if c.fd != nil {
+		_ = c.fd.Close()
+	}
+	_ = os.WriteFile(filepath.Join(c.dir, "cgroup.kill"), []byte("1"), 0o644)
+	_ = os.Remove(c.dir)
+}
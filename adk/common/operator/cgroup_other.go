@@ -0,0 +1,36 @@
+//go:build !linux
+
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package operator
+
+import (
+	"errors"
+	"os/exec"
+)
+
+const cgroupSupported = false
+
+type cgroup struct{}
+
+func newCgroup(string, int64, int) (*cgroup, error) {
+	return nil, errors.New("cgroups are only supported on linux")
+}
+
+func (*cgroup) apply(*exec.Cmd) {}
+
+func (*cgroup) close() {}
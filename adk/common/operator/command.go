@@ -0,0 +1,123 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package operator
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	assignment = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*=`)
+	redirect   = regexp.MustCompile(`^(\d*|&)[<>]`)
+)
+
+// skipped are shell keywords and wrappers that run the command given as
+// their arguments, so the program after them is checked instead.
+var skipped = map[string]bool{
+	"env": true, "exec": true, "command": true, "builtin": true, "nohup": true, "time": true, "xargs": true,
+	"if": true, "then": true, "elif": true, "else": true, "fi": true,
+	"while": true, "until": true, "do": true, "done": true, "!": true, "{": true, "}": true, ":": true,
+}
+
+// programs lists the programs a shell script starts. It is a best-effort
+// lexical scan: scripts that build program names at run time ("$cmd", eval,
+// sh -c "...") can evade it, so list shells and eval in DenyCommands or use
+// AllowCommands when that matters.
+func programs(script string) []string {
+	var progs []string
+	for _, seg := range simpleCommands(script) {
+		fields := strings.Fields(seg)
+		for i := 0; i < len(fields); i++ {
+			f := strings.Trim(fields[i], `"'`)
+			if redirect.MatchString(f) {
+				if strings.TrimLeft(f, "0123456789&<>") == "" {
+					i++ // the target is the next field
+				}
+				continue
+			}
+			if f == "" || assignment.MatchString(f) || skipped[f] || strings.HasPrefix(f, "-") {
+				continue
+			}
+			progs = append(progs, path.Base(f))
+			break
+		}
+	}
+	return progs
+}
+
+// simpleCommands splits script at ; & | newlines, subshells and command
+// substitutions outside quotes.
+func simpleCommands(script string) []string {
+	var segs []string
+	var cur strings.Builder
+	flush := func() {
+		segs = append(segs, cur.String())
+		cur.Reset()
+	}
+	rs := []rune(script)
+	var quote rune
+	for i := 0; i < len(rs); i++ {
+		c := rs[i]
+		switch {
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+			}
+		case c == '\\' && i+1 < len(rs):
+			cur.WriteRune(c)
+			i++
+			c = rs[i]
+		case c == '`' || (c == '$' && i+1 < len(rs) && rs[i+1] == '('):
+			// command substitution runs code even inside double quotes
+			flush()
+			if c == '$' {
+				i++
+			}
+			continue
+		case quote == '"':
+			if c == '"' {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '&' && ((i > 0 && strings.ContainsRune("<>", rs[i-1])) || (i+1 < len(rs) && rs[i+1] == '>')):
+			// part of a redirection such as 2>&1 or &>file
+		case strings.ContainsRune(";&|\n()", c):
+			flush()
+			continue
+		}
+		cur.WriteRune(c)
+	}
+	flush()
+	return segs
+}
+
+func (l *LocalOperator) checkCommand(script string) error {
+	for _, p := range programs(script) {
+		if slices.Contains(l.conf.DenyCommands, p) {
+			return fmt.Errorf("command %q is not allowed", p)
+		}
+		if len(l.conf.AllowCommands) > 0 && !slices.Contains(l.conf.AllowCommands, p) {
+			return fmt.Errorf("command %q is not in the allow list", p)
+		}
+	}
+	return nil
+}
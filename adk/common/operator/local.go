@@ -0,0 +1,377 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package operator provides a commandline.Operator that runs on the local
+// machine but keeps file access inside the session work dir and bounds what
+// shell commands may run and how much they may consume.
+package operator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino-ext/components/tool/commandline"
+)
+
+// ErrOutsideWorkDir is returned for paths that resolve outside the session work dir.
+var ErrOutsideWorkDir = errors.New("path is outside the work dir")
+
+// DefaultDenyCommands is used when Config.DenyCommands is nil. Deny lists
+// are best-effort: programs are found by a lexical scan of the script, so
+// sh -c "...", bash -c, eval or a python one-liner can still start a denied
+// program. What actually bounds commands are the os.Root confinement of the
+// file operations, the rlimits and the cgroup; run untrusted agents in a
+// container or VM as well.
+var DefaultDenyCommands = []string{
+	"sudo", "su", "doas", "ssh", "scp", "docker", "mount", "umount",
+	"shutdown", "reboot", "halt", "poweroff", "nc", "ncat",
+}
+
+const (
+	defaultTimeout        = 5 * time.Minute
+	defaultMaxOutputBytes = 1 << 20
+	defaultMaxFileBytes   = 64 << 20
+)
+
+type Config struct {
+	// WorkDir returns the work dir of the session that ctx belongs to. Required.
+	// Files outside it can not be read or written, and commands run inside it.
+	WorkDir func(ctx context.Context) (string, error)
+
+	// Timeout bounds a single command, default 5 minutes.
+	Timeout time.Duration
+	// MaxOutputBytes bounds stdout and stderr each, default 1 MiB. Output beyond it is dropped.
+	MaxOutputBytes int
+	// MaxFileBytes bounds files read or written through the operator and,
+	// as RLIMIT_FSIZE, files written by commands. Default 64 MiB.
+	MaxFileBytes int64
+
+	// CPUSeconds sets RLIMIT_CPU of commands, 0 means unlimited.
+	CPUSeconds int
+	// MemoryBytes sets RLIMIT_AS of commands and memory.max of their cgroup, 0 means unlimited.
+	MemoryBytes int64
+	// MaxProcesses sets pids.max of the command cgroup, 0 means unlimited.
+	MaxProcesses int
+	// CgroupParent is a cgroup v2 directory delegated to this process, e.g.
+	// /sys/fs/cgroup/user.slice/user-1000.slice/user@1000.service/agents.
+	// When set, every command runs in its own child cgroup that is killed and
+	// removed afterwards. Linux only.
+	CgroupParent string
+
+	// AllowCommands, when not empty, lists the only programs commands may start.
+	AllowCommands []string
+	// DenyCommands lists programs commands may not start, DefaultDenyCommands when nil.
+	DenyCommands []string
+
+	// Env is added to the environment of commands. Unless InheritEnv is set,
+	// commands only see PATH, HOME, USER, the locale and TMPDIR pointing at
+	// the work dir, so API keys in the agent's environment do not leak to them.
+	Env        []string
+	InheritEnv bool
+}
+
+// LocalOperator implements commandline.Operator on the local machine.
+type LocalOperator struct {
+	conf Config
+}
+
+func NewLocalOperator(conf *Config) (*LocalOperator, error) {
+	if conf == nil || conf.WorkDir == nil {
+		return nil, errors.New("work dir func is required")
+	}
+	if conf.CgroupParent != "" && !cgroupSupported {
+		return nil, fmt.Errorf("cgroups are not supported on %s", runtime.GOOS)
+	}
+	c := *conf
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+	if c.MaxOutputBytes <= 0 {
+		c.MaxOutputBytes = defaultMaxOutputBytes
+	}
+	if c.MaxFileBytes <= 0 {
+		c.MaxFileBytes = defaultMaxFileBytes
+	}
+	if c.DenyCommands == nil {
+		c.DenyCommands = DefaultDenyCommands
+	}
+	return &LocalOperator{conf: c}, nil
+}
+
+func (l *LocalOperator) ReadFile(ctx context.Context, path string) (string, error) {
+	root, rel, err := l.open(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	defer root.Close()
+
+	f, err := root.Open(rel)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	b, err := io.ReadAll(io.LimitReader(f, l.conf.MaxFileBytes+1))
+	if err != nil {
+		return "", err
+	}
+	if int64(len(b)) > l.conf.MaxFileBytes {
+		return "", fmt.Errorf("%s is larger than %d bytes", path, l.conf.MaxFileBytes)
+	}
+	return string(b), nil
+}
+
+// WriteFile creates missing parent directories inside the work dir.
+func (l *LocalOperator) WriteFile(ctx context.Context, path, content string) error {
+	if int64(len(content)) > l.conf.MaxFileBytes {
+		return fmt.Errorf("content is larger than %d bytes", l.conf.MaxFileBytes)
+	}
+	root, rel, err := l.open(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+
+	if err = mkdirAll(root, filepath.Dir(rel)); err != nil {
+		return err
+	}
+	f, err := root.OpenFile(rel, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o666)
+	if err != nil {
+		return err
+	}
+	if _, err = f.WriteString(content); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (l *LocalOperator) IsDirectory(ctx context.Context, path string) (bool, error) {
+	info, err := l.stat(ctx, path)
+	if err != nil || info == nil {
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+func (l *LocalOperator) Exists(ctx context.Context, path string) (bool, error) {
+	info, err := l.stat(ctx, path)
+	return info != nil, err
+}
+
+// RunCommand runs command through the shell in the work dir. Rejected,
+// failed and timed out commands return an error starting with "internal error",
+// which the agent tools hand back to the model instead of aborting.
+func (l *LocalOperator) RunCommand(ctx context.Context, command []string) (*commandline.CommandOutput, error) {
+	wd, err := l.workDir(ctx)
+	if err != nil {
+		return nil, err
+	}
+	script := strings.Join(command, " ")
+	if err = l.checkCommand(script); err != nil {
+		return nil, fmt.Errorf("internal error:\ncommand: %v\n\nerr: %v", script, err)
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, l.conf.Timeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.CommandContext(runCtx, "cmd.exe", append([]string{"/C"}, command...)...)
+	default:
+		cmd = exec.CommandContext(runCtx, "/bin/sh", "-c", l.ulimits()+script)
+	}
+	cmd.Dir = wd
+	cmd.Env = l.env(wd)
+	cmd.WaitDelay = time.Second
+	setProcessGroup(cmd)
+
+	if l.conf.CgroupParent != "" {
+		cg, err := newCgroup(l.conf.CgroupParent, l.conf.MemoryBytes, l.conf.MaxProcesses)
+		if err != nil {
+			return nil, fmt.Errorf("create cgroup: %w", err)
+		}
+		defer cg.close()
+		cg.apply(cmd)
+	}
+
+	stdout := &limitedBuffer{max: l.conf.MaxOutputBytes}
+	stderr := &limitedBuffer{max: l.conf.MaxOutputBytes}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	err = cmd.Run()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("command timed out after %v", l.conf.Timeout)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("internal error:\ncommand: %v\n\nerr: %v\n\nexec error: %v", script, err, stderr.String())
+	}
+	return &commandline.CommandOutput{
+		Stdout: stdout.String(),
+		Stderr: stderr.String(),
+	}, nil
+}
+
+func (l *LocalOperator) workDir(ctx context.Context) (string, error) {
+	wd, err := l.conf.WorkDir(ctx)
+	if err != nil {
+		return "", err
+	}
+	if wd == "" {
+		return "", errors.New("work dir not found")
+	}
+	return filepath.Abs(wd)
+}
+
+// open returns the work dir as an os.Root and path relative to it. os.Root
+// refuses to follow symlinks or ".." out of the work dir, so the check holds
+// even if the tree changes after it.
+func (l *LocalOperator) open(ctx context.Context, path string) (*os.Root, string, error) {
+	wd, err := l.workDir(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	rel, err := relPath(wd, path)
+	if err != nil {
+		return nil, "", err
+	}
+	root, err := os.OpenRoot(wd)
+	if err != nil {
+		return nil, "", err
+	}
+	return root, rel, nil
+}
+
+// stat returns nil info for missing paths.
+func (l *LocalOperator) stat(ctx context.Context, path string) (fs.FileInfo, error) {
+	root, rel, err := l.open(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer root.Close()
+	info, err := root.Stat(rel)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return info, err
+}
+
+func relPath(wd, path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(wd, path)
+	}
+	path = filepath.Clean(path)
+	bases := []string{wd}
+	if resolved, err := filepath.EvalSymlinks(wd); err == nil && resolved != wd {
+		// the agent may have been told the resolved path, e.g. /private/var on macOS
+		bases = append(bases, resolved)
+	}
+	for _, base := range bases {
+		if rel, err := filepath.Rel(base, path); err == nil && filepath.IsLocal(rel) {
+			return rel, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrOutsideWorkDir, path)
+}
+
+func mkdirAll(root *os.Root, dir string) error {
+	if dir == "." {
+		return nil
+	}
+	if info, err := root.Stat(dir); err == nil {
+		if !info.IsDir() {
+			return fmt.Errorf("%s is not a directory", dir)
+		}
+		return nil
+	}
+	if err := mkdirAll(root, filepath.Dir(dir)); err != nil {
+		return err
+	}
+	if err := root.Mkdir(dir, 0o755); err != nil && !errors.Is(err, fs.ErrExist) {
+		return err
+	}
+	return nil
+}
+
+// ulimits prefixes the shell script with its resource limits. ulimit without
+// -S/-H sets the hard limit too, so the script can not raise them again, and a
+// limit that can not be applied aborts the command instead of running it unbounded.
+func (l *LocalOperator) ulimits() string {
+	var b strings.Builder
+	limit := func(flag string, v int64) {
+		if v > 0 {
+			fmt.Fprintf(&b, "ulimit %s %d || exit 125; ", flag, v)
+		}
+	}
+	limit("-t", int64(l.conf.CPUSeconds))
+	limit("-v", l.conf.MemoryBytes/1024)
+	limit("-f", (l.conf.MaxFileBytes+511)/512) // 512-byte blocks in POSIX sh
+	return b.String()
+}
+
+func (l *LocalOperator) env(wd string) []string {
+	var env []string
+	if l.conf.InheritEnv {
+		env = os.Environ()
+	} else {
+		for _, k := range []string{"PATH", "HOME", "USER", "LANG", "LC_ALL", "SYSTEMROOT"} {
+			if v, ok := os.LookupEnv(k); ok {
+				env = append(env, k+"="+v)
+			}
+		}
+		env = append(env, "TMPDIR="+wd)
+	}
+	return append(env, l.conf.Env...)
+}
+
+// limitedBuffer keeps the first max bytes and drops the rest, so a chatty
+// command neither blocks on a full pipe nor exhausts memory.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if room := max(b.max-b.buf.Len(), 0); n > room {
+		b.truncated += n - room
+		p = p[:room]
+	}
+	b.buf.Write(p)
+	return n, nil
+}
+
+func (b *limitedBuffer) String() string {
+	if b.truncated == 0 {
+		return b.buf.String()
+	}
+	return fmt.Sprintf("%s\n... [%d more bytes truncated]", b.buf.String(), b.truncated)
+}
+
+var _ commandline.Operator = (*LocalOperator)(nil)
@@ -0,0 +1,177 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package operator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestOperator(t *testing.T, conf Config) (*LocalOperator, string) {
+	t.Helper()
+	wd := t.TempDir()
+	conf.WorkDir = func(context.Context) (string, error) { return wd, nil }
+	op, err := NewLocalOperator(&conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return op, wd
+}
+
+func TestFiles(t *testing.T) {
+	ctx := context.Background()
+	op, wd := newTestOperator(t, Config{MaxFileBytes: 16})
+	outside := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outside, "secret"), []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(wd, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := op.WriteFile(ctx, "a/b/c.txt", "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := op.ReadFile(ctx, filepath.Join(wd, "a/b/c.txt")); err != nil || got != "hello" {
+		t.Fatalf("read = %q, %v", got, err)
+	}
+	if err := op.WriteFile(ctx, "big.txt", strings.Repeat("x", 17)); err == nil {
+		t.Fatal("wrote a file over MaxFileBytes")
+	}
+
+	for _, p := range []string{"../escape", filepath.Join(outside, "secret"), "a/../../escape"} {
+		if _, err := op.ReadFile(ctx, p); !errors.Is(err, ErrOutsideWorkDir) {
+			t.Fatalf("read %s: %v", p, err)
+		}
+		if err := op.WriteFile(ctx, p, "x"); !errors.Is(err, ErrOutsideWorkDir) {
+			t.Fatalf("write %s: %v", p, err)
+		}
+	}
+	if _, err := op.ReadFile(ctx, "link/secret"); err == nil {
+		t.Fatal("read through a symlink out of the work dir")
+	}
+	if err := op.WriteFile(ctx, "link/new", "x"); err == nil {
+		t.Fatal("wrote through a symlink out of the work dir")
+	}
+	if _, err := os.Stat(filepath.Join(outside, "new")); err == nil {
+		t.Fatal("file created outside the work dir")
+	}
+
+	for _, tc := range []struct {
+		path          string
+		exists, isDir bool
+	}{
+		{"a", true, true},
+		{"a/b/c.txt", true, false},
+		{"missing", false, false},
+		{".", true, true},
+	} {
+		exists, err := op.Exists(ctx, tc.path)
+		if err != nil || exists != tc.exists {
+			t.Fatalf("Exists(%s) = %v, %v", tc.path, exists, err)
+		}
+		isDir, err := op.IsDirectory(ctx, tc.path)
+		if err != nil || isDir != tc.isDir {
+			t.Fatalf("IsDirectory(%s) = %v, %v", tc.path, isDir, err)
+		}
+	}
+	if _, err := op.Exists(ctx, "/etc"); !errors.Is(err, ErrOutsideWorkDir) {
+		t.Fatalf("Exists(/etc): %v", err)
+	}
+}
+
+func TestRunCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses /bin/sh")
+	}
+	ctx := context.Background()
+	op, wd := newTestOperator(t, Config{
+		Timeout:        time.Second,
+		MaxOutputBytes: 8,
+		Env:            []string{"GREETING=hi"},
+	})
+	t.Setenv("AGENT_SECRET", "leaked")
+
+	out, err := op.RunCommand(ctx, []string{"echo $GREETING$AGENT_SECRET; pwd >&2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Stdout != "hi\n" || !strings.HasPrefix(out.Stderr, wd[:8]) || !strings.Contains(out.Stderr, "truncated") {
+		t.Fatalf("output = %+v", out)
+	}
+
+	start := time.Now()
+	_, err = op.RunCommand(ctx, []string{"sleep 10 & sleep 10"})
+	if err == nil || !strings.HasPrefix(err.Error(), "internal error") || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("timeout err = %v", err)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Fatalf("timeout took %v", d)
+	}
+
+	if _, err = op.RunCommand(ctx, []string{"ls | sudo tee x"}); err == nil || !strings.Contains(err.Error(), `"sudo" is not allowed`) {
+		t.Fatalf("deny err = %v", err)
+	}
+}
+
+func TestResourceLimits(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("ulimit -v is not supported everywhere")
+	}
+	ctx := context.Background()
+	op, _ := newTestOperator(t, Config{CPUSeconds: 1, MaxFileBytes: 1024, Timeout: 20 * time.Second})
+
+	if _, err := op.RunCommand(ctx, []string{"while :; do :; done"}); err == nil || strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("cpu limit err = %v", err)
+	}
+	if _, err := op.RunCommand(ctx, []string{"head -c 4096 /dev/zero > big"}); err == nil {
+		t.Fatal("wrote past the file size limit")
+	}
+	if _, err := op.RunCommand(ctx, []string{"ulimit -f unlimited"}); err == nil {
+		t.Fatal("raised the file size limit")
+	}
+}
+
+func TestPrograms(t *testing.T) {
+	for script, want := range map[string][]string{
+		`python3 -c "import sys; print(1)" data.csv`:       {"python3"},
+		`FOO=1 env /usr/bin/sudo ls`:                       {"sudo"},
+		`cat a 2>&1 | grep x && echo "$(curl x)" > out`:    {"cat", "grep", "echo", "curl"},
+		"if test -f a; then rm a; fi":                      {"test", "rm"},
+		`echo 'a; sudo b' > log 2> err; ls`:                {"echo", "ls"},
+		"find . -name '*.py' | xargs -0 wc -l &>/dev/null": {"find", "wc"},
+	} {
+		if got := programs(script); !reflect.DeepEqual(got, want) {
+			t.Errorf("programs(%s) = %v, want %v", script, got, want)
+		}
+	}
+
+	op, _ := newTestOperator(t, Config{AllowCommands: []string{"python3", "ls"}})
+	if err := op.checkCommand(`python3 -c "a = (1); b = 2" && ls`); err != nil {
+		t.Fatal(err)
+	}
+	if err := op.checkCommand("ls; rm -rf x"); err == nil {
+		t.Fatal("rm is not in the allow list")
+	}
+}
@@ -1,5 +1,7 @@
+//go:build !unix
+
 /*
- * Copyright 2025 CloudWeGo Authors
+ * Copyright 2026 CloudWeGo Authors
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -14,12 +16,8 @@
  * limitations under the License.
  */
 
-package tools
+package operator
 
-import (
-	"github.com/cloudwego/eino-ext/components/tool/commandline"
-)
+import "os/exec"
 
-type options struct {
-	op commandline.Operator
-}
+func setProcessGroup(*exec.Cmd) {}
@@ -0,0 +1,58 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package operator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/cloudwego/eino-examples/adk/common/workspace/params"
+)
+
+// NewSessionOperator returns a LocalOperator confined to the work dir stored
+// in the session under params.WorkDirSessionKey. Commands are further bounded
+// by EXCEL_AGENT_CPU_SECONDS, EXCEL_AGENT_MEMORY_LIMIT_MB and
+// EXCEL_AGENT_CGROUP_PARENT when set.
+func NewSessionOperator() (*LocalOperator, error) {
+	conf := &Config{
+		WorkDir: func(ctx context.Context) (string, error) {
+			wd, ok := params.GetTypedContextParams[string](ctx, params.WorkDirSessionKey)
+			if !ok {
+				return "", fmt.Errorf("work dir not found")
+			}
+			return wd, nil
+		},
+		CgroupParent: os.Getenv("EXCEL_AGENT_CGROUP_PARENT"),
+	}
+	if v := os.Getenv("EXCEL_AGENT_CPU_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EXCEL_AGENT_CPU_SECONDS: %w", err)
+		}
+		conf.CPUSeconds = n
+	}
+	if v := os.Getenv("EXCEL_AGENT_MEMORY_LIMIT_MB"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EXCEL_AGENT_MEMORY_LIMIT_MB: %w", err)
+		}
+		conf.MemoryBytes = n << 20
+	}
+	return NewLocalOperator(conf)
+}
@@ -0,0 +1,369 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package policy enforces a review workflow on supervisor-style multi-agent
+// systems. Instead of trusting the supervisor's prompt to consult a reviewer,
+// a Review declares that a producer's output must pass a reviewer before the
+// supervisor may finish: whenever the producer finishes, the supervisor is
+// made to hand its output to the reviewer along with the acceptance criteria,
+// the reviewer's verdict is parsed into pass or fail, and failed output goes
+// back to the producer until it passes or runs out of revisions.
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"slices"
+
+	"github.com/cloudwego/eino/adk"
+	"github.com/cloudwego/eino/adk/prebuilt/supervisor"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	defaultMaxRevisions = 2
+	stateKey            = "_policy_review_state"
+)
+
+func init() {
+	schema.RegisterName[*state]("_eino_examples_policy_state")
+}
+
+// Review requires the output of Producer to pass Reviewer before the
+// supervisor finishes.
+type Review struct {
+	// Producer and Reviewer are sub-agent names.
+	Producer string
+	Reviewer string
+	// Criteria are the acceptance criteria the output is judged against;
+	// it passes only if all of them hold.
+	Criteria []string
+	// MaxRevisions bounds how often failed output is sent back to Producer,
+	// default 2. Negative means failed output is never sent back.
+	MaxRevisions int
+}
+
+func (r *Review) maxRevisions() int {
+	switch {
+	case r.MaxRevisions < 0:
+		return 0
+	case r.MaxRevisions == 0:
+		return defaultMaxRevisions
+	}
+	return r.MaxRevisions
+}
+
+type Config struct {
+	// Supervisor and SubAgents are as in supervisor.Config.
+	Supervisor adk.Agent
+	SubAgents  []adk.Agent
+	// Reviews are checked in order; a producer may appear in one review only.
+	Reviews []Review
+}
+
+// NewSupervisor builds the supervisor system with supervisor.New and enforces
+// the reviews on it. Reviewers see the whole conversation, including the
+// output under review, and should end their review in VerdictFormat.
+func NewSupervisor(ctx context.Context, conf *Config) (adk.ResumableAgent, error) {
+	if conf == nil || conf.Supervisor == nil {
+		return nil, errors.New("supervisor is required")
+	}
+	names := map[string]bool{}
+	for _, a := range conf.SubAgents {
+		names[a.Name(ctx)] = true
+	}
+	reviews := make([]*Review, 0, len(conf.Reviews))
+	producers := map[string]*Review{}
+	reviewers := map[string]bool{}
+	for i := range conf.Reviews {
+		r := conf.Reviews[i]
+		switch {
+		case !names[r.Producer]:
+			return nil, fmt.Errorf("review %d: producer %q is not a sub-agent", i, r.Producer)
+		case !names[r.Reviewer]:
+			return nil, fmt.Errorf("review %d: reviewer %q is not a sub-agent", i, r.Reviewer)
+		case r.Producer == r.Reviewer:
+			return nil, fmt.Errorf("review %d: %q can not review its own output", i, r.Producer)
+		case producers[r.Producer] != nil:
+			return nil, fmt.Errorf("review %d: producer %q is already reviewed", i, r.Producer)
+		}
+		producers[r.Producer] = &r
+		reviewers[r.Reviewer] = true
+		reviews = append(reviews, &r)
+	}
+
+	subAgents := make([]adk.Agent, 0, len(conf.SubAgents))
+	for _, a := range conf.SubAgents {
+		name := a.Name(ctx)
+		switch {
+		case producers[name] != nil:
+			a = &watchedAgent{Agent: a, done: markProduced(name)}
+		case reviewers[name]:
+			a = &watchedAgent{Agent: a, done: recordVerdict(name, reviews), collect: true}
+		}
+		subAgents = append(subAgents, a)
+	}
+	return supervisor.New(ctx, &supervisor.Config{
+		Supervisor: &gatedSupervisor{Agent: conf.Supervisor, reviews: reviews},
+		SubAgents:  subAgents,
+	})
+}
+
+// state is kept in the session values, so it survives checkpoints.
+type state struct {
+	// Reviews is keyed by producer.
+	Reviews map[string]*reviewState
+}
+
+type reviewState struct {
+	// Pending is set when the producer finished and its output has not been
+	// reviewed yet.
+	Pending   bool
+	Revisions int
+	Verdict   *Verdict
+}
+
+func getState(ctx context.Context) *state {
+	if v, ok := adk.GetSessionValue(ctx, stateKey); ok {
+		if st, ok := v.(*state); ok {
+			return st
+		}
+	}
+	st := &state{Reviews: map[string]*reviewState{}}
+	adk.AddSessionValue(ctx, stateKey, st)
+	return st
+}
+
+func (st *state) get(producer string) *reviewState {
+	s := st.Reviews[producer]
+	if s == nil {
+		s = &reviewState{}
+		st.Reviews[producer] = s
+	}
+	return s
+}
+
+func markProduced(producer string) func(ctx context.Context, _ string) {
+	return func(ctx context.Context, _ string) {
+		s := getState(ctx).get(producer)
+		s.Pending, s.Verdict = true, nil
+	}
+}
+
+func recordVerdict(reviewer string, reviews []*Review) func(ctx context.Context, review string) {
+	return func(ctx context.Context, review string) {
+		st := getState(ctx)
+		for _, r := range reviews {
+			if s := st.get(r.Producer); r.Reviewer == reviewer && s.Pending {
+				s.Pending, s.Verdict = false, ParseVerdict(review)
+			}
+		}
+	}
+}
+
+// gatedSupervisor routes the conversation itself while a review is due, and
+// otherwise lets the supervisor decide with the outcome of earlier reviews
+// appended to its input.
+type gatedSupervisor struct {
+	adk.Agent
+	reviews []*Review
+}
+
+func (g *gatedSupervisor) Run(ctx context.Context, input *adk.AgentInput, opts ...adk.AgentRunOption) *adk.AsyncIterator[*adk.AgentEvent] {
+	st := getState(ctx)
+	for _, r := range g.reviews {
+		s := st.get(r.Producer)
+		if s.Pending {
+			return transfer(ctx, r.Reviewer, reviewRequest(r))
+		}
+		if s.Verdict != nil && !s.Verdict.Pass && s.Revisions < r.maxRevisions() {
+			s.Revisions++
+			msg := revisionRequest(r, s)
+			s.Verdict = nil
+			return transfer(ctx, r.Producer, msg)
+		}
+	}
+	if note := status(g.reviews, st); note != "" && input != nil {
+		in := *input
+		in.Messages = append(slices.Clone(input.Messages), schema.UserMessage(note))
+		input = &in
+	}
+	return g.Agent.Run(ctx, input, opts...)
+}
+
+func (g *gatedSupervisor) Resume(ctx context.Context, info *adk.ResumeInfo, opts ...adk.AgentRunOption) *adk.AsyncIterator[*adk.AgentEvent] {
+	ra, ok := g.Agent.(adk.ResumableAgent)
+	if !ok {
+		return errorIter(fmt.Errorf("supervisor %s is not resumable", g.Name(ctx)))
+	}
+	return ra.Resume(ctx, info, opts...)
+}
+
+func (g *gatedSupervisor) GetType() string {
+	if t, ok := g.Agent.(components.Typer); ok {
+		return t.GetType()
+	}
+	return "GatedSupervisor"
+}
+
+// The supervisor learns about its sub-agents, e.g. to offer transfer tools,
+// through adk.OnSubAgents.
+
+func (g *gatedSupervisor) OnSetSubAgents(ctx context.Context, subAgents []adk.Agent) error {
+	if o, ok := g.Agent.(adk.OnSubAgents); ok {
+		return o.OnSetSubAgents(ctx, subAgents)
+	}
+	return nil
+}
+
+func (g *gatedSupervisor) OnSetAsSubAgent(ctx context.Context, parent adk.Agent) error {
+	if o, ok := g.Agent.(adk.OnSubAgents); ok {
+		return o.OnSetAsSubAgent(ctx, parent)
+	}
+	return nil
+}
+
+func (g *gatedSupervisor) OnDisallowTransferToParent(ctx context.Context) error {
+	if o, ok := g.Agent.(adk.OnSubAgents); ok {
+		return o.OnDisallowTransferToParent(ctx)
+	}
+	return nil
+}
+
+// transfer answers for the supervisor with msg and hands over to dest, the
+// same way a supervisor calling the transfer tool would.
+func transfer(ctx context.Context, dest, msg string) *adk.AsyncIterator[*adk.AgentEvent] {
+	iter, gen := adk.NewAsyncIteratorPair[*adk.AgentEvent]()
+	if msg != "" {
+		gen.Send(adk.EventFromMessage(schema.AssistantMessage(msg, nil), nil, schema.Assistant, ""))
+	}
+	aMsg, tMsg := adk.GenTransferMessages(ctx, dest)
+	gen.Send(adk.EventFromMessage(aMsg, nil, schema.Assistant, ""))
+	event := adk.EventFromMessage(tMsg, nil, schema.Tool, tMsg.ToolName)
+	event.Action = adk.NewTransferToAgentAction(dest)
+	gen.Send(event)
+	gen.Close()
+	return iter
+}
+
+func errorIter(err error) *adk.AsyncIterator[*adk.AgentEvent] {
+	iter, gen := adk.NewAsyncIteratorPair[*adk.AgentEvent]()
+	gen.Send(&adk.AgentEvent{Err: err})
+	gen.Close()
+	return iter
+}
+
+// watchedAgent calls done once the agent finished its turn, that is, was not
+// interrupted, did not exit and did not fail. With collect set, done gets the
+// content of the agent's last assistant message.
+type watchedAgent struct {
+	adk.Agent
+	done    func(ctx context.Context, lastMessage string)
+	collect bool
+}
+
+func (w *watchedAgent) Run(ctx context.Context, input *adk.AgentInput, opts ...adk.AgentRunOption) *adk.AsyncIterator[*adk.AgentEvent] {
+	return w.watch(ctx, w.Agent.Run(ctx, input, opts...))
+}
+
+func (w *watchedAgent) Resume(ctx context.Context, info *adk.ResumeInfo, opts ...adk.AgentRunOption) *adk.AsyncIterator[*adk.AgentEvent] {
+	ra, ok := w.Agent.(adk.ResumableAgent)
+	if !ok {
+		return errorIter(fmt.Errorf("agent %s is not resumable", w.Name(ctx)))
+	}
+	return w.watch(ctx, ra.Resume(ctx, info, opts...))
+}
+
+func (w *watchedAgent) GetType() string {
+	if t, ok := w.Agent.(components.Typer); ok {
+		return t.GetType()
+	}
+	return "PolicyWatched"
+}
+
+func (w *watchedAgent) watch(ctx context.Context, in *adk.AsyncIterator[*adk.AgentEvent]) *adk.AsyncIterator[*adk.AgentEvent] {
+	iter, gen := adk.NewAsyncIteratorPair[*adk.AgentEvent]()
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				gen.Send(&adk.AgentEvent{Err: fmt.Errorf("panic in %s: %v\n%s", w.Name(ctx), p, debug.Stack())})
+			}
+			gen.Close()
+		}()
+
+		var (
+			last     *adk.AgentEvent
+			messages []*adk.MessageVariant
+		)
+		for {
+			event, ok := in.Next()
+			if !ok {
+				break
+			}
+			if w.collect {
+				if mv := assistantOutput(event); mv != nil {
+					messages = append(messages, keep(mv))
+				}
+			}
+			gen.Send(event)
+			last = event
+		}
+		if last == nil || last.Err != nil || last.Action != nil && (last.Action.Interrupted != nil || last.Action.Exit) {
+			return
+		}
+		w.done(ctx, lastContent(messages))
+	}()
+	return iter
+}
+
+func assistantOutput(event *adk.AgentEvent) *adk.MessageVariant {
+	if event.Output == nil || event.Output.MessageOutput == nil || event.Output.MessageOutput.Role != schema.Assistant {
+		return nil
+	}
+	return event.Output.MessageOutput
+}
+
+// keep returns a copy of mv that can be read after mv has been sent on.
+func keep(mv *adk.MessageVariant) *adk.MessageVariant {
+	if !mv.IsStreaming || mv.MessageStream == nil {
+		return &adk.MessageVariant{Message: mv.Message}
+	}
+	copies := mv.MessageStream.Copy(2)
+	mv.MessageStream = copies[0]
+	return &adk.MessageVariant{IsStreaming: true, MessageStream: copies[1]}
+}
+
+// lastContent returns the content of the last message that has any,
+// skipping transfer calls and the like.
+func lastContent(messages []*adk.MessageVariant) string {
+	var content string
+	for _, mv := range messages {
+		m := mv.Message
+		if mv.IsStreaming {
+			var err error
+			if m, err = schema.ConcatMessageStream(mv.MessageStream); err != nil {
+				continue
+			}
+		}
+		if m != nil && m.Content != "" {
+			content = m.Content
+		}
+	}
+	return content
+}
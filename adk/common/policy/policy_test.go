@@ -0,0 +1,188 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/adk"
+	"github.com/cloudwego/eino/schema"
+)
+
+// scriptAgent answers its n-th turn with reply(n, input). A reply starting
+// with "->" transfers to the named agent.
+type scriptAgent struct {
+	name      string
+	stream    bool
+	turns     int
+	lastInput []*schema.Message
+	reply     func(turn int) string
+}
+
+func (s *scriptAgent) Name(context.Context) string        { return s.name }
+func (s *scriptAgent) Description(context.Context) string { return s.name }
+
+func (s *scriptAgent) Run(ctx context.Context, input *adk.AgentInput, _ ...adk.AgentRunOption) *adk.AsyncIterator[*adk.AgentEvent] {
+	s.lastInput = input.Messages
+	reply := s.reply(s.turns)
+	s.turns++
+	if dest, ok := strings.CutPrefix(reply, "->"); ok {
+		return transfer(ctx, dest, "")
+	}
+	iter, gen := adk.NewAsyncIteratorPair[*adk.AgentEvent]()
+	if s.stream {
+		half := len(reply) / 2
+		sr := schema.StreamReaderFromArray([]*schema.Message{schema.AssistantMessage(reply[:half], nil), schema.AssistantMessage(reply[half:], nil)})
+		gen.Send(adk.EventFromMessage(nil, sr, schema.Assistant, ""))
+	} else {
+		gen.Send(adk.EventFromMessage(schema.AssistantMessage(reply, nil), nil, schema.Assistant, ""))
+	}
+	gen.Close()
+	return iter
+}
+
+func run(t *testing.T, a adk.Agent, streaming bool) []string {
+	t.Helper()
+	ctx := context.Background()
+	iter := adk.NewRunner(ctx, adk.RunnerConfig{Agent: a, EnableStreaming: streaming}).Query(ctx, "write a sort function")
+	var trace []string
+	for {
+		event, ok := iter.Next()
+		if !ok {
+			return trace
+		}
+		if event.Err != nil {
+			t.Fatal(event.Err)
+		}
+		if event.Output == nil || event.Output.MessageOutput == nil || event.Output.MessageOutput.Role != schema.Assistant {
+			continue
+		}
+		m, err := event.Output.MessageOutput.GetMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.Content != "" {
+			trace = append(trace, event.AgentName+": "+strings.SplitN(m.Content, "\n", 2)[0])
+		}
+	}
+}
+
+func newTeam(reviews func(turn int) string) (pm, coder, reviewer *scriptAgent) {
+	pm = &scriptAgent{name: "PM", reply: func(turn int) string {
+		if turn == 0 {
+			return "->Coder"
+		}
+		return "done"
+	}}
+	coder = &scriptAgent{name: "Coder", reply: func(turn int) string { return "code v" + string(rune('1'+turn)) }}
+	reviewer = &scriptAgent{name: "Reviewer", stream: true, reply: reviews}
+	return pm, coder, reviewer
+}
+
+func TestReviewLoop(t *testing.T) {
+	pm, coder, reviewer := newTeam(func(turn int) string {
+		if turn == 0 {
+			return `needs work` + "\n" + `{"verdict": "fail", "failed_criteria": ["handles empty input"], "feedback": "check len"}`
+		}
+		return "looks good\nVerdict: PASS"
+	})
+	a, err := NewSupervisor(context.Background(), &Config{
+		Supervisor: pm,
+		SubAgents:  []adk.Agent{coder, reviewer},
+		Reviews:    []Review{{Producer: "Coder", Reviewer: "Reviewer", Criteria: []string{"handles empty input"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := run(t, a, true)
+	want := []string{
+		"Coder: code v1",
+		"PM: Reviewer, review the latest output of Coder. The project can not finish until it passes.",
+		"Reviewer: needs work",
+		"PM: Reviewer rejected the output of Coder (revision 1 of 2).",
+		"Coder: code v2",
+		"PM: Reviewer, review the latest output of Coder. The project can not finish until it passes.",
+		"Reviewer: looks good",
+		"PM: done",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("trace:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if pm.turns != 2 {
+		t.Errorf("supervisor ran %d times, want 2", pm.turns)
+	}
+	if last := pm.lastInput[len(pm.lastInput)-1].Content; !strings.Contains(last, "The output of Coder passed review by Reviewer") {
+		t.Errorf("supervisor input ends with %q", last)
+	}
+	joined := ""
+	for _, m := range coder.lastInput {
+		joined += m.Content
+	}
+	if !strings.Contains(joined, "Failed criteria:\n- handles empty input") || !strings.Contains(joined, "Feedback: check len") {
+		t.Errorf("revision request not visible to coder: %q", joined)
+	}
+}
+
+func TestMaxRevisions(t *testing.T) {
+	pm, coder, reviewer := newTeam(func(int) string { return "no" })
+	a, err := NewSupervisor(context.Background(), &Config{
+		Supervisor: pm,
+		SubAgents:  []adk.Agent{coder, reviewer},
+		Reviews:    []Review{{Producer: "Coder", Reviewer: "Reviewer", MaxRevisions: 1}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	run(t, a, false)
+	if coder.turns != 2 || reviewer.turns != 2 || pm.turns != 2 {
+		t.Fatalf("turns: coder %d, reviewer %d, supervisor %d", coder.turns, reviewer.turns, pm.turns)
+	}
+	if last := pm.lastInput[len(pm.lastInput)-1].Content; !strings.Contains(last, "still fails review by Reviewer after 1 revisions") {
+		t.Errorf("supervisor input ends with %q", last)
+	}
+}
+
+func TestNewSupervisorValidation(t *testing.T) {
+	pm, coder, reviewer := newTeam(nil)
+	for _, r := range []Review{
+		{Producer: "Tester", Reviewer: "Reviewer"},
+		{Producer: "Coder", Reviewer: "QA"},
+		{Producer: "Coder", Reviewer: "Coder"},
+	} {
+		if _, err := NewSupervisor(context.Background(), &Config{Supervisor: pm, SubAgents: []adk.Agent{coder, reviewer}, Reviews: []Review{r}}); err == nil {
+			t.Errorf("review %+v accepted", r)
+		}
+	}
+}
+
+func TestParseVerdict(t *testing.T) {
+	for review, want := range map[string]Verdict{
+		`ok {"verdict": "pass"}`: {Pass: true},
+		"```json\n{\"verdict\": \"FAIL\", \"failed_criteria\": [\"a\", \"b\"], \"feedback\": \"fix {it}\"}\n```": {Failed: []string{"a", "b"}, Feedback: "fix {it}"},
+		`{"verdict": "fail"} then {"verdict": "pass"}`:                                                           {Pass: true},
+		"**Verdict:** Pass":            {Pass: true},
+		"Verdict: fail":                {Feedback: "see the review above"},
+		"I have no opinion {not json}": {Feedback: "the review did not end with a verdict; review again and state one"},
+	} {
+		if got := ParseVerdict(review); !reflect.DeepEqual(*got, want) {
+			t.Errorf("ParseVerdict(%q) = %+v, want %+v", review, *got, want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// VerdictFormat tells a reviewer how to end its review so ParseVerdict can
+// read it.
+const VerdictFormat = `End your review with the verdict as a JSON object on its own line:
+{"verdict": "pass" or "fail", "failed_criteria": ["each acceptance criterion that does not hold"], "feedback": "what must change to pass"}`
+
+// Verdict is a reviewer's structured judgement of a producer's output.
+type Verdict struct {
+	Pass bool `json:"pass"`
+	// Failed lists the acceptance criteria that do not hold.
+	Failed   []string `json:"failed_criteria,omitempty"`
+	Feedback string   `json:"feedback,omitempty"`
+}
+
+var verdictLine = regexp.MustCompile(`(?im)^[^a-z\n]*verdict[^a-z\n]*(pass|fail)`)
+
+// ParseVerdict reads the verdict from a review written in VerdictFormat. The
+// last JSON object with a "verdict" field wins; a "Verdict: PASS" line is
+// accepted as a fallback. A review without a verdict fails, so an unclear
+// review never lets output through.
+func ParseVerdict(review string) *Verdict {
+	for i := strings.LastIndexByte(review, '{'); i >= 0; i = strings.LastIndexByte(review[:i], '{') {
+		var raw struct {
+			Verdict        string   `json:"verdict"`
+			FailedCriteria []string `json:"failed_criteria"`
+			Feedback       string   `json:"feedback"`
+		}
+		if err := json.NewDecoder(strings.NewReader(review[i:])).Decode(&raw); err != nil {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(raw.Verdict)) {
+		case "pass":
+			return &Verdict{Pass: true, Feedback: raw.Feedback}
+		case "fail":
+			return &Verdict{Failed: raw.FailedCriteria, Feedback: raw.Feedback}
+		}
+	}
+	if m := verdictLine.FindAllStringSubmatch(review, -1); len(m) > 0 {
+		if strings.EqualFold(m[len(m)-1][1], "pass") {
+			return &Verdict{Pass: true}
+		}
+		return &Verdict{Feedback: "see the review above"}
+	}
+	return &Verdict{Feedback: "the review did not end with a verdict; review again and state one"}
+}
+
+func reviewRequest(r *Review) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s, review the latest output of %s. The project can not finish until it passes.\n\n", r.Reviewer, r.Producer)
+	if len(r.Criteria) == 0 {
+		b.WriteString("Acceptance criterion: the output fully and correctly does what the user asked for.\n\n")
+	} else {
+		b.WriteString("Acceptance criteria, all of which must hold:\n")
+		for i, c := range r.Criteria {
+			fmt.Fprintf(&b, "%d. %s\n", i+1, c)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(VerdictFormat)
+	return b.String()
+}
+
+func revisionRequest(r *Review, s *reviewState) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s rejected the output of %s (revision %d of %d).\n", r.Reviewer, r.Producer, s.Revisions, r.maxRevisions())
+	if len(s.Verdict.Failed) > 0 {
+		b.WriteString("Failed criteria:\n")
+		for _, c := range s.Verdict.Failed {
+			fmt.Fprintf(&b, "- %s\n", c)
+		}
+	}
+	if s.Verdict.Feedback != "" {
+		fmt.Fprintf(&b, "Feedback: %s\n", s.Verdict.Feedback)
+	}
+	fmt.Fprintf(&b, "\n%s, revise your output to address the review.", r.Producer)
+	return b.String()
+}
+
+// status tells the supervisor the outcome of finished reviews.
+func status(reviews []*Review, st *state) string {
+	var b strings.Builder
+	for _, r := range reviews {
+		s := st.Reviews[r.Producer]
+		if s == nil || s.Verdict == nil {
+			continue
+		}
+		if s.Verdict.Pass {
+			fmt.Fprintf(&b, "- The output of %s passed review by %s.\n", r.Producer, r.Reviewer)
+			continue
+		}
+		fmt.Fprintf(&b, "- The output of %s still fails review by %s after %d revisions", r.Producer, r.Reviewer, s.Revisions)
+		if len(s.Verdict.Failed) > 0 {
+			fmt.Fprintf(&b, " (failed: %s)", strings.Join(s.Verdict.Failed, "; "))
+		}
+		b.WriteString(". Tell the user it was not accepted instead of presenting it as done.\n")
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return "Workflow policy status:\n" + b.String()
+}
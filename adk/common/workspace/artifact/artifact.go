@@ -0,0 +1,372 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package artifact records the files a task produces. A Registry snapshots
+// the work dir when the task starts and, after every tool call that may write
+// files, records new and changed files with their type, size, checksum,
+// producing step and a preview. submit_result turns the registry into a
+// machine-readable Manifest.
+package artifact
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino-examples/adk/common/workspace/params"
+)
+
+// Artifact types.
+const (
+	TypeTable    = "table"
+	TypeImage    = "image"
+	TypeDocument = "document"
+	TypeCode     = "code"
+	TypeData     = "data"
+	TypeArchive  = "archive"
+	TypeOther    = "other"
+)
+
+// Changes recorded for an artifact.
+const (
+	ChangeCreated = "created"
+	ChangeUpdated = "updated"
+	ChangeInput   = "input"
+)
+
+// Artifact is a file in the work dir.
+type Artifact struct {
+	// Path is relative to the work dir.
+	Path      string    `json:"path"`
+	Type      string    `json:"type"`
+	MIMEType  string    `json:"mime_type,omitempty"`
+	Size      int64     `json:"size"`
+	SHA256    string    `json:"sha256"`
+	Change    string    `json:"change"`
+	Step      int       `json:"step,omitempty"`
+	StepDesc  string    `json:"step_desc,omitempty"`
+	Tool      string    `json:"tool,omitempty"`
+	Desc      string    `json:"desc,omitempty"`
+	Preview   *Preview  `json:"preview,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Error is set for deliverables that could not be read.
+	Error string `json:"error,omitempty"`
+}
+
+// bookkeeping files written by the agents themselves are not artifacts.
+var bookkeeping = map[string]bool{
+	"plan.md":           true,
+	"final_report.json": true,
+	"manifest.json":     true,
+}
+
+type fileState struct {
+	size    int64
+	modTime time.Time
+	sum     string
+}
+
+// Registry records the artifacts of one task. It is safe for concurrent use;
+// when tools run in parallel, a change is attributed to whichever tool scans
+// first.
+type Registry struct {
+	dir string
+
+	mu        sync.Mutex
+	known     map[string]fileState
+	artifacts map[string]*Artifact
+	step      int
+	stepDesc  string
+}
+
+// NewRegistry snapshots dir. Files already present, such as the user's
+// input files, are not artifacts until a tool changes them.
+func NewRegistry(dir string) (*Registry, error) {
+	r := &Registry{dir: dir, known: map[string]fileState{}, artifacts: map[string]*Artifact{}}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer root.Close()
+	err = r.walk(func(rel string, info fs.FileInfo) error {
+		sum, err := checksum(root, rel)
+		if err != nil {
+			return err
+		}
+		r.known[rel] = fileState{size: info.Size(), modTime: info.ModTime(), sum: sum}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// FromContext returns the registry stored under params.ArtifactsSessionKey.
+func FromContext(ctx context.Context) (*Registry, bool) {
+	return params.GetTypedContextParams[*Registry](ctx, params.ArtifactsSessionKey)
+}
+
+// Dir returns the work dir the registry watches.
+func (r *Registry) Dir() string {
+	return r.dir
+}
+
+// SetStep sets the plan step that files found by later scans are attributed
+// to. Steps are 1-based; 0 means no step.
+func (r *Registry) SetStep(step int, desc string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.step, r.stepDesc = step, desc
+}
+
+// Scan records the files created or changed since the last scan as produced
+// by tool, and forgets artifacts that were deleted. It returns the new and
+// changed artifacts.
+func (r *Registry) Scan(tool string) ([]*Artifact, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	root, err := os.OpenRoot(r.dir)
+	if err != nil {
+		return nil, err
+	}
+	defer root.Close()
+
+	seen := map[string]bool{}
+	var changed []*Artifact
+	err = r.walk(func(rel string, info fs.FileInfo) error {
+		seen[rel] = true
+		prev, known := r.known[rel]
+		if known && prev.size == info.Size() && prev.modTime.Equal(info.ModTime()) {
+			return nil
+		}
+		sum, err := checksum(root, rel)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		r.known[rel] = fileState{size: info.Size(), modTime: info.ModTime(), sum: sum}
+		if known && prev.sum == sum {
+			return nil
+		}
+		a := describe(root, rel, info, sum)
+		a.Change, a.Tool, a.Step, a.StepDesc = ChangeUpdated, tool, r.step, r.stepDesc
+		if !known {
+			a.Change = ChangeCreated
+		} else if old := r.artifacts[rel]; old != nil && old.Change == ChangeCreated {
+			a.Change = ChangeCreated
+		}
+		r.artifacts[rel] = a
+		changed = append(changed, a)
+		return nil
+	})
+	for rel := range r.known {
+		if !seen[rel] {
+			delete(r.known, rel)
+			delete(r.artifacts, rel)
+		}
+	}
+	return changed, err
+}
+
+// List returns the recorded artifacts ordered by path.
+func (r *Registry) List() []*Artifact {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]*Artifact, 0, len(r.artifacts))
+	for _, a := range r.artifacts {
+		cp := *a
+		out = append(out, &cp)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out
+}
+
+// Describe returns the artifact for path, absolute or relative to the work
+// dir. Files no tool has changed are described with Change "input".
+func (r *Registry) Describe(path string) *Artifact {
+	rel := r.rel(path)
+	r.mu.Lock()
+	a, ok := r.artifacts[rel]
+	r.mu.Unlock()
+	if ok {
+		cp := *a
+		return &cp
+	}
+	return Describe(r.dir, path)
+}
+
+func (r *Registry) rel(path string) string {
+	if filepath.IsAbs(path) {
+		if rel, err := filepath.Rel(r.dir, path); err == nil {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+// walk calls fn for every regular file under the work dir, skipping hidden
+// files and directories, __pycache__ and bookkeeping files.
+func (r *Registry) walk(fn func(rel string, info fs.FileInfo) error) error {
+	return filepath.WalkDir(r.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path != r.dir {
+				return nil
+			}
+			return err
+		}
+		if path == r.dir {
+			return nil
+		}
+		name := d.Name()
+		if strings.HasPrefix(name, ".") || name == "__pycache__" {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(r.dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if bookkeeping[rel] {
+			return nil
+		}
+		info, err := d.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return fn(rel, info)
+	})
+}
+
+// Describe builds the artifact for a file in dir that is not tracked by a
+// registry. Paths that resolve outside dir, including through symlinks, are
+// not read. Errors are reported in Artifact.Error.
+func Describe(dir, path string) *Artifact {
+	rel := path
+	if filepath.IsAbs(path) {
+		if r, err := filepath.Rel(dir, path); err == nil {
+			rel = r
+		}
+	}
+	rel = filepath.Clean(rel)
+	a := &Artifact{Path: filepath.ToSlash(rel), Type: typeOf(rel), Change: ChangeInput}
+	if !filepath.IsLocal(rel) {
+		a.Error = "path is outside the work dir"
+		return a
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		a.Error = err.Error()
+		return a
+	}
+	defer root.Close()
+	info, err := root.Stat(rel)
+	if err == nil && !info.Mode().IsRegular() {
+		err = errors.New("not a regular file")
+	}
+	if err != nil {
+		a.Error = err.Error()
+		return a
+	}
+	sum, err := checksum(root, rel)
+	if err != nil {
+		a.Error = err.Error()
+		return a
+	}
+	d := describe(root, rel, info, sum)
+	d.Change = ChangeInput
+	return d
+}
+
+// describe reads the file at rel, a slash-separated path inside root.
+func describe(root *os.Root, rel string, info fs.FileInfo, sum string) *Artifact {
+	a := &Artifact{
+		Path:      filepath.ToSlash(rel),
+		Type:      typeOf(rel),
+		MIMEType:  mimeType(root, rel),
+		Size:      info.Size(),
+		SHA256:    sum,
+		UpdatedAt: info.ModTime().UTC(),
+	}
+	a.Preview = preview(root, rel, a.Type)
+	return a
+}
+
+func checksum(root *os.Root, rel string) (string, error) {
+	f, err := root.Open(rel)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+var types = map[string]string{
+	".csv": TypeTable, ".tsv": TypeTable, ".xlsx": TypeTable, ".xlsm": TypeTable, ".xls": TypeTable,
+	".png": TypeImage, ".jpg": TypeImage, ".jpeg": TypeImage, ".gif": TypeImage, ".svg": TypeImage, ".webp": TypeImage, ".bmp": TypeImage,
+	".md": TypeDocument, ".txt": TypeDocument, ".pdf": TypeDocument, ".docx": TypeDocument, ".html": TypeDocument, ".htm": TypeDocument, ".pptx": TypeDocument,
+	".py": TypeCode, ".go": TypeCode, ".js": TypeCode, ".ts": TypeCode, ".sh": TypeCode, ".sql": TypeCode, ".ipynb": TypeCode,
+	".json": TypeData, ".jsonl": TypeData, ".xml": TypeData, ".yaml": TypeData, ".yml": TypeData, ".parquet": TypeData, ".pkl": TypeData,
+	".zip": TypeArchive, ".tar": TypeArchive, ".gz": TypeArchive, ".tgz": TypeArchive,
+}
+
+func typeOf(path string) string {
+	if t, ok := types[strings.ToLower(filepath.Ext(path))]; ok {
+		return t
+	}
+	return TypeOther
+}
+
+func mimeType(root *os.Root, rel string) string {
+	if t := mime.TypeByExtension(filepath.Ext(rel)); t != "" {
+		return t
+	}
+	f, err := root.Open(rel)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(f, head)
+	return http.DetectContentType(head[:n])
+}
@@ -0,0 +1,195 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino-examples/adk/common/workspace/generic"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/params"
+)
+
+func write(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRegistryScan(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, "input.csv", "a,b\n1,2\n")
+	write(t, dir, "keep.txt", "unchanged")
+	r, err := NewRegistry(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := r.Scan("bash"); err != nil || len(got) != 0 {
+		t.Fatalf("scan of input files = %v, %v", got, err)
+	}
+
+	r.SetStep(1, "clean data")
+	write(t, dir, "out/result.csv", "name,qty\npen,3\n")
+	write(t, dir, "plan.md", "bookkeeping")
+	write(t, dir, ".hidden/x.txt", "hidden")
+	got, err := r.Scan("python_runner")
+	if err != nil || len(got) != 1 {
+		t.Fatalf("scan = %v, %v", got, err)
+	}
+	a := got[0]
+	if a.Path != "out/result.csv" || a.Change != ChangeCreated || a.Type != TypeTable || a.Step != 1 ||
+		a.StepDesc != "clean data" || a.Tool != "python_runner" || len(a.SHA256) != 64 || a.Size != 15 {
+		t.Fatalf("artifact = %+v", a)
+	}
+	if a.Preview == nil || !strings.Contains(a.Preview.Text, "| pen | 3 |") {
+		t.Fatalf("preview = %+v", a.Preview)
+	}
+
+	// rewriting a file with the same content is not a change
+	write(t, dir, "keep.txt", "unchanged")
+	later := time.Now().Add(time.Minute)
+	_ = os.Chtimes(filepath.Join(dir, "keep.txt"), later, later)
+	if got, _ = r.Scan("bash"); len(got) != 0 {
+		t.Fatalf("unchanged rewrite recorded: %v", got)
+	}
+
+	r.SetStep(2, "update input")
+	write(t, dir, "input.csv", "a,b\n1,2\n3,4\n")
+	write(t, dir, "out/result.csv", "name,qty\npen,4\n")
+	if got, _ = r.Scan("sheet_write"); len(got) != 2 {
+		t.Fatalf("scan = %v", got)
+	}
+	list := r.List()
+	if len(list) != 2 || list[0].Path != "input.csv" || list[0].Change != ChangeUpdated || list[0].Step != 2 ||
+		list[1].Path != "out/result.csv" || list[1].Change != ChangeCreated || list[1].Step != 2 {
+		t.Fatalf("list = %+v %+v", list[0], list[1])
+	}
+
+	if err = os.Remove(filepath.Join(dir, "out/result.csv")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = r.Scan("bash"); err != nil {
+		t.Fatal(err)
+	}
+	if list = r.List(); len(list) != 1 || list[0].Path != "input.csv" {
+		t.Fatalf("deleted artifact still listed: %v", list)
+	}
+}
+
+func TestPreview(t *testing.T) {
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 40, 30))); err != nil {
+		t.Fatal(err)
+	}
+	write(t, dir, "chart.png", buf.String())
+	write(t, dir, "notes.md", "# Title\nbody\n")
+	write(t, dir, "blob.bin", "\x00\x01")
+
+	a := Describe(dir, "chart.png")
+	if a.Type != TypeImage || a.MIMEType != "image/png" || a.Preview == nil || a.Preview.Width != 40 || a.Preview.Height != 30 {
+		t.Fatalf("image = %+v %+v", a, a.Preview)
+	}
+	if a = Describe(dir, filepath.Join(dir, "notes.md")); a.Path != "notes.md" || a.Preview == nil || a.Preview.Text != "# Title\nbody\n" {
+		t.Fatalf("text = %+v", a)
+	}
+	if a = Describe(dir, "blob.bin"); a.Type != TypeOther || a.Preview != nil || a.Error != "" {
+		t.Fatalf("binary = %+v", a)
+	}
+	if a = Describe(dir, "missing.csv"); a.Error == "" {
+		t.Fatalf("missing file has no error: %+v", a)
+	}
+
+	md := Markdown([]*Artifact{Describe(dir, "chart.png"), Describe(dir, "notes.md")})
+	for _, want := range []string{"### chart.png", "![chart.png](chart.png) (40x30)", "# Title"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown %q does not contain %q", md, want)
+		}
+	}
+}
+
+func TestDescribeOutsideWorkDir(t *testing.T) {
+	dir, other := t.TempDir(), t.TempDir()
+	write(t, other, "secret.txt", "TOPSECRET")
+	if err := os.Symlink(filepath.Join(other, "secret.txt"), filepath.Join(dir, "link.txt")); err != nil {
+		t.Fatal(err)
+	}
+	escape, err := filepath.Rel(dir, filepath.Join(other, "secret.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{filepath.Join(other, "secret.txt"), escape, "link.txt"} {
+		if a := Describe(dir, path); a.Error == "" || a.Preview != nil || a.SHA256 != "" {
+			t.Errorf("%s was read: %+v", path, a)
+		}
+	}
+}
+
+func TestNewManifest(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, "input.csv", "a\n1\n")
+	r, err := NewRegistry(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := params.InitContextParams(context.Background())
+	params.AppendContextParams(ctx, map[string]interface{}{
+		params.TaskIDKey:           "task-1",
+		params.ArtifactsSessionKey: r,
+	})
+	r.SetStep(1, "summarize")
+	write(t, dir, "summary.md", "total: 1\n")
+
+	m := NewManifest(ctx, dir, &generic.SubmitResult{
+		Result: "done",
+		Files: []*generic.SubmitResultFile{
+			{Path: filepath.Join(dir, "summary.md"), Desc: "summary"},
+			{Path: "input.csv", Desc: "source"},
+			{Path: "gone.csv"},
+		},
+	})
+	if m.TaskID != "task-1" || !m.IsSuccess || m.Result != "done" || len(m.Artifacts) != 1 || len(m.Deliverables) != 3 {
+		t.Fatalf("manifest = %+v", m)
+	}
+	d := m.Deliverables
+	if d[0].Path != "summary.md" || d[0].Change != ChangeCreated || d[0].Step != 1 || d[0].Tool != "submit_result" || d[0].Desc != "summary" {
+		t.Errorf("deliverable 0 = %+v", d[0])
+	}
+	if d[1].Change != ChangeInput || d[1].Desc != "source" || d[1].Error != "" {
+		t.Errorf("deliverable 1 = %+v", d[1])
+	}
+	if d[2].Error == "" {
+		t.Errorf("deliverable 2 = %+v", d[2])
+	}
+
+	failed := false
+	if m = NewManifest(context.Background(), dir, &generic.SubmitResult{IsSuccess: &failed}); m.IsSuccess || len(m.Artifacts) != 0 {
+		t.Fatalf("manifest without registry = %+v", m)
+	}
+}
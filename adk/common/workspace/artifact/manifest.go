@@ -0,0 +1,71 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package artifact
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudwego/eino-examples/adk/common/workspace/generic"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/params"
+)
+
+// Manifest is the machine-readable result of a task, returned by
+// submit_result and saved as manifest.json in the work dir.
+type Manifest struct {
+	TaskID    string `json:"task_id,omitempty"`
+	IsSuccess bool   `json:"is_success"`
+	Result    string `json:"result"`
+	// Deliverables are the files submitted to the user, in submission order.
+	Deliverables []*Artifact `json:"deliverables"`
+	// Artifacts are all files produced during the task.
+	Artifacts []*Artifact `json:"artifacts"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// NewManifest builds the manifest for a submitted result. Without a registry
+// in ctx, only the deliverables are described.
+func NewManifest(ctx context.Context, wd string, res *generic.SubmitResult) *Manifest {
+	m := &Manifest{
+		Result:       res.Result,
+		IsSuccess:    res.IsSuccess == nil || *res.IsSuccess,
+		Deliverables: []*Artifact{},
+		Artifacts:    []*Artifact{},
+		CreatedAt:    time.Now().UTC(),
+	}
+	m.TaskID, _ = params.GetTypedContextParams[string](ctx, params.TaskIDKey)
+
+	reg, ok := FromContext(ctx)
+	if ok {
+		_, _ = reg.Scan("submit_result")
+		m.Artifacts = reg.List()
+	}
+	for _, f := range res.Files {
+		if f == nil {
+			continue
+		}
+		var a *Artifact
+		if ok {
+			a = reg.Describe(f.Path)
+		} else {
+			a = Describe(wd, f.Path)
+		}
+		a.Desc = f.Desc
+		m.Deliverables = append(m.Deliverables, a)
+	}
+	return m
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package artifact
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"image"
+	_ "image/gif" // register decoders for image previews
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/cloudwego/eino-examples/adk/common/workspace/sheet"
+)
+
+const (
+	kindText = "text"
+
+	previewRows  = 5
+	previewLines = 20
+	previewBytes = 2 << 10
+)
+
+// Preview is a short rendering of an artifact the report agent can embed.
+type Preview struct {
+	// Kind is table, image or text.
+	Kind string `json:"kind"`
+	// Text is a Markdown table for tables and the leading lines for text.
+	Text   string `json:"text,omitempty"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+}
+
+func preview(root *os.Root, rel, typ string) *Preview {
+	ext := strings.ToLower(filepath.Ext(rel))
+	switch {
+	case ext == ".csv" || ext == ".tsv":
+		return csvPreview(root, rel, ext == ".tsv")
+	case ext == ".xlsx" || ext == ".xlsm":
+		return workbookPreview(root, rel)
+	case typ == TypeImage:
+		return imagePreview(root, rel)
+	case typ == TypeDocument && ext != ".pdf" && ext != ".docx" && ext != ".pptx", typ == TypeCode, typ == TypeData && ext != ".parquet" && ext != ".pkl":
+		return textPreview(root, rel)
+	}
+	return nil
+}
+
+func csvPreview(root *os.Root, rel string, tsv bool) *Preview {
+	f, err := root.Open(rel)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	r := csv.NewReader(bufio.NewReader(f))
+	if tsv {
+		r.Comma = '\t'
+	}
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	var rows [][]string
+	for len(rows) <= previewRows {
+		rec, err := r.Read()
+		if err != nil {
+			break
+		}
+		rows = append(rows, rec)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return &Preview{Kind: TypeTable, Text: markdownTable(rows)}
+}
+
+func markdownTable(rows [][]string) string {
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	var b strings.Builder
+	for i, row := range rows {
+		b.WriteString("|")
+		for c := 0; c < width; c++ {
+			v := ""
+			if c < len(row) {
+				v = strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ").Replace(row[c])
+			}
+			b.WriteString(" " + v + " |")
+		}
+		b.WriteString("\n")
+		if i == 0 {
+			b.WriteString("|" + strings.Repeat("---|", width) + "\n")
+		}
+	}
+	return b.String()
+}
+
+func workbookPreview(root *os.Root, rel string) *Preview {
+	r, err := root.Open(rel)
+	if err != nil {
+		return nil
+	}
+	defer r.Close()
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	var parts []string
+	for _, name := range f.GetSheetList() {
+		text, err := sheet.Preview(f, name, "", previewRows+1)
+		if err != nil {
+			return nil
+		}
+		parts = append(parts, text)
+	}
+	return &Preview{Kind: TypeTable, Text: strings.Join(parts, "\n")}
+}
+
+func imagePreview(root *os.Root, rel string) *Preview {
+	p := &Preview{Kind: TypeImage}
+	f, err := root.Open(rel)
+	if err != nil {
+		return p
+	}
+	defer f.Close()
+	if cfg, _, err := image.DecodeConfig(f); err == nil {
+		p.Width, p.Height = cfg.Width, cfg.Height
+	}
+	return p
+}
+
+func textPreview(root *os.Root, rel string) *Preview {
+	f, err := root.Open(rel)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	head := make([]byte, previewBytes)
+	n, _ := io.ReadFull(f, head)
+	head = head[:n]
+	for i := 0; i < utf8.UTFMax-1 && len(head) > 0 && !utf8.Valid(head); i++ {
+		head = head[:len(head)-1] // drop a rune split by the byte limit
+	}
+	if !utf8.Valid(head) || strings.ContainsRune(string(head), 0) {
+		return nil
+	}
+	lines := strings.SplitAfter(string(head), "\n")
+	if len(lines) > previewLines {
+		lines = lines[:previewLines]
+	}
+	return &Preview{Kind: kindText, Text: strings.Join(lines, "")}
+}
+
+// Markdown renders artifacts for a prompt: one section per artifact with its
+// metadata and preview, images as Markdown image links relative to the work
+// dir.
+func Markdown(artifacts []*Artifact) string {
+	var b strings.Builder
+	for _, a := range artifacts {
+		fmt.Fprintf(&b, "### %s\n- type: %s, size: %d bytes, %s", a.Path, a.Type, a.Size, a.Change)
+		if a.Step > 0 {
+			fmt.Fprintf(&b, " in step %d", a.Step)
+		}
+		if a.Tool != "" {
+			fmt.Fprintf(&b, " by %s", a.Tool)
+		}
+		b.WriteString("\n")
+		if a.Desc != "" {
+			fmt.Fprintf(&b, "- description: %s\n", a.Desc)
+		}
+		if a.Error != "" {
+			fmt.Fprintf(&b, "- error: %s\n", a.Error)
+		}
+		if p := a.Preview; p != nil {
+			switch p.Kind {
+			case TypeImage:
+				fmt.Fprintf(&b, "- embed: ![%s](%s)", filepath.Base(a.Path), a.Path)
+				if p.Width > 0 {
+					fmt.Fprintf(&b, " (%dx%d)", p.Width, p.Height)
+				}
+				b.WriteString("\n")
+			case TypeTable:
+				fmt.Fprintf(&b, "- preview:\n\n%s\n", p.Text)
+			default:
+				fmt.Fprintf(&b, "- preview:\n```\n%s\n```\n", strings.TrimRight(p.Text, "\n"))
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generic
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestStepInstructionAlias(t *testing.T) {
+	plan := &Plan{}
+	if err := json.Unmarshal([]byte(`{"steps":[{"index":1,"desc":"a"},{"index":2,"instruction":"b"}]}`), plan); err != nil {
+		t.Fatal(err)
+	}
+	want := []Step{{Index: 1, Desc: "a"}, {Index: 2, Desc: "b"}}
+	if !reflect.DeepEqual(plan.Steps, want) {
+		t.Fatalf("steps = %+v", plan.Steps)
+	}
+}
+
+func TestListDir(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"a.csv", "sub/b.png", "sub/deep/c.txt", ".hidden/d.txt", "sub/.e"} {
+		p := filepath.Join(dir, f)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := ListDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range files {
+		got = append(got, f.Path)
+	}
+	sort.Strings(got)
+	want := []string{filepath.Join(dir, "a.csv"), filepath.Join(dir, "sub/b.png"), filepath.Join(dir, "sub/deep/c.txt")}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("files = %v, want %v", got, want)
+	}
+}
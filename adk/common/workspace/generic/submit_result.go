@@ -45,6 +45,7 @@ func (s *SubmitResult) String() string {
 	return res
 }
 
+// ListDir lists the files under dir, skipping hidden files and directories.
 func ListDir(dir string) ([]*SubmitResultFile, error) {
 	var resp []*SubmitResultFile
 
@@ -52,24 +53,18 @@ func ListDir(dir string) ([]*SubmitResultFile, error) {
 		if err != nil {
 			return err
 		}
-		if strings.HasPrefix(d.Name(), ".") {
-			return nil
-		}
 		if path == dir {
 			return nil
 		}
-		if d.IsDir() {
-			next := filepath.Join(dir, d.Name())
-			nextResp, err := ListDir(next)
-			if err != nil {
-				return err
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
 			}
-			resp = append(resp, nextResp...)
 			return nil
 		}
-		resp = append(resp, &SubmitResultFile{
-			Path: filepath.Join(filepath.Dir(dir), d.Name()),
-		})
+		if !d.IsDir() {
+			resp = append(resp, &SubmitResultFile{Path: path})
+		}
 		return nil
 	})
 	if err != nil {
@@ -21,4 +21,5 @@ const (
 	UserAllPreviewFilesSessionKey = "user_all_preview_files_session_key"
 	WorkDirSessionKey             = "work_dir_session_key"
 	TaskIDKey                     = "task_id"
+	ArtifactsSessionKey           = "artifacts_session_key"
 )
@@ -0,0 +1,874 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sheet
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// QuerySyntax documents the query language for the model.
+const QuerySyntax = `[SELECT items] [WHERE condition] [GROUP BY columns] [ORDER BY item [ASC|DESC], ...] [LIMIT n]
+- Columns are referenced by header name; quote names with spaces or keywords in backticks: ` + "`Unit Price`" + `. A column letter such as C also works when no header has that name.
+- items: *, columns, or aggregates sum(col), avg(col), min(col), max(col), count(col), count(*), each optionally followed by AS alias.
+- condition: comparisons joined by AND, OR, NOT and parentheses. Operators: = != <> < <= > >= contains, like ('%' and '_' wildcards), in (v1, v2), is empty, is not empty. contains and like ignore case.
+- Values: numbers, 'text' or "text", true/false. Dates are ISO strings like '2024-01-31' and compare as text.
+- Without SELECT all columns are returned. With aggregates and no GROUP BY the whole filtered table is one group.
+Example: SELECT Region, sum(Amount) AS total, count(*) WHERE Year >= 2024 AND Status in ('paid', 'shipped') GROUP BY Region ORDER BY total DESC LIMIT 5`
+
+// Table is a header plus typed data rows.
+type Table struct {
+	Header []string
+	Rows   [][]any
+}
+
+// Query runs a query against t.
+func Query(t *Table, query string) (*Table, error) {
+	p := &parser{table: t}
+	if err := p.init(query); err != nil {
+		return nil, err
+	}
+	q, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	return q.run(t)
+}
+
+type token struct {
+	kind int
+	text string
+}
+
+const (
+	tokEOF = iota
+	tokIdent
+	tokQuoted // `column`
+	tokString
+	tokNumber
+	tokSymbol
+)
+
+var keywords = map[string]bool{
+	"select": true, "where": true, "group": true, "by": true, "order": true, "asc": true, "desc": true,
+	"limit": true, "and": true, "or": true, "not": true, "as": true, "is": true, "empty": true,
+	"contains": true, "like": true, "in": true, "true": true, "false": true,
+}
+
+func tokenize(s string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '`' || r == '\'' || r == '"':
+			end := strings.IndexRune(s[i+1:], r)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated %c at offset %d", r, i)
+			}
+			kind := tokString
+			if r == '`' {
+				kind = tokQuoted
+			}
+			toks = append(toks, token{kind, s[i+1 : i+1+end]})
+			i += end + 2
+		case unicode.IsDigit(r) || r == '-' && i+1 < len(s) && (unicode.IsDigit(rune(s[i+1])) || s[i+1] == '.') || r == '.':
+			j := i + 1
+			for j < len(s) && (s[j] >= '0' && s[j] <= '9' || s[j] == '.' || s[j] == 'e' || s[j] == 'E') {
+				j++
+			}
+			toks = append(toks, token{tokNumber, s[i:j]})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(s) {
+				r, size := utf8.DecodeRuneInString(s[j:])
+				if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+					break
+				}
+				j += size
+			}
+			toks = append(toks, token{tokIdent, s[i:j]})
+			i = j
+		default:
+			sym := string(r)
+			if i+1 < len(s) {
+				if two := s[i : i+2]; two == "!=" || two == "<>" || two == "<=" || two == ">=" {
+					sym = two
+				}
+			}
+			if !strings.Contains("(),*", sym) && !comparisons[sym] {
+				return nil, fmt.Errorf("unexpected %q at offset %d", sym, i)
+			}
+			toks = append(toks, token{tokSymbol, sym})
+			i += len(sym)
+		}
+	}
+	return append(toks, token{kind: tokEOF}), nil
+}
+
+type parser struct {
+	table *Table
+	toks  []token
+	pos   int
+}
+
+func (p *parser) init(query string) (err error) {
+	p.toks, err = tokenize(query)
+	return err
+}
+
+func (p *parser) peek() token { return p.toks[p.pos] }
+
+func (p *parser) next() token {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// keyword consumes the given keywords if they come next.
+func (p *parser) keyword(words ...string) bool {
+	for i, w := range words {
+		t := p.toks[min(p.pos+i, len(p.toks)-1)]
+		if t.kind != tokIdent || !strings.EqualFold(t.text, w) {
+			return false
+		}
+	}
+	p.pos += len(words)
+	return true
+}
+
+func (p *parser) symbol(s string) bool {
+	if t := p.peek(); t.kind == tokSymbol && t.text == s {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(s string) error {
+	if !p.symbol(s) {
+		return fmt.Errorf("expected %q, got %q", s, p.peek().text)
+	}
+	return nil
+}
+
+type selectItem struct {
+	name string
+	agg  string // "" for a plain column
+	col  int    // -1 for count(*)
+}
+
+type orderKey struct {
+	name string
+	desc bool
+}
+
+type query struct {
+	items   []selectItem
+	all     bool
+	where   expr
+	groupBy []int
+	orderBy []orderKey
+	limit   int
+}
+
+func (p *parser) parse() (*query, error) {
+	q := &query{limit: -1}
+	var err error
+	if p.keyword("select") {
+		if p.symbol("*") {
+			q.all = true
+		} else if q.items, err = p.selectItems(); err != nil {
+			return nil, err
+		}
+	} else {
+		q.all = true
+	}
+	if p.keyword("where") {
+		if q.where, err = p.or(); err != nil {
+			return nil, err
+		}
+	}
+	if p.keyword("group", "by") {
+		for {
+			col, err := p.column()
+			if err != nil {
+				return nil, err
+			}
+			q.groupBy = append(q.groupBy, col)
+			if !p.symbol(",") {
+				break
+			}
+		}
+	}
+	if p.keyword("order", "by") {
+		for {
+			name, err := p.itemName()
+			if err != nil {
+				return nil, err
+			}
+			key := orderKey{name: name}
+			if p.keyword("desc") {
+				key.desc = true
+			} else {
+				p.keyword("asc")
+			}
+			q.orderBy = append(q.orderBy, key)
+			if !p.symbol(",") {
+				break
+			}
+		}
+	}
+	if p.keyword("limit") {
+		t := p.next()
+		n, err := strconv.Atoi(t.text)
+		if t.kind != tokNumber || err != nil || n < 0 {
+			return nil, fmt.Errorf("LIMIT needs a non-negative integer, got %q", t.text)
+		}
+		q.limit = n
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q", t.text)
+	}
+	return q, q.check(p.table)
+}
+
+var aggregates = map[string]bool{"sum": true, "avg": true, "min": true, "max": true, "count": true}
+
+func (p *parser) selectItems() ([]selectItem, error) {
+	var items []selectItem
+	for {
+		item, err := p.selectItem()
+		if err != nil {
+			return nil, err
+		}
+		if p.keyword("as") {
+			t := p.next()
+			if t.kind != tokIdent && t.kind != tokQuoted && t.kind != tokString {
+				return nil, fmt.Errorf("expected an alias after AS, got %q", t.text)
+			}
+			item.name = t.text
+		}
+		items = append(items, item)
+		if !p.symbol(",") {
+			return items, nil
+		}
+	}
+}
+
+func (p *parser) selectItem() (selectItem, error) {
+	t := p.peek()
+	if t.kind == tokIdent && aggregates[strings.ToLower(t.text)] && p.toks[p.pos+1].text == "(" {
+		p.pos += 2
+		item := selectItem{agg: strings.ToLower(t.text), col: -1}
+		if item.agg == "count" && p.symbol("*") {
+			item.name = "count(*)"
+		} else {
+			col, err := p.column()
+			if err != nil {
+				return item, err
+			}
+			item.col = col
+			item.name = fmt.Sprintf("%s(%s)", item.agg, p.table.Header[col])
+		}
+		return item, p.expect(")")
+	}
+	col, err := p.column()
+	if err != nil {
+		return selectItem{}, err
+	}
+	return selectItem{name: p.table.Header[col], col: col}, nil
+}
+
+// itemName reads an ORDER BY key: a column, alias or aggregate like sum(col).
+func (p *parser) itemName() (string, error) {
+	t := p.peek()
+	if t.kind == tokIdent && aggregates[strings.ToLower(t.text)] && p.toks[p.pos+1].text == "(" {
+		item, err := p.selectItem()
+		return item.name, err
+	}
+	p.next()
+	if t.kind != tokIdent && t.kind != tokQuoted {
+		return "", fmt.Errorf("expected a column in ORDER BY, got %q", t.text)
+	}
+	return t.text, nil
+}
+
+func (p *parser) column() (int, error) {
+	t := p.next()
+	if t.kind != tokIdent && t.kind != tokQuoted {
+		return 0, fmt.Errorf("expected a column, got %q", t.text)
+	}
+	if col := p.table.column(t.text); col >= 0 {
+		return col, nil
+	}
+	return 0, fmt.Errorf("unknown column %q, columns: %s", t.text, strings.Join(p.table.Header, ", "))
+}
+
+// column finds a header by name, falling back to a column letter.
+func (t *Table) column(name string) int {
+	for i, h := range t.Header {
+		if h == name {
+			return i
+		}
+	}
+	for i, h := range t.Header {
+		if strings.EqualFold(h, name) {
+			return i
+		}
+	}
+	if name == strings.ToUpper(name) {
+		if n, err := excelize.ColumnNameToNumber(name); err == nil && n <= len(t.Header) {
+			return n - 1
+		}
+	}
+	return -1
+}
+
+func (q *query) grouped() bool {
+	if len(q.groupBy) > 0 {
+		return true
+	}
+	for _, it := range q.items {
+		if it.agg != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *query) check(t *Table) error {
+	if !q.grouped() {
+		return nil
+	}
+	if q.all {
+		return fmt.Errorf("SELECT * cannot be combined with GROUP BY")
+	}
+	for _, it := range q.items {
+		if it.agg != "" {
+			continue
+		}
+		found := false
+		for _, g := range q.groupBy {
+			found = found || g == it.col
+		}
+		if !found {
+			return fmt.Errorf("column %q must appear in GROUP BY or be aggregated", t.Header[it.col])
+		}
+	}
+	return nil
+}
+
+func (q *query) run(t *Table) (*Table, error) {
+	var rows [][]any
+	for _, row := range t.Rows {
+		if q.where == nil || truthy(q.where.eval(row)) {
+			rows = append(rows, row)
+		}
+	}
+
+	out := &Table{}
+	if q.all {
+		q.items = nil
+		for i, h := range t.Header {
+			q.items = append(q.items, selectItem{name: h, col: i})
+		}
+	}
+	for _, it := range q.items {
+		out.Header = append(out.Header, it.name)
+	}
+
+	// source keeps the input row of each output row so ORDER BY can use
+	// columns that were not selected.
+	var source [][]any
+	if q.grouped() {
+		out.Rows = q.aggregate(rows)
+	} else {
+		for _, row := range rows {
+			o := make([]any, len(q.items))
+			for i, it := range q.items {
+				o[i] = cellAt(row, it.col)
+			}
+			out.Rows = append(out.Rows, o)
+		}
+		source = rows
+	}
+
+	if len(q.orderBy) > 0 {
+		if err := q.sort(t, out, source); err != nil {
+			return nil, err
+		}
+	}
+	if q.limit >= 0 && len(out.Rows) > q.limit {
+		out.Rows = out.Rows[:q.limit]
+	}
+	return out, nil
+}
+
+func (q *query) aggregate(rows [][]any) [][]any {
+	type group struct {
+		first []any
+		rows  [][]any
+	}
+	var order []string
+	groups := map[string]*group{}
+	for _, row := range rows {
+		var key strings.Builder
+		for _, g := range q.groupBy {
+			fmt.Fprintf(&key, "%T:%v\x00", cellAt(row, g), cellAt(row, g))
+		}
+		k := key.String()
+		if groups[k] == nil {
+			groups[k] = &group{first: row}
+			order = append(order, k)
+		}
+		groups[k].rows = append(groups[k].rows, row)
+	}
+	if len(q.groupBy) == 0 && len(order) == 0 {
+		groups[""] = &group{}
+		order = append(order, "")
+	}
+
+	var out [][]any
+	for _, k := range order {
+		g := groups[k]
+		o := make([]any, len(q.items))
+		for i, it := range q.items {
+			if it.agg == "" {
+				o[i] = cellAt(g.first, it.col)
+			} else {
+				o[i] = aggregateValues(it, g.rows)
+			}
+		}
+		out = append(out, o)
+	}
+	return out
+}
+
+func aggregateValues(it selectItem, rows [][]any) any {
+	if it.agg == "count" {
+		n := 0
+		for _, row := range rows {
+			if it.col < 0 || cellAt(row, it.col) != nil {
+				n++
+			}
+		}
+		return float64(n)
+	}
+	var (
+		result any
+		sum    float64
+		n      int
+	)
+	for _, row := range rows {
+		v := cellAt(row, it.col)
+		if v == nil {
+			continue
+		}
+		switch it.agg {
+		case "sum", "avg":
+			if f, ok := number(v); ok {
+				sum += f
+				n++
+			}
+		case "min", "max":
+			if f, ok := number(v); ok {
+				v = f
+			}
+			if result == nil {
+				result = v
+			} else if c, ok := compare(v, result); ok && (it.agg == "min" && c < 0 || it.agg == "max" && c > 0) {
+				result = v
+			}
+		}
+	}
+	switch it.agg {
+	case "sum":
+		return round(sum)
+	case "avg":
+		if n == 0 {
+			return nil
+		}
+		return round(sum / float64(n))
+	}
+	return result
+}
+
+// round drops floating point noise such as 0.30000000000000004.
+func round(f float64) float64 {
+	r, _ := strconv.ParseFloat(strconv.FormatFloat(f, 'g', 12, 64), 64)
+	return r
+}
+
+func (q *query) sort(t *Table, out *Table, source [][]any) error {
+	type key struct {
+		out  bool
+		col  int
+		desc bool
+	}
+	var keys []key
+	for _, k := range q.orderBy {
+		found := false
+		for i, h := range out.Header {
+			if strings.EqualFold(h, k.name) {
+				keys, found = append(keys, key{out: true, col: i, desc: k.desc}), true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		col := -1
+		if source != nil {
+			col = t.column(k.name)
+		}
+		if col < 0 {
+			return fmt.Errorf("cannot ORDER BY %q, it is not a selected column or alias", k.name)
+		}
+		keys = append(keys, key{col: col, desc: k.desc})
+	}
+
+	idx := make([]int, len(out.Rows))
+	for i := range idx {
+		idx[i] = i
+	}
+	value := func(k key, i int) any {
+		if k.out {
+			return out.Rows[i][k.col]
+		}
+		return cellAt(source[i], k.col)
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		for _, k := range keys {
+			va, vb := value(k, idx[a]), value(k, idx[b])
+			// empty values sort last in both directions
+			if va == nil || vb == nil {
+				if (va == nil) != (vb == nil) {
+					return vb == nil
+				}
+				continue
+			}
+			c, _ := compare(va, vb)
+			if c != 0 {
+				return c < 0 != k.desc
+			}
+		}
+		return false
+	})
+	rows := make([][]any, len(idx))
+	for i, j := range idx {
+		rows[i] = out.Rows[j]
+	}
+	out.Rows = rows
+	return nil
+}
+
+func cellAt(row []any, col int) any {
+	if col < 0 || col >= len(row) {
+		return nil
+	}
+	return row[col]
+}
+
+// number converts numbers and numeric text to float64.
+func number(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil && !math.IsNaN(f)
+	}
+	return 0, false
+}
+
+// compare orders two non-empty values: numerically when both are numbers,
+// otherwise as text.
+func compare(a, b any) (int, bool) {
+	if a == nil || b == nil {
+		return 0, a == nil && b == nil
+	}
+	if fa, ok := number(a); ok {
+		if fb, ok := number(b); ok {
+			switch {
+			case fa < fb:
+				return -1, true
+			case fa > fb:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	if ba, ok := a.(bool); ok {
+		bb, ok := b.(bool)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case ba == bb:
+			return 0, true
+		case !ba:
+			return -1, true
+		}
+		return 1, true
+	}
+	return strings.Compare(display(a), display(b)), true
+}
+
+func truthy(v any) bool {
+	b, ok := v.(bool)
+	return ok && b
+}
+
+type expr interface {
+	eval(row []any) any
+}
+
+type colExpr int
+
+func (c colExpr) eval(row []any) any { return cellAt(row, int(c)) }
+
+type litExpr struct{ v any }
+
+func (l litExpr) eval([]any) any { return l.v }
+
+type logicExpr struct {
+	op   string
+	l, r expr
+}
+
+func (e *logicExpr) eval(row []any) any {
+	if e.op == "and" {
+		return truthy(e.l.eval(row)) && truthy(e.r.eval(row))
+	}
+	return truthy(e.l.eval(row)) || truthy(e.r.eval(row))
+}
+
+type notExpr struct{ e expr }
+
+func (e notExpr) eval(row []any) any { return !truthy(e.e.eval(row)) }
+
+type cmpExpr struct {
+	op   string
+	l, r expr
+	like *regexp.Regexp
+}
+
+func (e *cmpExpr) eval(row []any) any {
+	l, r := e.l.eval(row), e.r.eval(row)
+	switch e.op {
+	case "contains":
+		return l != nil && strings.Contains(strings.ToLower(display(l)), strings.ToLower(display(r)))
+	case "like":
+		return l != nil && e.like.MatchString(display(l))
+	}
+	c, ok := compare(l, r)
+	if !ok {
+		return e.op == "!="
+	}
+	switch e.op {
+	case "=":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	}
+	return c >= 0
+}
+
+type inExpr struct {
+	e    expr
+	list []expr
+}
+
+func (e *inExpr) eval(row []any) any {
+	v := e.e.eval(row)
+	for _, item := range e.list {
+		if c, ok := compare(v, item.eval(row)); ok && c == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+type emptyExpr struct{ e expr }
+
+func (e emptyExpr) eval(row []any) any {
+	v := e.e.eval(row)
+	return v == nil || v == ""
+}
+
+func (p *parser) or() (expr, error) {
+	l, err := p.and()
+	for err == nil && p.keyword("or") {
+		var r expr
+		if r, err = p.and(); err == nil {
+			l = &logicExpr{op: "or", l: l, r: r}
+		}
+	}
+	return l, err
+}
+
+func (p *parser) and() (expr, error) {
+	l, err := p.not()
+	for err == nil && p.keyword("and") {
+		var r expr
+		if r, err = p.not(); err == nil {
+			l = &logicExpr{op: "and", l: l, r: r}
+		}
+	}
+	return l, err
+}
+
+func (p *parser) not() (expr, error) {
+	if p.keyword("not") {
+		e, err := p.not()
+		return notExpr{e}, err
+	}
+	if p.symbol("(") {
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return e, p.expect(")")
+	}
+	return p.comparison()
+}
+
+func (p *parser) comparison() (expr, error) {
+	l, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case p.keyword("is", "not", "empty"):
+		return notExpr{emptyExpr{l}}, nil
+	case p.keyword("is", "empty"):
+		return emptyExpr{l}, nil
+	case p.keyword("not", "in"):
+		in, err := p.inList(l)
+		return notExpr{in}, err
+	case p.keyword("in"):
+		return p.inList(l)
+	}
+
+	var op string
+	switch t := p.next(); {
+	case t.kind == tokSymbol && comparisons[t.text]:
+		op = t.text
+		if op == "<>" {
+			op = "!="
+		}
+	case t.kind == tokIdent && (strings.EqualFold(t.text, "contains") || strings.EqualFold(t.text, "like")):
+		op = strings.ToLower(t.text)
+	default:
+		return nil, fmt.Errorf("expected a comparison operator, got %q", t.text)
+	}
+	r, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	e := &cmpExpr{op: op, l: l, r: r}
+	if op == "like" {
+		lit, ok := r.(litExpr)
+		if !ok {
+			return nil, fmt.Errorf("LIKE needs a text pattern")
+		}
+		e.like = likePattern(display(lit.v))
+	}
+	return e, nil
+}
+
+var comparisons = map[string]bool{"=": true, "!=": true, "<>": true, "<": true, "<=": true, ">": true, ">=": true}
+
+func likePattern(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("(?is)^")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+func (p *parser) inList(l expr) (expr, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	in := &inExpr{e: l}
+	for {
+		v, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		in.list = append(in.list, v)
+		if !p.symbol(",") {
+			break
+		}
+	}
+	return in, p.expect(")")
+}
+
+func (p *parser) operand() (expr, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokString:
+		p.next()
+		return litExpr{t.text}, nil
+	case tokNumber:
+		p.next()
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", t.text)
+		}
+		return litExpr{f}, nil
+	case tokIdent:
+		if strings.EqualFold(t.text, "true") || strings.EqualFold(t.text, "false") {
+			p.next()
+			return litExpr{strings.EqualFold(t.text, "true")}, nil
+		}
+		if keywords[strings.ToLower(t.text)] && p.table.column(t.text) < 0 {
+			return nil, fmt.Errorf("unexpected keyword %q", t.text)
+		}
+	}
+	col, err := p.column()
+	return colExpr(col), err
+}
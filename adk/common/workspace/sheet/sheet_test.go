@@ -0,0 +1,256 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sheet
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/cloudwego/eino-examples/adk/common/operator"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/params"
+)
+
+var sales = &Table{
+	Header: []string{"Region", "Product", "Amount", "Date", "Paid"},
+	Rows: [][]any{
+		{"East", "Pen", 10.0, "2024-01-05", true},
+		{"West", "Pen", 20.0, "2024-02-01", false},
+		{"East", "Ink", 5.5, "2023-12-30", true},
+		{"North", "Paper", nil, "2024-03-01", true},
+		{"West", "Ink", 7.0, "2024-01-20", true},
+	},
+}
+
+func TestQuery(t *testing.T) {
+	for _, tc := range []struct {
+		query string
+		want  *Table
+	}{
+		{
+			"SELECT Region, sum(Amount) AS total, count(*) GROUP BY Region ORDER BY total DESC",
+			&Table{[]string{"Region", "total", "count(*)"}, [][]any{{"West", 27.0, 2.0}, {"East", 15.5, 2.0}, {"North", 0.0, 1.0}}},
+		},
+		{
+			"select product where Date >= '2024-01-01' and Paid = true order by Date desc limit 2",
+			&Table{[]string{"Product"}, [][]any{{"Paper"}, {"Ink"}}},
+		},
+		{
+			"SELECT Region, Amount WHERE Region in ('east', 'North') OR Product like 'p_n' ORDER BY Amount",
+			&Table{[]string{"Region", "Amount"}, [][]any{{"East", 10.0}, {"West", 20.0}, {"North", nil}}},
+		},
+		{
+			"SELECT avg(Amount), min(Date), max(`Amount`), count(Amount) WHERE NOT (Product contains 'INK')",
+			&Table{[]string{"avg(Amount)", "min(Date)", "max(Amount)", "count(Amount)"}, [][]any{{15.0, "2024-01-05", 20.0, 2.0}}},
+		},
+		{
+			"WHERE Amount is empty",
+			&Table{sales.Header, [][]any{sales.Rows[3]}},
+		},
+		{
+			"SELECT C WHERE Amount > 6 AND Amount <= 10",
+			&Table{[]string{"Amount"}, [][]any{{10.0}, {7.0}}},
+		},
+		{
+			"SELECT count(*) WHERE Region = 'South'",
+			&Table{[]string{"count(*)"}, [][]any{{0.0}}},
+		},
+	} {
+		got, err := Query(sales, tc.query)
+		if err != nil {
+			t.Errorf("%s: %v", tc.query, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s:\ngot  %v\nwant %v", tc.query, got, tc.want)
+		}
+	}
+
+	for query, want := range map[string]string{
+		"SELECT Region, Product GROUP BY Region": "must appear in GROUP BY",
+		"SELECT Price":                           "unknown column",
+		"WHERE Amount >":                         "expected a column",
+		"SELECT Region LIMIT x":                  "LIMIT",
+		"WHERE Region = 'East":                   "unterminated",
+		"SELECT sum(Amount) ORDER BY Region":     "cannot ORDER BY",
+	} {
+		if _, err := Query(sales, query); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: err = %v, want %q", query, err, want)
+		}
+	}
+}
+
+func newTestTools(t *testing.T) (context.Context, map[string]tool.InvokableTool, string) {
+	t.Helper()
+	wd := t.TempDir()
+	op, err := operator.NewLocalOperator(&operator.Config{
+		WorkDir: func(context.Context) (string, error) { return wd, nil },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := params.InitContextParams(context.Background())
+	params.AppendContextParams(ctx, map[string]interface{}{params.WorkDirSessionKey: wd})
+
+	ts := map[string]tool.InvokableTool{}
+	for _, it := range NewTools(op) {
+		info, _ := it.Info(ctx)
+		ts[info.Name] = it
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+	_ = f.SetSheetName("Sheet1", "Sales")
+	dateStyle, _ := f.NewStyle(&excelize.Style{NumFmt: 14})
+	_ = f.SetSheetRow("Sales", "A1", &[]any{"Region", "Amount", "Date", "Paid"})
+	for i, row := range [][]any{
+		{"East", 10, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), true},
+		{"West", 20.5, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), false},
+		{"East", 4, time.Date(2023, 12, 30, 0, 0, 0, 0, time.UTC), true},
+	} {
+		axis, _ := excelize.CoordinatesToCellName(1, i+2)
+		_ = f.SetSheetRow("Sales", axis, &row)
+	}
+	_ = f.SetCellStyle("Sales", "C2", "C4", dateStyle)
+	_, _ = f.NewSheet("Notes")
+	if err = f.SaveAs(filepath.Join(wd, "sales.xlsx")); err != nil {
+		t.Fatal(err)
+	}
+	return ctx, ts, wd
+}
+
+func run(t *testing.T, ctx context.Context, it tool.InvokableTool, args any) string {
+	t.Helper()
+	b, _ := json.Marshal(args)
+	out, err := it.InvokableRun(ctx, string(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestTools(t *testing.T) {
+	ctx, ts, wd := newTestTools(t)
+
+	var sheets []sheetSummary
+	if err := json.Unmarshal([]byte(run(t, ctx, ts["sheet_list"], map[string]any{"path": "sales.xlsx"})), &sheets); err != nil {
+		t.Fatal(err)
+	}
+	if len(sheets) != 2 || sheets[0].Name != "Sales" || sheets[0].Range != "A1:D4" || !sheets[0].Active || sheets[1].Rows != 0 {
+		t.Fatalf("sheets = %+v", sheets)
+	}
+
+	out := run(t, ctx, ts["sheet_preview"], map[string]any{"path": "sales.xlsx", "max_rows": 2})
+	for _, want := range []string{"range A1:D2", "| 2 | East | 10 | 2024-01-05 | TRUE |", "2 more rows"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("preview %q does not contain %q", out, want)
+		}
+	}
+
+	var cells []Cell
+	out = run(t, ctx, ts["sheet_read_cells"], map[string]any{"path": "sales.xlsx", "sheet": "sales", "cells": []string{"A2:B2", "C3", "D3", "A9"}})
+	if err := json.Unmarshal([]byte(out), &cells); err != nil {
+		t.Fatal(out)
+	}
+	want := []Cell{
+		{Cell: "A2", Type: TypeString, Value: "East"},
+		{Cell: "B2", Type: TypeNumber, Value: 10.0},
+		{Cell: "C3", Type: TypeDate, Value: "2024-02-01", Text: "02-01-24"},
+		{Cell: "D3", Type: TypeBool, Value: false, Text: "FALSE"},
+		{Cell: "A9", Type: TypeEmpty},
+	}
+	if !reflect.DeepEqual(cells, want) {
+		t.Fatalf("cells = %+v", cells)
+	}
+
+	out = run(t, ctx, ts["sheet_query"], map[string]any{
+		"path":        "sales.xlsx",
+		"query":       "SELECT Region, sum(Amount) AS total WHERE Date >= '2024-01-01' GROUP BY Region ORDER BY Region",
+		"output_path": "totals.csv",
+	})
+	if !strings.Contains(out, "2 rows") || !strings.Contains(out, "| West | 20.5 |") {
+		t.Fatalf("query output = %q", out)
+	}
+	if b, _ := os.ReadFile(filepath.Join(wd, "totals.csv")); string(b) != "Region,total\nEast,10\nWest,20.5\n" {
+		t.Fatalf("totals.csv = %q", b)
+	}
+
+	run(t, ctx, ts["sheet_query"], map[string]any{"path": "sales.xlsx", "query": "WHERE Paid = true", "output_path": "sales.xlsx", "output_sheet": "Paid"})
+	out = run(t, ctx, ts["sheet_write"], map[string]any{
+		"path":       "sales.xlsx",
+		"sheet":      "Paid",
+		"start_cell": "F1",
+		"rows":       [][]any{{"Total"}, {"=SUM(B2:B3)"}},
+		"cells":      []map[string]any{{"cell": "G1", "value": "42"}, {"cell": "G2", "value": "007"}},
+	})
+	if !strings.Contains(out, "F2 = SUM(B2:B3) -> 14") {
+		t.Fatalf("write output = %q", out)
+	}
+	out = run(t, ctx, ts["sheet_read_cells"], map[string]any{"path": "sales.xlsx", "sheet": "Paid", "cells": []string{"A3", "F2:G2", "G1"}})
+	cells = nil
+	if err := json.Unmarshal([]byte(out), &cells); err != nil {
+		t.Fatal(out)
+	}
+	want = []Cell{
+		{Cell: "A3", Type: TypeString, Value: "East"},
+		{Cell: "F2", Type: TypeNumber, Value: 14.0, Formula: "SUM(B2:B3)"},
+		{Cell: "G2", Type: TypeString, Value: "007"},
+		{Cell: "G1", Type: TypeNumber, Value: 42.0},
+	}
+	if !reflect.DeepEqual(cells, want) {
+		t.Fatalf("cells after write = %+v", cells)
+	}
+
+	out = run(t, ctx, ts["sheet_chart"], map[string]any{
+		"path": "sales.xlsx", "sheet": "Sales", "type": "col", "title": "Amount",
+		"series": []map[string]any{{"name": "B1", "categories": "A2:A4", "values": "B2:B4"}},
+	})
+	if !strings.HasPrefix(out, "added a col chart") {
+		t.Fatalf("chart output = %q", out)
+	}
+	f, err := excelize.OpenFile(filepath.Join(wd, "sales.xlsx"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.GetSheetList(); !reflect.DeepEqual(got, []string{"Sales", "Notes", "Paid"}) {
+		t.Fatalf("sheets = %v", got)
+	}
+	_ = f.Close()
+
+	run(t, ctx, ts["sheet_export_csv"], map[string]any{"path": "sales.xlsx", "range": "A1:C3", "output_path": "out/sales.csv"})
+	if b, _ := os.ReadFile(filepath.Join(wd, "out/sales.csv")); string(b) != "Region,Amount,Date\nEast,10,2024-01-05\nWest,20.5,2024-02-01\n" {
+		t.Fatalf("sales.csv = %q", b)
+	}
+
+	for name, args := range map[string]map[string]any{
+		"sheet_list":    {"path": "/etc/passwd"},
+		"sheet_preview": {"path": "sales.xlsx", "sheet": "Missing"},
+		"sheet_query":   {"path": "sales.xlsx", "query": "SELECT Price"},
+	} {
+		if out = run(t, ctx, ts[name], args); !strings.HasPrefix(out, name+" error:") {
+			t.Errorf("%s output = %q", name, out)
+		}
+	}
+}
@@ -0,0 +1,794 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sheet
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/tool/commandline"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	defaultPreviewRows = 20
+	maxPreviewRows     = 200
+	maxReadCells       = 500
+	maxResultRows      = 100
+)
+
+// NewTools returns the spreadsheet tools: sheet_list, sheet_preview,
+// sheet_read_cells, sheet_query, sheet_write, sheet_chart and
+// sheet_export_csv.
+func NewTools(op commandline.Operator) []tool.InvokableTool {
+	return []tool.InvokableTool{
+		newSheetTool(op, listInfo, list),
+		newSheetTool(op, previewInfo, preview),
+		newSheetTool(op, readCellsInfo, readCells),
+		newSheetTool(op, queryInfo, runQuery),
+		newSheetTool(op, writeInfo, write),
+		newSheetTool(op, chartInfo, addChart),
+		newSheetTool(op, exportCSVInfo, exportCSV),
+	}
+}
+
+type sheetTool[T any] struct {
+	op   commandline.Operator
+	info *schema.ToolInfo
+	run  func(ctx context.Context, op commandline.Operator, in *T) (string, error)
+}
+
+func newSheetTool[T any](op commandline.Operator, info *schema.ToolInfo,
+	run func(ctx context.Context, op commandline.Operator, in *T) (string, error)) tool.InvokableTool {
+	return &sheetTool[T]{op: op, info: info, run: run}
+}
+
+func (t *sheetTool[T]) Info(context.Context) (*schema.ToolInfo, error) {
+	return t.info, nil
+}
+
+// InvokableRun returns spreadsheet errors as output, so the model can fix the
+// path, sheet or query and try again.
+func (t *sheetTool[T]) InvokableRun(ctx context.Context, argumentsInJSON string, _ ...tool.Option) (string, error) {
+	in := new(T)
+	if err := json.Unmarshal([]byte(argumentsInJSON), in); err != nil {
+		return "", err
+	}
+	out, err := t.run(ctx, t.op, in)
+	if err != nil {
+		return fmt.Sprintf("%s error: %v", t.info.Name, err), nil
+	}
+	return out, nil
+}
+
+var (
+	pathParam = &schema.ParameterInfo{
+		Type:     schema.String,
+		Desc:     "xlsx file path, absolute or relative to the working directory",
+		Required: true,
+	}
+	sheetParam = &schema.ParameterInfo{
+		Type: schema.String,
+		Desc: "sheet name, defaults to the active sheet",
+	}
+)
+
+var listInfo = &schema.ToolInfo{
+	Name: "sheet_list",
+	Desc: "List the sheets of an xlsx workbook with their used range and size.",
+	ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+		"path": pathParam,
+	}),
+}
+
+type listInput struct {
+	Path string `json:"path"`
+}
+
+type sheetSummary struct {
+	Name    string `json:"name"`
+	Range   string `json:"range"`
+	Rows    int    `json:"rows"`
+	Columns int    `json:"columns"`
+	Active  bool   `json:"active,omitempty"`
+	Hidden  bool   `json:"hidden,omitempty"`
+}
+
+func list(ctx context.Context, op commandline.Operator, in *listInput) (string, error) {
+	w, err := openWorkbook(ctx, op, resolvePath(ctx, in.Path))
+	if err != nil {
+		return "", err
+	}
+	defer w.Close()
+	active, _ := w.sheet("")
+	var out []sheetSummary
+	for _, name := range w.GetSheetList() {
+		raw, err := w.GetRows(name, excelize.Options{RawCellValue: true})
+		if err != nil {
+			return "", err
+		}
+		s := sheetSummary{Name: name, Rows: len(raw), Active: name == active}
+		for _, row := range raw {
+			s.Columns = max(s.Columns, len(row))
+		}
+		if s.Rows > 0 && s.Columns > 0 {
+			s.Range = area{1, 1, s.Columns, s.Rows}.String()
+		}
+		visible, _ := w.GetSheetVisible(name)
+		s.Hidden = !visible
+		out = append(out, s)
+	}
+	b, err := json.Marshal(out)
+	return string(b), err
+}
+
+var previewInfo = &schema.ToolInfo{
+	Name: "sheet_preview",
+	Desc: "Show a range of a sheet as a table with row numbers and column letters. Numbers are shown unformatted and dates as ISO 8601.",
+	ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+		"path":  pathParam,
+		"sheet": sheetParam,
+		"range": {
+			Type: schema.String,
+			Desc: "cell range such as A1:F30 or A:C, defaults to the top of the used range",
+		},
+		"max_rows": {
+			Type: schema.Integer,
+			Desc: fmt.Sprintf("maximum number of rows to show, default %d, at most %d", defaultPreviewRows, maxPreviewRows),
+		},
+	}),
+}
+
+type previewInput struct {
+	Path    string `json:"path"`
+	Sheet   string `json:"sheet"`
+	Range   string `json:"range"`
+	MaxRows int    `json:"max_rows"`
+}
+
+func preview(ctx context.Context, op commandline.Operator, in *previewInput) (string, error) {
+	w, err := openWorkbook(ctx, op, resolvePath(ctx, in.Path))
+	if err != nil {
+		return "", err
+	}
+	defer w.Close()
+	return Preview(w.File, in.Sheet, in.Range, in.MaxRows)
+}
+
+// Preview renders a range of a sheet as a Markdown table. read_file uses it
+// for xlsx files.
+func Preview(f *excelize.File, sheet, ref string, maxRows int) (string, error) {
+	w := newWorkbook(f)
+	sheet, err := w.sheet(sheet)
+	if err != nil {
+		return "", err
+	}
+	if maxRows <= 0 {
+		maxRows = defaultPreviewRows
+	}
+	maxRows = min(maxRows, maxPreviewRows)
+
+	_, used, err := w.rows(sheet, 1)
+	if err != nil {
+		return "", err
+	}
+	a := used
+	if ref != "" {
+		if a, err = parseArea(ref, used.row2); err != nil {
+			return "", err
+		}
+	}
+	shown := a
+	shown.row2 = min(a.row2, a.row1+maxRows-1)
+	rows, _, err := w.rows(sheet, shown.row2)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Sheet %q, range %s", sheet, shown)
+	if len(rows) == 0 {
+		b.WriteString(" (empty sheet)\n")
+		return b.String(), nil
+	}
+	fmt.Fprintf(&b, ", used range %s\n\n", used)
+	b.WriteString("| |")
+	for c := shown.col1; c <= shown.col2; c++ {
+		name, _ := excelize.ColumnNumberToName(c)
+		b.WriteString(" " + name + " |")
+	}
+	b.WriteString("\n|---|")
+	b.WriteString(strings.Repeat("---|", shown.col2-shown.col1+1))
+	for r := shown.row1; r <= shown.row2; r++ {
+		fmt.Fprintf(&b, "\n| %d |", r)
+		for c := shown.col1; c <= shown.col2; c++ {
+			b.WriteString(" " + markdownCell(display(at(rows, c, r))) + " |")
+		}
+	}
+	b.WriteString("\n")
+	if more := a.row2 - shown.row2; more > 0 {
+		fmt.Fprintf(&b, "\n%d more rows in the range, use range or max_rows to see them.\n", more)
+	}
+	return b.String(), nil
+}
+
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", " "), "\n", " ")
+}
+
+var readCellsInfo = &schema.ToolInfo{
+	Name: "sheet_read_cells",
+	Desc: fmt.Sprintf(`Read cells with their type (empty, number, string, bool, date, error), typed value, displayed text and formula. At most %d cells per call.`, maxReadCells),
+	ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+		"path":  pathParam,
+		"sheet": sheetParam,
+		"cells": {
+			Type:     schema.Array,
+			Desc:     "cells or ranges such as B2 or A1:C3",
+			ElemInfo: &schema.ParameterInfo{Type: schema.String},
+			Required: true,
+		},
+	}),
+}
+
+type readCellsInput struct {
+	Path  string   `json:"path"`
+	Sheet string   `json:"sheet"`
+	Cells []string `json:"cells"`
+}
+
+func readCells(ctx context.Context, op commandline.Operator, in *readCellsInput) (string, error) {
+	if len(in.Cells) == 0 {
+		return "", fmt.Errorf("cells can not be empty")
+	}
+	w, err := openWorkbook(ctx, op, resolvePath(ctx, in.Path))
+	if err != nil {
+		return "", err
+	}
+	defer w.Close()
+	sheet, err := w.sheet(in.Sheet)
+	if err != nil {
+		return "", err
+	}
+	var cells []*Cell
+	for _, ref := range in.Cells {
+		a, err := parseArea(ref, 1)
+		if err != nil {
+			return "", err
+		}
+		if len(cells)+a.cells() > maxReadCells {
+			return "", fmt.Errorf("more than %d cells requested, use sheet_preview or sheet_query for large ranges", maxReadCells)
+		}
+		for r := a.row1; r <= a.row2; r++ {
+			for c := a.col1; c <= a.col2; c++ {
+				axis, _ := excelize.CoordinatesToCellName(c, r)
+				cell, err := w.cell(sheet, axis)
+				if err != nil {
+					return "", err
+				}
+				cells = append(cells, cell)
+			}
+		}
+	}
+	b, err := json.Marshal(cells)
+	return string(b), err
+}
+
+var queryInfo = &schema.ToolInfo{
+	Name: "sheet_query",
+	Desc: "Filter, aggregate and sort the rows of a sheet with a small query language, treating one row as the header. Results are deterministic and can be saved to a new sheet or a CSV file.\nSyntax:\n" + QuerySyntax,
+	ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+		"path":  pathParam,
+		"sheet": sheetParam,
+		"query": {
+			Type:     schema.String,
+			Desc:     "the query, e.g. SELECT Region, sum(Amount) GROUP BY Region",
+			Required: true,
+		},
+		"header_row": {
+			Type: schema.Integer,
+			Desc: "row number of the header, default 1; data starts on the next row",
+		},
+		"output_path": {
+			Type: schema.String,
+			Desc: "optional file to save the full result to: a .csv file, or an .xlsx file (may be the input file)",
+		},
+		"output_sheet": {
+			Type: schema.String,
+			Desc: "sheet to write the result to when output_path is an xlsx file, default Query; an existing sheet is replaced",
+		},
+	}),
+}
+
+type queryInput struct {
+	Path        string `json:"path"`
+	Sheet       string `json:"sheet"`
+	Query       string `json:"query"`
+	HeaderRow   int    `json:"header_row"`
+	OutputPath  string `json:"output_path"`
+	OutputSheet string `json:"output_sheet"`
+}
+
+func runQuery(ctx context.Context, op commandline.Operator, in *queryInput) (string, error) {
+	w, err := openWorkbook(ctx, op, resolvePath(ctx, in.Path))
+	if err != nil {
+		return "", err
+	}
+	defer w.Close()
+	sheet, err := w.sheet(in.Sheet)
+	if err != nil {
+		return "", err
+	}
+	t, err := w.table(sheet, max(in.HeaderRow, 1))
+	if err != nil {
+		return "", err
+	}
+	res, err := Query(t, in.Query)
+	if err != nil {
+		return "", fmt.Errorf("query: %w", err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d rows\n\n", len(res.Rows))
+	writeMarkdown(&b, res, maxResultRows)
+	if len(res.Rows) > maxResultRows {
+		fmt.Fprintf(&b, "\nshowing the first %d rows, set output_path to save all of them.\n", maxResultRows)
+	}
+	if in.OutputPath != "" {
+		saved, err := saveTable(ctx, op, resolvePath(ctx, in.OutputPath), in.OutputSheet, res)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "\nsaved to %s\n", saved)
+	}
+	return b.String(), nil
+}
+
+// table reads a sheet as a Table with the header on headerRow. Empty header
+// cells are named after their column letter and fully empty rows are skipped.
+func (w *workbook) table(sheet string, headerRow int) (*Table, error) {
+	rows, used, err := w.rows(sheet, 0)
+	if err != nil {
+		return nil, err
+	}
+	if headerRow > len(rows) {
+		return nil, fmt.Errorf("header row %d is past the last row %d", headerRow, len(rows))
+	}
+	t := &Table{}
+	width := used.col2
+	seen := map[string]bool{}
+	for c := 1; c <= width; c++ {
+		name := strings.TrimSpace(display(at(rows, c, headerRow)))
+		letter, _ := excelize.ColumnNumberToName(c)
+		if name == "" || seen[name] {
+			name = strings.TrimSpace(name + " " + letter)
+		}
+		seen[name] = true
+		t.Header = append(t.Header, name)
+	}
+	for _, row := range rows[headerRow:] {
+		empty := true
+		for _, v := range row {
+			empty = empty && v == nil
+		}
+		if !empty {
+			t.Rows = append(t.Rows, row)
+		}
+	}
+	return t, nil
+}
+
+func writeMarkdown(b *strings.Builder, t *Table, maxRows int) {
+	b.WriteString("|")
+	for _, h := range t.Header {
+		b.WriteString(" " + markdownCell(h) + " |")
+	}
+	b.WriteString("\n|")
+	b.WriteString(strings.Repeat("---|", len(t.Header)))
+	for i, row := range t.Rows {
+		if i == maxRows {
+			break
+		}
+		b.WriteString("\n|")
+		for c := range t.Header {
+			b.WriteString(" " + markdownCell(display(cellAt(row, c))) + " |")
+		}
+	}
+	b.WriteString("\n")
+}
+
+// saveTable writes t to a CSV file, or to a sheet of an xlsx file replacing
+// that sheet, and returns where it went.
+func saveTable(ctx context.Context, op commandline.Operator, path, sheet string, t *Table) (string, error) {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return path, op.WriteFile(ctx, path, toCSV(append([][]any{headerRow(t)}, t.Rows...)))
+	}
+	if sheet == "" {
+		sheet = "Query"
+	}
+	w, err := openOrCreateWorkbook(ctx, op, path, sheet)
+	if err != nil {
+		return "", err
+	}
+	defer w.Close()
+	if sheet, err = w.resetSheet(sheet); err != nil {
+		return "", err
+	}
+	for i, row := range append([][]any{headerRow(t)}, t.Rows...) {
+		axis, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err = w.SetSheetRow(sheet, axis, &row); err != nil {
+			return "", err
+		}
+	}
+	return fmt.Sprintf("%s, sheet %q", path, sheet), w.save(ctx, op, path)
+}
+
+func headerRow(t *Table) []any {
+	row := make([]any, len(t.Header))
+	for i, h := range t.Header {
+		row[i] = h
+	}
+	return row
+}
+
+// resetSheet replaces the sheet called name with an empty one.
+func (w *workbook) resetSheet(name string) (string, error) {
+	existing, err := w.sheet(name)
+	if err != nil {
+		_, err = w.NewSheet(name)
+		return name, err
+	}
+	tmp := existing + "~"
+	if _, err = w.NewSheet(tmp); err != nil {
+		return "", err
+	}
+	if err = w.DeleteSheet(existing); err != nil {
+		return "", err
+	}
+	return existing, w.SetSheetName(tmp, existing)
+}
+
+func toCSV(rows [][]any) string {
+	var b strings.Builder
+	cw := csv.NewWriter(&b)
+	for _, row := range rows {
+		rec := make([]string, len(row))
+		for i, v := range row {
+			rec[i] = display(v)
+		}
+		_ = cw.Write(rec)
+	}
+	cw.Flush()
+	return b.String()
+}
+
+var writeInfo = &schema.ToolInfo{
+	Name: "sheet_write",
+	Desc: `Write values and formulas to a sheet. The workbook and the sheet are created when they do not exist.
+Give single cells in "cells", and/or a block of rows starting at "start_cell". A string value starting with "=" is written as a formula.
+Numeric formula results are calculated and returned.`,
+	ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+		"path":  pathParam,
+		"sheet": sheetParam,
+		"cells": {
+			Type: schema.Array,
+			Desc: "cells to write",
+			ElemInfo: &schema.ParameterInfo{
+				Type: schema.Object,
+				SubParams: map[string]*schema.ParameterInfo{
+					"cell":    {Type: schema.String, Desc: "cell such as B2", Required: true},
+					"value":   {Type: schema.String, Desc: "number, text, true/false, or null to clear the cell; numeric text is stored as a number"},
+					"formula": {Type: schema.String, Desc: "formula such as SUM(B2:B10), overrides value"},
+				},
+			},
+		},
+		"start_cell": {
+			Type: schema.String,
+			Desc: "top left cell of rows, default A1",
+		},
+		"rows": {
+			Type:     schema.Array,
+			Desc:     "rows of values to write starting at start_cell, e.g. [[\"Region\", \"Total\"], [\"East\", 120]]",
+			ElemInfo: &schema.ParameterInfo{Type: schema.Array, ElemInfo: &schema.ParameterInfo{Type: schema.String}},
+		},
+	}),
+}
+
+type cellInput struct {
+	Cell    string `json:"cell"`
+	Value   any    `json:"value"`
+	Formula string `json:"formula"`
+}
+
+type writeInput struct {
+	Path      string      `json:"path"`
+	Sheet     string      `json:"sheet"`
+	Cells     []cellInput `json:"cells"`
+	StartCell string      `json:"start_cell"`
+	Rows      [][]any     `json:"rows"`
+}
+
+func write(ctx context.Context, op commandline.Operator, in *writeInput) (string, error) {
+	cells := in.Cells
+	if len(in.Rows) > 0 {
+		start := in.StartCell
+		if start == "" {
+			start = "A1"
+		}
+		col, row, err := excelize.CellNameToCoordinates(start)
+		if err != nil {
+			return "", err
+		}
+		for r, values := range in.Rows {
+			for c, v := range values {
+				axis, _ := excelize.CoordinatesToCellName(col+c, row+r)
+				cells = append(cells, cellInput{Cell: axis, Value: v})
+			}
+		}
+	}
+	if len(cells) == 0 {
+		return "", fmt.Errorf("nothing to write, set cells or rows")
+	}
+
+	path := resolvePath(ctx, in.Path)
+	w, err := openOrCreateWorkbook(ctx, op, path, in.Sheet)
+	if err != nil {
+		return "", err
+	}
+	defer w.Close()
+	sheet, err := w.ensureSheet(in.Sheet)
+	if err != nil {
+		return "", err
+	}
+
+	var formulas []string
+	for _, c := range cells {
+		formula := c.Formula
+		if s, ok := c.Value.(string); ok && formula == "" && strings.HasPrefix(s, "=") && len(s) > 1 {
+			formula = s
+		}
+		if formula != "" {
+			if err = w.SetCellValue(sheet, c.Cell, nil); err == nil {
+				err = w.SetCellFormula(sheet, c.Cell, strings.TrimPrefix(formula, "="))
+			}
+			formulas = append(formulas, c.Cell)
+		} else {
+			err = w.SetCellValue(sheet, c.Cell, coerce(c.Value))
+		}
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", c.Cell, err)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "wrote %d cells to sheet %q of %s\n", len(cells), sheet, path)
+	for _, axis := range formulas {
+		formula, _ := w.GetCellFormula(sheet, axis)
+		v, err := w.CalcCellValue(sheet, axis, excelize.Options{RawCellValue: true})
+		if err != nil {
+			fmt.Fprintf(&b, "%s = %s: %v\n", axis, formula, err)
+			continue
+		}
+		// cache numeric results so tools reading the file see them before
+		// Excel recalculates
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			_ = w.SetCellValue(sheet, axis, n)
+			_ = w.SetCellFormula(sheet, axis, formula)
+		}
+		fmt.Fprintf(&b, "%s = %s -> %s\n", axis, formula, v)
+	}
+	return b.String(), w.save(ctx, op, path)
+}
+
+var numericText = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?$`)
+
+// coerce stores numeric text as a number, since models often quote numbers.
+// Text with leading zeros such as "007" stays text.
+func coerce(v any) any {
+	if s, ok := v.(string); ok && numericText.MatchString(s) {
+		if n, err := strconv.ParseFloat(s, 64); err == nil {
+			return n
+		}
+	}
+	return v
+}
+
+var chartTypes = map[string]excelize.ChartType{
+	"col":      excelize.Col,
+	"column":   excelize.Col,
+	"bar":      excelize.Bar,
+	"line":     excelize.Line,
+	"pie":      excelize.Pie,
+	"doughnut": excelize.Doughnut,
+	"scatter":  excelize.Scatter,
+	"area":     excelize.Area,
+}
+
+var chartInfo = &schema.ToolInfo{
+	Name: "sheet_chart",
+	Desc: "Add a native Excel chart to a sheet. Categories and values are cell ranges; ranges without a sheet name refer to the chart's sheet.",
+	ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+		"path":  pathParam,
+		"sheet": sheetParam,
+		"cell": {
+			Type: schema.String,
+			Desc: "top left cell the chart is placed at, default H2",
+		},
+		"type": {
+			Type:     schema.String,
+			Desc:     "chart type",
+			Enum:     []string{"col", "bar", "line", "pie", "doughnut", "scatter", "area"},
+			Required: true,
+		},
+		"title": {Type: schema.String, Desc: "chart title"},
+		"series": {
+			Type:     schema.Array,
+			Required: true,
+			ElemInfo: &schema.ParameterInfo{
+				Type: schema.Object,
+				SubParams: map[string]*schema.ParameterInfo{
+					"name":       {Type: schema.String, Desc: "series name, text or a cell such as B1"},
+					"categories": {Type: schema.String, Desc: "category range such as A2:A10"},
+					"values":     {Type: schema.String, Desc: "value range such as B2:B10", Required: true},
+				},
+			},
+		},
+	}),
+}
+
+type seriesInput struct {
+	Name       string `json:"name"`
+	Categories string `json:"categories"`
+	Values     string `json:"values"`
+}
+
+type chartInput struct {
+	Path   string        `json:"path"`
+	Sheet  string        `json:"sheet"`
+	Cell   string        `json:"cell"`
+	Type   string        `json:"type"`
+	Title  string        `json:"title"`
+	Series []seriesInput `json:"series"`
+}
+
+func addChart(ctx context.Context, op commandline.Operator, in *chartInput) (string, error) {
+	typ, ok := chartTypes[strings.ToLower(in.Type)]
+	if !ok {
+		return "", fmt.Errorf("unknown chart type %q", in.Type)
+	}
+	if len(in.Series) == 0 {
+		return "", fmt.Errorf("series can not be empty")
+	}
+	path := resolvePath(ctx, in.Path)
+	w, err := openWorkbook(ctx, op, path)
+	if err != nil {
+		return "", err
+	}
+	defer w.Close()
+	sheet, err := w.sheet(in.Sheet)
+	if err != nil {
+		return "", err
+	}
+	chart := &excelize.Chart{Type: typ}
+	if in.Title != "" {
+		chart.Title = []excelize.RichTextRun{{Text: in.Title}}
+	}
+	for _, s := range in.Series {
+		if s.Values == "" {
+			return "", fmt.Errorf("series values can not be empty")
+		}
+		series := excelize.ChartSeries{Name: s.Name, Values: qualify(sheet, s.Values)}
+		if s.Categories != "" {
+			series.Categories = qualify(sheet, s.Categories)
+		}
+		if _, _, err := excelize.CellNameToCoordinates(strings.ReplaceAll(s.Name, "$", "")); err == nil {
+			series.Name = qualify(sheet, s.Name)
+		}
+		chart.Series = append(chart.Series, series)
+	}
+	cell := in.Cell
+	if cell == "" {
+		cell = "H2"
+	}
+	if err = w.AddChart(sheet, cell, chart); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("added a %s chart with %d series at %s!%s", in.Type, len(chart.Series), sheet, cell), w.save(ctx, op, path)
+}
+
+// qualify turns "A2:A10" into "'Sheet 1'!$A$2:$A$10".
+func qualify(sheet, ref string) string {
+	if strings.Contains(ref, "!") {
+		return ref
+	}
+	var parts []string
+	for _, p := range strings.Split(strings.ReplaceAll(ref, "$", ""), ":") {
+		if col, row, err := excelize.SplitCellName(p); err == nil {
+			p = "$" + col + "$" + strconv.Itoa(row)
+		}
+		parts = append(parts, p)
+	}
+	return "'" + strings.ReplaceAll(sheet, "'", "''") + "'!" + strings.Join(parts, ":")
+}
+
+var exportCSVInfo = &schema.ToolInfo{
+	Name: "sheet_export_csv",
+	Desc: "Export a sheet, or a range of it, to a CSV file. Numbers are written unformatted and dates as ISO 8601.",
+	ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+		"path":  pathParam,
+		"sheet": sheetParam,
+		"range": {Type: schema.String, Desc: "cell range such as A1:F30, defaults to the used range"},
+		"output_path": {
+			Type:     schema.String,
+			Desc:     "CSV file to write",
+			Required: true,
+		},
+	}),
+}
+
+type exportCSVInput struct {
+	Path       string `json:"path"`
+	Sheet      string `json:"sheet"`
+	Range      string `json:"range"`
+	OutputPath string `json:"output_path"`
+}
+
+func exportCSV(ctx context.Context, op commandline.Operator, in *exportCSVInput) (string, error) {
+	if in.OutputPath == "" {
+		return "", fmt.Errorf("output_path can not be empty")
+	}
+	w, err := openWorkbook(ctx, op, resolvePath(ctx, in.Path))
+	if err != nil {
+		return "", err
+	}
+	defer w.Close()
+	sheet, err := w.sheet(in.Sheet)
+	if err != nil {
+		return "", err
+	}
+	rows, a, err := w.rows(sheet, 0)
+	if err != nil {
+		return "", err
+	}
+	if in.Range != "" {
+		if a, err = parseArea(in.Range, a.row2); err != nil {
+			return "", err
+		}
+	}
+	var out [][]any
+	for r := a.row1; r <= a.row2; r++ {
+		row := make([]any, 0, a.col2-a.col1+1)
+		for c := a.col1; c <= a.col2; c++ {
+			row = append(row, at(rows, c, r))
+		}
+		out = append(out, row)
+	}
+	path := resolvePath(ctx, in.OutputPath)
+	if err = op.WriteFile(ctx, path, toCSV(out)); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("exported %s!%s (%d rows) to %s", sheet, a, len(out), path), nil
+}
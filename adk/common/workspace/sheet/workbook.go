@@ -0,0 +1,378 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sheet implements Go-native spreadsheet tools on top of excelize, so
+// agents can inspect, query and edit xlsx workbooks without generating Python.
+// Workbooks are read and written through a commandline.Operator, which keeps
+// file access inside the operator's workspace.
+package sheet
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino-ext/components/tool/commandline"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/cloudwego/eino-examples/adk/common/workspace/params"
+)
+
+// Cell types reported by the tools.
+const (
+	TypeEmpty  = "empty"
+	TypeNumber = "number"
+	TypeString = "string"
+	TypeBool   = "bool"
+	TypeDate   = "date"
+	TypeError  = "error"
+)
+
+// Cell is a typed cell value. Value holds a float64, bool or string; dates are
+// ISO 8601 strings so they compare and sort correctly.
+type Cell struct {
+	Cell    string `json:"cell"`
+	Type    string `json:"type"`
+	Value   any    `json:"value,omitempty"`
+	Text    string `json:"text,omitempty"`
+	Formula string `json:"formula,omitempty"`
+}
+
+type workbook struct {
+	*excelize.File
+	date1904   bool
+	dateStyles map[int]bool
+}
+
+// resolvePath resolves relative paths against the session work dir, the same
+// way edit_file and read_file do.
+func resolvePath(ctx context.Context, path string) string {
+	if !filepath.IsAbs(path) {
+		if wd, ok := params.GetTypedContextParams[string](ctx, params.WorkDirSessionKey); ok {
+			return filepath.Join(wd, path)
+		}
+	}
+	return path
+}
+
+func openWorkbook(ctx context.Context, op commandline.Operator, path string) (*workbook, error) {
+	content, err := op.ReadFile(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := excelize.OpenReader(strings.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", filepath.Base(path), err)
+	}
+	return newWorkbook(f), nil
+}
+
+// openOrCreateWorkbook opens path, or starts a new workbook whose only sheet
+// is named sheet when path does not exist yet.
+func openOrCreateWorkbook(ctx context.Context, op commandline.Operator, path, sheet string) (*workbook, error) {
+	exists, err := op.Exists(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return openWorkbook(ctx, op, path)
+	}
+	f := excelize.NewFile()
+	if sheet != "" {
+		if err = f.SetSheetName(f.GetSheetName(0), sheet); err != nil {
+			return nil, err
+		}
+	}
+	return newWorkbook(f), nil
+}
+
+func newWorkbook(f *excelize.File) *workbook {
+	w := &workbook{File: f, dateStyles: map[int]bool{}}
+	if props, err := f.GetWorkbookProps(); err == nil && props.Date1904 != nil {
+		w.date1904 = *props.Date1904
+	}
+	return w
+}
+
+func (w *workbook) save(ctx context.Context, op commandline.Operator, path string) error {
+	var buf bytes.Buffer
+	if _, err := w.WriteTo(&buf); err != nil {
+		return err
+	}
+	return op.WriteFile(ctx, path, buf.String())
+}
+
+// sheet returns the sheet called name, matched case-insensitively, or the
+// active sheet when name is empty.
+func (w *workbook) sheet(name string) (string, error) {
+	sheets := w.GetSheetList()
+	if name == "" {
+		if idx := w.GetActiveSheetIndex(); idx >= 0 && idx < len(sheets) {
+			return sheets[idx], nil
+		}
+		if len(sheets) > 0 {
+			return sheets[0], nil
+		}
+		return "", fmt.Errorf("workbook has no sheets")
+	}
+	for _, s := range sheets {
+		if strings.EqualFold(s, name) {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("sheet %q not found, sheets: %s", name, strings.Join(sheets, ", "))
+}
+
+// ensureSheet returns the sheet called name, creating it when missing.
+func (w *workbook) ensureSheet(name string) (string, error) {
+	if name == "" {
+		return w.sheet("")
+	}
+	if s, err := w.sheet(name); err == nil {
+		return s, nil
+	}
+	if _, err := w.NewSheet(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// cell reads one cell with its type, display text and formula.
+func (w *workbook) cell(sheet, axis string) (*Cell, error) {
+	raw, err := w.GetCellValue(sheet, axis, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, err
+	}
+	c := &Cell{Cell: axis}
+	c.Type, c.Value = w.typed(sheet, axis, raw)
+	if c.Text, err = w.GetCellValue(sheet, axis); err != nil {
+		return nil, err
+	}
+	if c.Text == raw || c.Type == TypeString {
+		c.Text = ""
+	}
+	if c.Formula, err = w.GetCellFormula(sheet, axis); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// typed converts the raw value of a cell into a typed value.
+func (w *workbook) typed(sheet, axis, raw string) (string, any) {
+	if raw == "" {
+		return TypeEmpty, nil
+	}
+	ct, _ := w.GetCellType(sheet, axis)
+	switch ct {
+	case excelize.CellTypeBool:
+		return TypeBool, raw == "1" || strings.EqualFold(raw, "true")
+	case excelize.CellTypeError:
+		return TypeError, raw
+	case excelize.CellTypeSharedString, excelize.CellTypeInlineString:
+		return TypeString, raw
+	case excelize.CellTypeFormula:
+		// excelize marks every formula it writes as a string result
+		if n, err := strconv.ParseFloat(raw, 64); err == nil {
+			return TypeNumber, n
+		}
+		return TypeString, raw
+	case excelize.CellTypeDate:
+		if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			return TypeDate, formatTime(t)
+		}
+		return TypeDate, raw
+	}
+	n, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return TypeString, raw
+	}
+	if w.isDate(sheet, axis) {
+		if t, err := excelize.ExcelDateToTime(n, w.date1904); err == nil {
+			return TypeDate, formatTime(t)
+		}
+	}
+	return TypeNumber, n
+}
+
+func (w *workbook) isDate(sheet, axis string) bool {
+	id, err := w.GetCellStyle(sheet, axis)
+	if err != nil || id == 0 {
+		return false
+	}
+	if v, ok := w.dateStyles[id]; ok {
+		return v
+	}
+	style, err := w.GetStyle(id)
+	v := err == nil && isDateFormat(style)
+	w.dateStyles[id] = v
+	return v
+}
+
+func isDateFormat(s *excelize.Style) bool {
+	if s.CustomNumFmt != nil {
+		return isDatePattern(*s.CustomNumFmt)
+	}
+	n := s.NumFmt
+	return n >= 14 && n <= 22 || n >= 27 && n <= 36 || n >= 45 && n <= 47 || n >= 50 && n <= 58
+}
+
+// isDatePattern reports whether a number format code contains date or time
+// placeholders outside quoted text, escapes and [...] sections.
+func isDatePattern(code string) bool {
+	var quoted, bracket, escaped bool
+	for _, r := range strings.ToLower(code) {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted:
+			quoted = r != '"'
+		case bracket:
+			bracket = r != ']'
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = true
+		case r == '[':
+			bracket = true
+		case strings.ContainsRune("ymdh", r):
+			return true
+		}
+	}
+	return false
+}
+
+func formatTime(t time.Time) string {
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+// rows reads the typed values of a sheet, up to lastRow when it is positive,
+// and the used area of the whole sheet. Rows are ragged: trailing empty cells
+// are not included.
+func (w *workbook) rows(sheet string, lastRow int) ([][]any, area, error) {
+	raw, err := w.GetRows(sheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, area{}, err
+	}
+	used := area{col1: 1, row1: 1, col2: 1, row2: max(len(raw), 1)}
+	for _, row := range raw {
+		used.col2 = max(used.col2, len(row))
+	}
+	if lastRow > 0 && lastRow < len(raw) {
+		raw = raw[:lastRow]
+	}
+	out := make([][]any, len(raw))
+	for r, row := range raw {
+		out[r] = make([]any, len(row))
+		for c, v := range row {
+			if v == "" {
+				continue
+			}
+			axis, _ := excelize.CoordinatesToCellName(c+1, r+1)
+			_, out[r][c] = w.typed(sheet, axis, v)
+		}
+	}
+	return out, used, nil
+}
+
+// area is an inclusive, 1-based cell range.
+type area struct {
+	col1, row1, col2, row2 int
+}
+
+func (a area) String() string {
+	from, _ := excelize.CoordinatesToCellName(a.col1, a.row1)
+	to, _ := excelize.CoordinatesToCellName(a.col2, a.row2)
+	if from == to {
+		return from
+	}
+	return from + ":" + to
+}
+
+func (a area) cells() int {
+	return (a.col2 - a.col1 + 1) * (a.row2 - a.row1 + 1)
+}
+
+// parseArea parses "B2", "A1:C10" or whole columns like "A:C". Whole columns
+// end at maxRow.
+func parseArea(ref string, maxRow int) (area, error) {
+	ref = strings.ReplaceAll(strings.TrimSpace(ref), "$", "")
+	if i := strings.LastIndex(ref, "!"); i >= 0 {
+		ref = ref[i+1:]
+	}
+	from, to, isRange := strings.Cut(ref, ":")
+	if !isRange {
+		to = from
+	}
+	var a area
+	var err error
+	if a.col1, a.row1, err = cellOrColumn(from, 1); err != nil {
+		return a, err
+	}
+	if a.col2, a.row2, err = cellOrColumn(to, max(maxRow, 1)); err != nil {
+		return a, err
+	}
+	if a.col1 > a.col2 {
+		a.col1, a.col2 = a.col2, a.col1
+	}
+	if a.row1 > a.row2 {
+		a.row1, a.row2 = a.row2, a.row1
+	}
+	return a, nil
+}
+
+func cellOrColumn(ref string, row int) (int, int, error) {
+	if col, err := excelize.ColumnNameToNumber(ref); err == nil {
+		return col, row, nil
+	}
+	col, r, err := excelize.CellNameToCoordinates(ref)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid cell reference %q", ref)
+	}
+	return col, r, nil
+}
+
+func at(rows [][]any, col, row int) any {
+	if row < 1 || row > len(rows) || col < 1 || col > len(rows[row-1]) {
+		return nil
+	}
+	return rows[row-1][col-1]
+}
+
+// display formats a typed value for tables and CSV.
+func display(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -21,10 +21,11 @@ import (
 	"encoding/json"
 	"strings"
 
-	"github.com/cloudwego/eino-examples/adk/multiagent/deep/utils"
 	"github.com/cloudwego/eino-ext/components/tool/commandline"
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/adk/common/workspace/utils"
 )
 
 var bashToolInfo = &schema.ToolInfo{
@@ -24,13 +24,13 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/cloudwego/eino-examples/adk/multiagent/deep/utils"
 	"github.com/cloudwego/eino-ext/components/tool/commandline"
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/schema"
 	jsoniter "github.com/json-iterator/go"
 
-	"github.com/cloudwego/eino-examples/adk/multiagent/deep/params"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/params"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/utils"
 )
 
 var toolPythonRunnerInfo = &schema.ToolInfo{
@@ -20,18 +20,23 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"path/filepath"
 	"strings"
 
-	"github.com/cloudwego/eino-examples/adk/multiagent/deep/utils"
 	"github.com/cloudwego/eino-ext/components/tool/commandline"
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/schema"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/cloudwego/eino-examples/adk/common/workspace/params"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/sheet"
 )
 
 var readFileToolInfo = &schema.ToolInfo{
 	Name: "read_file",
 	Desc: `This tool is used for reading file content, with parameters including the file path, starting line, and the number of lines to read. Content will be truncated if it is too long.  
-For xlsx files, each sheet's information will be returned sequentially upon a single call. If multiple sheets' information is needed, only one call is required. The call will return the headers, merged cell information, and the first n_rows of data for each sheet.`,
+For xlsx files, each sheet's information will be returned sequentially upon a single call. If multiple sheets' information is needed, only one call is required. The call will return the used range and the first n_rows of data for each sheet.`,
 	ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
 		"path": {
 			Type:     schema.String,
@@ -76,21 +81,67 @@ func (r *readFile) InvokableRun(ctx context.Context, argumentsInJSON string, opt
 	if input.Path == "" {
 		return "path can not be empty", nil
 	}
+	if !filepath.IsAbs(input.Path) {
+		wd, ok := params.GetTypedContextParams[string](ctx, params.WorkDirSessionKey)
+		if ok {
+			input.Path = filepath.Join(wd, input.Path)
+		}
+	}
 	if input.StartRow <= 0 {
 		input.StartRow = 1
 	}
-	if input.NRows <= 0 {
+	o := tool.GetImplSpecificOptions(&options{op: r.op}, opts...)
+	if ext := strings.ToLower(filepath.Ext(input.Path)); ext == ".xlsx" || ext == ".xlsm" {
+		if input.NRows == 0 {
+			input.NRows = 10
+		}
+		return readWorkbook(ctx, o.op, input.Path, input.NRows)
+	}
+	if input.NRows == 0 {
 		input.NRows = 20
 	}
-	o := tool.GetImplSpecificOptions(&options{op: r.op})
-	cmd := fmt.Sprintf("python3 -c \"import sys; lines = (line for idx, line in enumerate(open(sys.argv[1], encoding='utf-8')) if %d <= idx < %d); print(''.join(lines))\" %s",
-		input.StartRow, input.StartRow+input.NRows, input.Path)
-	content, err := o.op.RunCommand(ctx, []string{cmd})
+
+	content, err := o.op.ReadFile(ctx, input.Path)
+	if err != nil {
+		return fmt.Sprintf("read file error: %v, file path: %v", err, input.Path), nil
+	}
+	lines := strings.SplitAfter(content, "\n")
+	from := min(input.StartRow-1, len(lines))
+	to := len(lines)
+	if input.NRows > 0 {
+		to = min(from+input.NRows, len(lines))
+	}
+	out := strings.Join(lines[from:to], "")
+	if len(out) > maxReadBytes {
+		out = out[:maxReadBytes] + "\n... (truncated)"
+	}
+	return out, nil
+}
+
+// maxReadBytes caps the content returned by read_file.
+const maxReadBytes = 64 << 10
+
+// readWorkbook previews the first nRows rows of every sheet.
+func readWorkbook(ctx context.Context, op commandline.Operator, path string, nRows int) (string, error) {
+	content, err := op.ReadFile(ctx, path)
 	if err != nil {
-		if strings.HasPrefix(err.Error(), "internal error") {
-			return err.Error(), nil
+		return fmt.Sprintf("read file error: %v, file path: %v", err, path), nil
+	}
+	f, err := excelize.OpenReader(strings.NewReader(content))
+	if err != nil {
+		return fmt.Sprintf("open workbook error: %v, file path: %v", err, path), nil
+	}
+	defer f.Close()
+	if nRows < 0 {
+		nRows = math.MaxInt // capped by sheet.Preview
+	}
+	var parts []string
+	for _, name := range f.GetSheetList() {
+		preview, err := sheet.Preview(f, name, "", nRows)
+		if err != nil {
+			return fmt.Sprintf("read sheet %q error: %v, file path: %v", name, err, path), nil
 		}
-		return "", err
+		parts = append(parts, preview)
 	}
-	return utils.FormatCommandOutput(content), nil
+	return strings.Join(parts, "\n"), nil
 }
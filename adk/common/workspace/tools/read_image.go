@@ -21,10 +21,9 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
-	"os"
 
+	"github.com/cloudwego/eino-ext/components/tool/commandline"
 	"github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/schema"
@@ -48,11 +47,14 @@ var toolImageReaderInfo = &schema.ToolInfo{
 	}),
 }
 
-func NewToolImageReader(visionModel model.BaseChatModel) tool.InvokableTool {
-	return &localToolImageReader{visionModel: visionModel}
+// NewToolImageReader returns a tool that reads images through op, so the
+// model can only look at images inside the operator's workspace.
+func NewToolImageReader(op commandline.Operator, visionModel model.BaseChatModel) tool.InvokableTool {
+	return &localToolImageReader{op: op, visionModel: visionModel}
 }
 
 type localToolImageReader struct {
+	op          commandline.Operator
 	visionModel model.BaseChatModel
 }
 
@@ -72,15 +74,12 @@ func (t *localToolImageReader) InvokableRun(ctx context.Context, argumentsInJSON
 		return "", errors.New("missing parameters")
 	}
 
-	f, err := os.Open(params.ImagePath)
-	if err != nil {
-		return fmt.Sprintf("open file error: %v, file path: %v", err, params.ImagePath), nil
-	}
-	defer f.Close()
-	fc, err := io.ReadAll(f)
+	o := tool.GetImplSpecificOptions(&options{t.op}, opts...)
+	content, err := o.op.ReadFile(ctx, params.ImagePath)
 	if err != nil {
 		return fmt.Sprintf("read file error: %v, file path: %v", err, params.ImagePath), nil
 	}
+	fc := []byte(content)
 
 	mimeType := http.DetectContentType(fc)
 	b64 := base64.StdEncoding.EncodeToString(fc)
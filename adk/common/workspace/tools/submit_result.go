@@ -25,18 +25,18 @@ import (
 	"github.com/cloudwego/eino-ext/components/tool/commandline"
 	"github.com/cloudwego/eino/adk/prebuilt/planexecute"
 	"github.com/cloudwego/eino/components/tool"
-	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
 
-	"github.com/cloudwego/eino-examples/adk/multiagent/deep/generic"
-	"github.com/cloudwego/eino-examples/adk/multiagent/deep/params"
-	"github.com/cloudwego/eino-examples/adk/multiagent/deep/utils"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/artifact"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/generic"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/params"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/utils"
 )
 
 var (
 	submitResultToolInfo = &schema.ToolInfo{
 		Name: "submit_result",
-		Desc: "When all steps are completed without obvious problems, call this tool to end the task and report the final execution results to the user.",
+		Desc: "When all steps are completed without obvious problems, call this tool to end the task and report the final execution results to the user. It returns a JSON manifest of the delivered files and all files produced during the task.",
 		ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
 			"is_success": {
 				Type: schema.Boolean,
@@ -69,7 +69,7 @@ If there are many documents that meet the original needs of users, the report in
 	}
 
 	SubmitResultReturnDirectly = map[string]bool{
-		"SubmitResult": true,
+		"submit_result": true,
 	}
 )
 
@@ -107,7 +107,7 @@ func (t *submitResultTool) InvokableRun(ctx context.Context, argumentsInJSON str
 		})
 	}
 
-	for i := len(steps); i < len(plan.Steps); i++ {
+	for i := len(steps); plan != nil && i < len(plan.Steps); i++ {
 		step := plan.Steps[i]
 		fullPlan = append(fullPlan, &generic.FullPlan{
 			TaskID: len(fullPlan) + 1,
@@ -123,5 +123,13 @@ func (t *submitResultTool) InvokableRun(ctx context.Context, argumentsInJSON str
 
 	_ = t.op.WriteFile(ctx, filepath.Join(wd, "final_report.json"), argumentsInJSON)
 	_ = generic.Write2PlanMD(ctx, t.op, wd, fullPlan)
-	return utils.ToJSONString(&generic.FullPlan{AgentName: compose.END}), nil
+
+	manifest, err := sonic.MarshalIndent(artifact.NewManifest(ctx, wd, args), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err = t.op.WriteFile(ctx, filepath.Join(wd, "manifest.json"), string(manifest)); err != nil {
+		return "", err
+	}
+	return string(manifest), nil
 }
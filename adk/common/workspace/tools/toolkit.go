@@ -0,0 +1,97 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tools
+
+import (
+	"github.com/cloudwego/eino-ext/components/tool/commandline"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/tool"
+
+	"github.com/cloudwego/eino-examples/adk/common/workspace/sheet"
+)
+
+// ToolkitConfig selects the workspace tools an agent gets. Every tool runs
+// against Operator.
+type ToolkitConfig struct {
+	Operator commandline.Operator
+
+	// ReadOnly leaves out bash, edit_file, python_runner and the sheet_* tools.
+	ReadOnly bool
+	// Spreadsheet adds the sheet_* tools from package sheet.
+	Spreadsheet bool
+	// Python adds python_runner.
+	Python bool
+	// SubmitResult adds submit_result; pair it with SubmitResultReturnDirectly.
+	SubmitResult bool
+	// VisionModel adds image_reader when set.
+	VisionModel model.BaseChatModel
+
+	// RepairJSON repairs malformed tool arguments before they are parsed.
+	RepairJSON bool
+	// PostProcess condenses bash and python_runner output into a short summary
+	// of stdout, stderr and file changes, and acknowledges edit_file writes.
+	PostProcess bool
+}
+
+// Tools that can write files also run ArtifactPostProcess, which records
+// their output files when the task context carries an artifact.Registry.
+
+// NewToolkit returns the tools selected by conf, in a stable order: bash,
+// tree, edit_file, read_file, sheet_*, python_runner, submit_result,
+// image_reader.
+func NewToolkit(conf *ToolkitConfig) []tool.BaseTool {
+	var preprocess []ToolRequestPreprocess
+	if conf.RepairJSON {
+		preprocess = []ToolRequestPreprocess{ToolRequestRepairJSON}
+	}
+	var filePost, editPost []ToolResponsePostprocess
+	if conf.PostProcess {
+		filePost = []ToolResponsePostprocess{FilePostProcess}
+		editPost = []ToolResponsePostprocess{EditFilePostProcess}
+	}
+	filePost = append(filePost, ArtifactPostProcess)
+	editPost = append(editPost, ArtifactPostProcess)
+	wrap := func(t tool.InvokableTool, post []ToolResponsePostprocess) tool.BaseTool {
+		return NewWrapTool(t, preprocess, post)
+	}
+
+	op := conf.Operator
+	var ts []tool.BaseTool
+	if !conf.ReadOnly {
+		ts = append(ts, wrap(NewBashTool(op), filePost))
+	}
+	ts = append(ts, wrap(NewTreeTool(op), nil))
+	if !conf.ReadOnly {
+		ts = append(ts, wrap(NewEditFileTool(op), editPost))
+	}
+	ts = append(ts, wrap(NewReadFileTool(op), nil)) // TODO: compress post process
+	if conf.Spreadsheet && !conf.ReadOnly {
+		for _, t := range sheet.NewTools(op) {
+			ts = append(ts, wrap(t, []ToolResponsePostprocess{ArtifactPostProcess}))
+		}
+	}
+	if conf.Python && !conf.ReadOnly {
+		ts = append(ts, wrap(NewPythonRunnerTool(op), filePost))
+	}
+	if conf.SubmitResult {
+		ts = append(ts, wrap(NewToolSubmitResult(op), nil))
+	}
+	if conf.VisionModel != nil {
+		ts = append(ts, wrap(NewToolImageReader(op, conf.VisionModel), nil))
+	}
+	return ts
+}
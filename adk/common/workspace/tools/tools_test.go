@@ -0,0 +1,275 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/cloudwego/eino-examples/adk/common/operator"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/artifact"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/params"
+)
+
+func newTestWorkspace(t *testing.T) (context.Context, *operator.LocalOperator, string) {
+	t.Helper()
+	wd := t.TempDir()
+	op, err := operator.NewLocalOperator(&operator.Config{
+		WorkDir: func(context.Context) (string, error) { return wd, nil },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := params.InitContextParams(context.Background())
+	params.AppendContextParams(ctx, map[string]interface{}{params.WorkDirSessionKey: wd})
+	return ctx, op, wd
+}
+
+func toolNames(t *testing.T, ts []tool.BaseTool) []string {
+	t.Helper()
+	var names []string
+	for _, bt := range ts {
+		info, err := bt.Info(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, info.Name)
+	}
+	return names
+}
+
+type fakeVisionModel struct {
+	model.BaseChatModel
+	got []*schema.Message
+}
+
+func (m *fakeVisionModel) Generate(_ context.Context, in []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	m.got = in
+	return schema.AssistantMessage("a cat", nil), nil
+}
+
+func TestNewToolkit(t *testing.T) {
+	_, op, _ := newTestWorkspace(t)
+	for _, tc := range []struct {
+		conf *ToolkitConfig
+		want []string
+	}{
+		{&ToolkitConfig{Python: true}, []string{"bash", "tree", "edit_file", "read_file", "python_runner"}},
+		{&ToolkitConfig{ReadOnly: true, Python: true, Spreadsheet: true}, []string{"tree", "read_file"}},
+		{&ToolkitConfig{Spreadsheet: true, Python: true}, []string{"bash", "tree", "edit_file", "read_file",
+			"sheet_list", "sheet_preview", "sheet_read_cells", "sheet_query", "sheet_write", "sheet_chart", "sheet_export_csv", "python_runner"}},
+		{&ToolkitConfig{SubmitResult: true, VisionModel: &fakeVisionModel{}}, []string{"bash", "tree", "edit_file", "read_file", "submit_result", "image_reader"}},
+	} {
+		tc.conf.Operator = op
+		if got := toolNames(t, NewToolkit(tc.conf)); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("tools = %v, want %v", got, tc.want)
+		}
+	}
+	for name := range SubmitResultReturnDirectly {
+		if name != submitResultToolInfo.Name {
+			t.Errorf("ReturnDirectly key %q does not match the tool name", name)
+		}
+	}
+}
+
+func TestToolkitPipeline(t *testing.T) {
+	ctx, op, wd := newTestWorkspace(t)
+	byName := func(ts []tool.BaseTool, name string) tool.InvokableTool {
+		for _, bt := range ts {
+			if info, _ := bt.Info(ctx); info.Name == name {
+				return bt.(tool.InvokableTool)
+			}
+		}
+		t.Fatalf("no %s tool", name)
+		return nil
+	}
+
+	processed := NewToolkit(&ToolkitConfig{Operator: op, RepairJSON: true, PostProcess: true})
+	// broken JSON is repaired, relative paths land in the work dir
+	out, err := byName(processed, "edit_file").InvokableRun(ctx, `<|FunctionCallBegin|>{"path": "a.txt", "content": "hi"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "Write file: edit file success success!" {
+		t.Fatalf("edit output = %q", out)
+	}
+	if b, err := os.ReadFile(filepath.Join(wd, "a.txt")); err != nil || string(b) != "hi" {
+		t.Fatalf("a.txt = %q, %v", b, err)
+	}
+
+	raw := NewToolkit(&ToolkitConfig{Operator: op})
+	if out, _ = byName(raw, "edit_file").InvokableRun(ctx, `{"path": "b.txt", "content": "x"}`); out != "edit file success" {
+		t.Fatalf("raw edit output = %q", out)
+	}
+	if _, err = byName(raw, "read_file").InvokableRun(ctx, `{"path": "a.txt"`); err == nil {
+		t.Fatal("unrepaired arguments were accepted")
+	}
+
+	out, err = byName(processed, "read_file").InvokableRun(ctx, `{"path": "a.txt"}`)
+	if err != nil || out != "hi" {
+		t.Fatalf("read output = %q, %v", out, err)
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	ctx, op, wd := newTestWorkspace(t)
+	read := NewReadFileTool(op)
+	if err := os.WriteFile(filepath.Join(wd, "lines.txt"), []byte("1\n2\n3\n4\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for args, want := range map[string]string{
+		`{"path": "lines.txt"}`:                               "1\n2\n3\n4\n",
+		`{"path": "lines.txt", "start_row": 2, "n_rows": 2}`:  "2\n3\n",
+		`{"path": "lines.txt", "start_row": 3, "n_rows": -1}`: "3\n4\n",
+	} {
+		if out, err := read.InvokableRun(ctx, args); err != nil || out != want {
+			t.Errorf("%s: %q, %v", args, out, err)
+		}
+	}
+
+	f := excelize.NewFile()
+	_ = f.SetSheetRow("Sheet1", "A1", &[]any{"name", "qty"})
+	_ = f.SetSheetRow("Sheet1", "A2", &[]any{"pen", 3})
+	_, _ = f.NewSheet("Empty")
+	if err := f.SaveAs(filepath.Join(wd, "book.xlsx")); err != nil {
+		t.Fatal(err)
+	}
+	out, err := read.InvokableRun(ctx, `{"path": "book.xlsx"}`)
+	if err != nil || !strings.Contains(out, "| 2 | pen | 3 |") || !strings.Contains(out, `Sheet "Empty"`) {
+		t.Fatalf("xlsx output = %q, %v", out, err)
+	}
+}
+
+func TestFilePostProcess(t *testing.T) {
+	out, err := FilePostProcess(context.Background(), nil,
+		`{"stdout":[{"stdout":"ok"}],"stderr":[{"stderr":"warn"}],"file_change":[{"file_type":"file","path":"out.csv","type":"create"}]}`, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"out.csv", "stderr and warnings:warn", "stdout: ok"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output %q does not contain %q", out, want)
+		}
+	}
+	if out, _ = FilePostProcess(context.Background(), nil, "plain text", ""); out != "plain text" {
+		t.Errorf("non-JSON output = %q", out)
+	}
+}
+
+func TestSubmitResult(t *testing.T) {
+	ctx, op, wd := newTestWorkspace(t)
+	args := `{"is_success": true, "result": "done", "files": [{"path": "report.md", "desc": "report"}]}`
+	if _, err := NewToolSubmitResult(op).InvokableRun(ctx, args); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := os.ReadFile(filepath.Join(wd, "final_report.json")); err != nil || string(b) != args {
+		t.Fatalf("final_report.json = %q, %v", b, err)
+	}
+	if _, err := os.Stat(filepath.Join(wd, "plan.md")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSubmitResultManifest(t *testing.T) {
+	ctx, op, wd := newTestWorkspace(t)
+	reg, err := artifact.NewRegistry(wd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params.AppendContextParams(ctx, map[string]interface{}{params.ArtifactsSessionKey: reg})
+	if err = os.WriteFile(filepath.Join(wd, "report.md"), []byte("# Report\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := NewToolSubmitResult(op).InvokableRun(ctx, `{"result": "done", "files": [{"path": "report.md", "desc": "report"}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(filepath.Join(wd, "manifest.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m artifact.Manifest
+	if err = json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+	if !m.IsSuccess || len(m.Deliverables) != 1 || m.Deliverables[0].Desc != "report" || m.Deliverables[0].Change != artifact.ChangeCreated ||
+		len(m.Artifacts) != 1 || m.Artifacts[0].Path != "report.md" {
+		t.Fatalf("manifest = %s", b)
+	}
+	if !strings.Contains(out, `"sha256": "`+m.Artifacts[0].SHA256) {
+		t.Fatalf("output = %s", out)
+	}
+}
+
+func TestArtifactPostProcess(t *testing.T) {
+	ctx, op, wd := newTestWorkspace(t)
+	bash := NewBashTool(op)
+	if out, err := ArtifactPostProcess(ctx, bash, "ok", ""); err != nil || out != "ok" {
+		t.Fatalf("without registry = %q, %v", out, err)
+	}
+
+	reg, err := artifact.NewRegistry(wd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params.AppendContextParams(ctx, map[string]interface{}{params.ArtifactsSessionKey: reg})
+	if err = os.WriteFile(filepath.Join(wd, "out.csv"), []byte("a,b\n1,2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := ArtifactPostProcess(ctx, bash, "ok", "")
+	if err != nil || out != "ok\nrecorded artifacts:\n- out.csv (table, 8 bytes, created)" {
+		t.Fatalf("output = %q, %v", out, err)
+	}
+	if list := reg.List(); len(list) != 1 || list[0].Tool != "bash" {
+		t.Fatalf("registry = %v", list)
+	}
+}
+
+func TestImageReader(t *testing.T) {
+	ctx, op, wd := newTestWorkspace(t)
+	png := "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+	if err := os.WriteFile(filepath.Join(wd, "cat.png"), []byte(png), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	vm := &fakeVisionModel{}
+	reader := NewToolImageReader(op, vm)
+
+	out, err := reader.InvokableRun(ctx, `{"query": "what is it?", "image_path": "`+filepath.Join(wd, "cat.png")+`"}`)
+	if err != nil || out != "a cat" {
+		t.Fatalf("output = %q, %v", out, err)
+	}
+	if url := *vm.got[len(vm.got)-1].UserInputMultiContent[0].Image.URL; !strings.HasPrefix(url, "data:image/png;base64,") {
+		t.Fatalf("image url = %.40s", url)
+	}
+
+	// reads go through the operator, so files outside the work dir are refused
+	vm.got = nil
+	if out, _ = reader.InvokableRun(ctx, `{"query": "q", "image_path": "/etc/hostname"}`); !strings.HasPrefix(out, "read file error") || vm.got != nil {
+		t.Fatalf("outside read = %q", out)
+	}
+}
@@ -27,7 +27,8 @@ import (
 	"github.com/cloudwego/eino/schema"
 	jsoniter "github.com/json-iterator/go"
 
-	"github.com/cloudwego/eino-examples/adk/multiagent/deep/utils"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/artifact"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/utils"
 )
 
 type ToolRequestPreprocess func(ctx context.Context, baseTool tool.InvokableTool, toolArguments string) (string, error)
@@ -175,3 +176,31 @@ func FilePostProcess(ctx context.Context, baseTool tool.InvokableTool, toolRespo
 func EditFilePostProcess(ctx context.Context, baseTool tool.InvokableTool, toolResponse, toolArguments string) (string, error) {
 	return fmt.Sprintf("Write file: %s success!", toolResponse), nil
 }
+
+// ArtifactPostProcess records the files the tool created or changed in the
+// task's artifact registry and lists them after the response. It does nothing
+// when ctx carries no registry.
+func ArtifactPostProcess(ctx context.Context, baseTool tool.InvokableTool, toolResponse, toolArguments string) (string, error) {
+	reg, ok := artifact.FromContext(ctx)
+	if !ok {
+		return toolResponse, nil
+	}
+	info, err := baseTool.Info(ctx)
+	if err != nil {
+		return toolResponse, err
+	}
+	changed, err := reg.Scan(info.Name)
+	if err != nil {
+		return toolResponse, err
+	}
+	if len(changed) == 0 {
+		return toolResponse, nil
+	}
+	var b strings.Builder
+	b.WriteString(toolResponse)
+	b.WriteString("\nrecorded artifacts:")
+	for _, a := range changed {
+		fmt.Fprintf(&b, "\n- %s (%s, %d bytes, %s)", a.Path, a.Type, a.Size, a.Change)
+	}
+	return b.String(), nil
+}
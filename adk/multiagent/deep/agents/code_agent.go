@@ -23,13 +23,12 @@ import (
 	"github.com/cloudwego/eino-ext/components/tool/commandline"
 	"github.com/cloudwego/eino/adk"
 	"github.com/cloudwego/eino/components/prompt"
-	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
 
-	"github.com/cloudwego/eino-examples/adk/multiagent/deep/params"
-	"github.com/cloudwego/eino-examples/adk/multiagent/deep/tools"
-	"github.com/cloudwego/eino-examples/adk/multiagent/deep/utils"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/params"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/tools"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/utils"
 )
 
 func NewCodeAgent(ctx context.Context, operator commandline.Operator) (adk.Agent, error) {
@@ -42,8 +41,6 @@ func NewCodeAgent(ctx context.Context, operator commandline.Operator) (adk.Agent
 		return nil, err
 	}
 
-	preprocess := []tools.ToolRequestPreprocess{tools.ToolRequestRepairJSON}
-
 	ca, err := adk.NewChatModelAgent(ctx, &adk.ChatModelAgentConfig{
 		Name: "CodeAgent",
 		Description: `This sub-agent is a code agent specialized in handling Excel files. 
@@ -68,13 +65,12 @@ Notice:
 		Model: cm,
 		ToolsConfig: adk.ToolsConfig{
 			ToolsNodeConfig: compose.ToolsNodeConfig{
-				Tools: []tool.BaseTool{
-					tools.NewWrapTool(tools.NewBashTool(operator), preprocess, []tools.ToolResponsePostprocess{tools.FilePostProcess}),
-					tools.NewWrapTool(tools.NewTreeTool(operator), preprocess, nil),
-					tools.NewWrapTool(tools.NewEditFileTool(operator), preprocess, []tools.ToolResponsePostprocess{tools.EditFilePostProcess}),
-					tools.NewWrapTool(tools.NewReadFileTool(operator), preprocess, nil), // TODO: compress post process
-					tools.NewWrapTool(tools.NewPythonRunnerTool(operator), preprocess, []tools.ToolResponsePostprocess{tools.FilePostProcess}),
-				},
+				Tools: tools.NewToolkit(&tools.ToolkitConfig{
+					Operator:    operator,
+					Python:      true,
+					RepairJSON:  true,
+					PostProcess: true,
+				}),
 			},
 		},
 		GenModelInput: func(ctx context.Context, instruction string, input *adk.AgentInput) ([]adk.Message, error) {
@@ -24,7 +24,7 @@ import (
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/compose"
 
-	"github.com/cloudwego/eino-examples/adk/multiagent/deep/utils"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/utils"
 )
 
 func NewWebSearchAgent(ctx context.Context) (adk.Agent, error) {
@@ -25,18 +25,19 @@ import (
 
 	"github.com/cloudwego/eino/adk"
 	"github.com/cloudwego/eino/adk/prebuilt/deep"
-	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
 	"github.com/google/uuid"
 
+	"github.com/cloudwego/eino-examples/adk/common/operator"
 	"github.com/cloudwego/eino-examples/adk/common/prints"
 	"github.com/cloudwego/eino-examples/adk/common/trace"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/artifact"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/generic"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/params"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/tools"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/utils"
 	"github.com/cloudwego/eino-examples/adk/multiagent/deep/agents"
-	"github.com/cloudwego/eino-examples/adk/multiagent/deep/generic"
-	"github.com/cloudwego/eino-examples/adk/multiagent/deep/params"
-	"github.com/cloudwego/eino-examples/adk/multiagent/deep/tools"
-	"github.com/cloudwego/eino-examples/adk/multiagent/deep/utils"
 )
 
 func main() {
@@ -99,12 +100,18 @@ func main() {
 		log.Fatal(err)
 	}
 
+	artifacts, err := artifact.NewRegistry(workdir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	ctx = params.InitContextParams(ctx)
 	params.AppendContextParams(ctx, map[string]interface{}{
 		params.FilePathSessionKey:            inputFileDir,
 		params.WorkDirSessionKey:             workdir,
 		params.UserAllPreviewFilesSessionKey: utils.ToJSONString(previews),
 		params.TaskIDKey:                     id,
+		params.ArtifactsSessionKey:           artifacts,
 	})
 
 	ctx, endSpanFn := startSpanFn(ctx, "plan-execute-replan", query)
@@ -151,7 +158,10 @@ func main() {
 }
 
 func newExcelAgent(ctx context.Context) (adk.Agent, error) {
-	operator := &LocalOperator{}
+	op, err := operator.NewSessionOperator()
+	if err != nil {
+		return nil, err
+	}
 
 	cm, err := utils.NewChatModel(ctx,
 		utils.WithMaxTokens(4096),
@@ -162,7 +172,7 @@ func newExcelAgent(ctx context.Context) (adk.Agent, error) {
 		return nil, err
 	}
 
-	ca, err := agents.NewCodeAgent(ctx, operator)
+	ca, err := agents.NewCodeAgent(ctx, op)
 	if err != nil {
 		return nil, err
 	}
@@ -178,11 +188,13 @@ func newExcelAgent(ctx context.Context) (adk.Agent, error) {
 		SubAgents:   []adk.Agent{ca, wa},
 		ToolsConfig: adk.ToolsConfig{
 			ToolsNodeConfig: compose.ToolsNodeConfig{
-				Tools: []tool.BaseTool{
-					tools.NewWrapTool(tools.NewReadFileTool(operator), nil, nil),
-					tools.NewWrapTool(tools.NewTreeTool(operator), nil, nil),
-				},
+				Tools: tools.NewToolkit(&tools.ToolkitConfig{
+					Operator:     op,
+					ReadOnly:     true,
+					SubmitResult: true,
+				}),
 			},
+			ReturnDirectly: tools.SubmitResultReturnDirectly,
 		},
 		MaxIteration: 100,
 	})
@@ -19,17 +19,17 @@ package executor
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/cloudwego/eino-ext/components/tool/commandline"
 	"github.com/cloudwego/eino/adk"
 	"github.com/cloudwego/eino/components/prompt"
-	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
 
-	"github.com/cloudwego/eino-examples/adk/multiagent/integration-excel-agent/params"
-	"github.com/cloudwego/eino-examples/adk/multiagent/integration-excel-agent/tools"
-	"github.com/cloudwego/eino-examples/adk/multiagent/integration-excel-agent/utils"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/params"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/tools"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/utils"
 )
 
 func newCodeAgent(ctx context.Context, operator commandline.Operator) (adk.Agent, error) {
@@ -42,21 +42,19 @@ func newCodeAgent(ctx context.Context, operator commandline.Operator) (adk.Agent
 		return nil, err
 	}
 
-	preprocess := []tools.ToolRequestPreprocess{tools.ToolRequestRepairJSON}
-
 	ca, err := adk.NewChatModelAgent(ctx, &adk.ChatModelAgentConfig{
 		Name: "CodeAgent",
 		Description: `This sub-agent is a code agent specialized in handling Excel files. 
-It receives a clear task and accomplish the task by generating Python code and execute it. 
-The agent leverages pandas for data analysis and manipulation, matplotlib for plotting and visualization, and openpyxl for reading and writing Excel files. 
-The React agent should invoke this sub-agent whenever stepwise Python coding for Excel file operations is required, ensuring precise and efficient task execution.`,
+It receives a clear task and accomplishes it with native spreadsheet tools (listing sheets, previewing ranges, reading typed cells, querying/aggregating, writing cells/formulas/charts and exporting CSV), generating and executing Python code only when those tools are not enough. 
+The React agent should invoke this sub-agent whenever Excel file operations are required, ensuring precise and efficient task execution.`,
 		Instruction: `You are a code agent. Your workflow is as follows:
 1. You will be given a clear task to handle Excel files.
-2. You should analyse the task and use right tools to help coding.
-3. You should write python code to finish the task.
-4. You are preferred to write code execution result to another file for further usages. 
+2. You should analyse the task and use right tools to finish it.
+3. Prefer the sheet_* tools for xlsx files: sheet_list and sheet_preview to understand the layout, sheet_read_cells for exact typed values, sheet_query to filter, aggregate and sort rows, sheet_write for values and formulas, sheet_chart for charts and sheet_export_csv for CSV output. Their results are deterministic.
+4. Only write python code when the sheet_* tools cannot do the task, e.g. for statistics, pivoting or image charts.
+5. You are preferred to write results to another file for further usages. 
 
-You are in a react mode, and you should use the following libraries to help you finish the task:
+When you write python code, use the following libraries:
 - pandas: for data analysis and manipulation
 - matplotlib: for plotting and visualization
 - openpyxl: for reading and writing Excel files
@@ -68,13 +66,13 @@ Notice:
 		Model: cm,
 		ToolsConfig: adk.ToolsConfig{
 			ToolsNodeConfig: compose.ToolsNodeConfig{
-				Tools: []tool.BaseTool{
-					tools.NewWrapTool(tools.NewBashTool(operator), preprocess, []tools.ToolResponsePostprocess{tools.FilePostProcess}),
-					tools.NewWrapTool(tools.NewTreeTool(operator), preprocess, nil),
-					tools.NewWrapTool(tools.NewEditFileTool(operator), preprocess, []tools.ToolResponsePostprocess{tools.EditFilePostProcess}),
-					tools.NewWrapTool(tools.NewReadFileTool(operator), preprocess, nil), // TODO: compress post process
-					tools.NewWrapTool(tools.NewPythonRunnerTool(operator), preprocess, []tools.ToolResponsePostprocess{tools.FilePostProcess}),
-				},
+				Tools: tools.NewToolkit(&tools.ToolkitConfig{
+					Operator:    operator,
+					Spreadsheet: true,
+					Python:      os.Getenv("EXCEL_AGENT_DISABLE_PYTHON") != "true",
+					RepairJSON:  true,
+					PostProcess: true,
+				}),
 			},
 		},
 		GenModelInput: func(ctx context.Context, instruction string, input *adk.AgentInput) ([]adk.Message, error) {
@@ -27,14 +27,16 @@ import (
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
 
-	"github.com/cloudwego/eino-examples/adk/multiagent/integration-excel-agent/utils"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/artifact"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/generic"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/utils"
 )
 
 var executorPrompt = prompt.FromMessages(schema.FString,
 	schema.SystemMessage(`You are a diligent and meticulous executor agent. Follow the given plan and execute your tasks carefully and thoroughly.
 
 Available Tools:
-- CodeAgent: This tool is a code agent specialized in Excel file handling. It takes step-by-step plans, processes each task by generating Python code (leveraging pandas for data analysis/manipulation, matplotlib for plotting/visualization, and openpyxl for Excel reading/writing), and executes tasks sequentially. The React agent should invoke it when stepwise Python coding for Excel operations is needed to ensure precise, efficient task completion.
+- CodeAgent: This tool is a code agent specialized in Excel file handling. It takes step-by-step plans and processes each task with native spreadsheet tools (sheet listing, range previews, typed cell reads, filter/aggregate queries, writing cells, formulas and charts, CSV export), falling back to generated Python code (pandas, matplotlib, openpyxl) when needed. The React agent should invoke it for any Excel operation to ensure precise, efficient task completion.
 
 Notice:
 - Do not transfer to other agents, use tools only.
@@ -85,6 +87,15 @@ func NewExecutor(ctx context.Context, operator commandline.Operator) (adk.Agent,
 				return nil, err
 			}
 
+			// files written from here on belong to the step being executed
+			if reg, ok := artifact.FromContext(ctx); ok {
+				var desc string
+				if p, ok := in.Plan.(*generic.Plan); ok && len(p.Steps) > 0 {
+					desc = p.Steps[0].Desc
+				}
+				reg.SetStep(len(in.ExecutedSteps)+1, desc)
+			}
+
 			return executorPrompt.Format(ctx, map[string]any{
 				"input":          utils.FormatInput(in.UserInput),
 				"plan":           string(planContent),
@@ -27,10 +27,10 @@ import (
 	"github.com/cloudwego/eino/components/prompt"
 	"github.com/cloudwego/eino/schema"
 
+	"github.com/cloudwego/eino-examples/adk/common/workspace/generic"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/params"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/utils"
 	"github.com/cloudwego/eino-examples/adk/multiagent/integration-excel-agent/agents"
-	"github.com/cloudwego/eino-examples/adk/multiagent/integration-excel-agent/generic"
-	"github.com/cloudwego/eino-examples/adk/multiagent/integration-excel-agent/params"
-	"github.com/cloudwego/eino-examples/adk/multiagent/integration-excel-agent/utils"
 )
 
 var plannerPromptTemplate = prompt.FromMessages(schema.Jinja2,
@@ -55,10 +55,10 @@ User Request: "Please calculate the average sales for each product category in t
 {
   "steps": [
     {
-      "desc": "Read the 'sales_data.xlsx' file into a pandas DataFrame."
+      "desc": "Preview the sheets of 'sales_data.xlsx' to locate the 'Product Category' and 'Sales' columns."
     },
     {
-      "desc": "Group the DataFrame by 'Product Category' and calculate the mean of the 'Sales' column for each group."
+      "desc": "Group the rows by 'Product Category' and calculate the mean of the 'Sales' column for each group."
     },
     {
       "desc": "Summarize the average sales for each product category and present the results in a table."
@@ -26,15 +26,16 @@ import (
 	"github.com/cloudwego/eino-ext/components/tool/commandline"
 	"github.com/cloudwego/eino/adk"
 	"github.com/cloudwego/eino/adk/prebuilt/planexecute"
+	"github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/components/prompt"
-	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
 
-	"github.com/cloudwego/eino-examples/adk/multiagent/integration-excel-agent/generic"
-	"github.com/cloudwego/eino-examples/adk/multiagent/integration-excel-agent/params"
-	"github.com/cloudwego/eino-examples/adk/multiagent/integration-excel-agent/tools"
-	"github.com/cloudwego/eino-examples/adk/multiagent/integration-excel-agent/utils"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/artifact"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/generic"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/params"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/tools"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/utils"
 )
 
 func NewReportAgent(ctx context.Context, operator commandline.Operator) (adk.Agent, error) {
@@ -47,9 +48,9 @@ func NewReportAgent(ctx context.Context, operator commandline.Operator) (adk.Age
 		return nil, err
 	}
 
-	var imageReaderTool tool.InvokableTool
+	var visionModel model.BaseChatModel
 	if modelName := os.Getenv("ARK_VISION_MODEL"); modelName != "" {
-		visionModel, err := ark.NewChatModel(ctx, &ark.ChatModelConfig{
+		visionModel, err = ark.NewChatModel(ctx, &ark.ChatModelConfig{
 			APIKey:  os.Getenv("ARK_VISION_API_KEY"),
 			BaseURL: os.Getenv("ARK_VISION_BASE_URL"),
 			Region:  os.Getenv("ARK_VISION_REGION"),
@@ -58,20 +59,14 @@ func NewReportAgent(ctx context.Context, operator commandline.Operator) (adk.Age
 		if err != nil {
 			return nil, err
 		}
-		imageReaderTool = tools.NewToolImageReader(visionModel)
 	}
 
-	preprocess := []tools.ToolRequestPreprocess{tools.ToolRequestRepairJSON}
-	agentTools := []tool.BaseTool{
-		tools.NewWrapTool(tools.NewBashTool(operator), preprocess, nil),
-		tools.NewWrapTool(tools.NewTreeTool(operator), preprocess, nil),
-		tools.NewWrapTool(tools.NewEditFileTool(operator), preprocess, nil),
-		tools.NewWrapTool(tools.NewReadFileTool(operator), preprocess, nil),
-		tools.NewWrapTool(tools.NewToolSubmitResult(operator), preprocess, nil),
-	}
-	if imageReaderTool != nil {
-		agentTools = append(agentTools, tools.NewWrapTool(imageReaderTool, preprocess, nil))
-	}
+	agentTools := tools.NewToolkit(&tools.ToolkitConfig{
+		Operator:     operator,
+		SubmitResult: true,
+		VisionModel:  visionModel,
+		RepairJSON:   true,
+	})
 
 	ra, err := adk.NewChatModelAgent(ctx, &adk.ChatModelAgentConfig{
 		Name: "Report",
@@ -86,7 +81,8 @@ If the file contains charts or visualizations, the agent will reference them app
 3.  Summarize the key findings and insights.
 4.  Generate a clear and concise report that addresses the user's query.
 5.  If there are any charts or visualizations, refer to them in your report.
-6.  If work is done, must call SubmitResult tool before finishing.
+6.  'Task Artifacts' lists the files produced by each step with previews. Embed table previews as Markdown tables and images with the given ![name](path) links instead of describing them.
+7.  If work is done, must call submit_result tool before finishing, listing the delivered files.
 `,
 		Model: cm,
 		ToolsConfig: adk.ToolsConfig{
@@ -126,6 +122,16 @@ If the file contains charts or visualizations, the agent will reference them app
 				return nil, err
 			}
 
+			artifacts := "none recorded"
+			if reg, ok := artifact.FromContext(ctx); ok {
+				if _, err = reg.Scan(""); err != nil {
+					return nil, err
+				}
+				if list := reg.List(); len(list) > 0 {
+					artifacts = artifact.Markdown(list)
+				}
+			}
+
 			tpl := prompt.FromMessages(schema.Jinja2,
 				schema.SystemMessage(instruction),
 				schema.UserMessage(`
@@ -137,6 +143,9 @@ Current Time: {{ current_time }}
 
 **Plan Details:**
 {{ plan }}
+
+**Task Artifacts:**
+{{ artifacts }}
 `))
 
 			msgs, err := tpl.Format(ctx, map[string]any{
@@ -146,6 +155,7 @@ Current Time: {{ current_time }}
 				"user_query":     utils.FormatInput(planExecuteResult),
 				"plan":           string(planStr),
 				"current_time":   utils.GetCurrentTime(),
+				"artifacts":      artifacts,
 			})
 			if err != nil {
 				return nil, err
@@ -28,15 +28,17 @@ import (
 	"github.com/cloudwego/eino/schema"
 	"github.com/google/uuid"
 
+	"github.com/cloudwego/eino-examples/adk/common/operator"
 	"github.com/cloudwego/eino-examples/adk/common/prints"
 	"github.com/cloudwego/eino-examples/adk/common/trace"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/artifact"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/generic"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/params"
+	"github.com/cloudwego/eino-examples/adk/common/workspace/utils"
 	"github.com/cloudwego/eino-examples/adk/multiagent/integration-excel-agent/agents/executor"
 	"github.com/cloudwego/eino-examples/adk/multiagent/integration-excel-agent/agents/planner"
 	"github.com/cloudwego/eino-examples/adk/multiagent/integration-excel-agent/agents/replanner"
 	"github.com/cloudwego/eino-examples/adk/multiagent/integration-excel-agent/agents/report"
-	"github.com/cloudwego/eino-examples/adk/multiagent/integration-excel-agent/generic"
-	"github.com/cloudwego/eino-examples/adk/multiagent/integration-excel-agent/params"
-	"github.com/cloudwego/eino-examples/adk/multiagent/integration-excel-agent/utils"
 )
 
 func main() {
@@ -99,12 +101,18 @@ func main() {
 		log.Fatal(err)
 	}
 
+	artifacts, err := artifact.NewRegistry(workdir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	ctx = params.InitContextParams(ctx)
 	params.AppendContextParams(ctx, map[string]interface{}{
 		params.FilePathSessionKey:            inputFileDir,
 		params.WorkDirSessionKey:             workdir,
 		params.UserAllPreviewFilesSessionKey: utils.ToJSONString(previews),
 		params.TaskIDKey:                     uuid,
+		params.ArtifactsSessionKey:           artifacts,
 	})
 
 	ctx, endSpanFn := startSpanFn(ctx, "plan-execute-replan", query)
@@ -151,19 +159,22 @@ func main() {
 }
 
 func newExcelAgent(ctx context.Context) (adk.Agent, error) {
-	operator := &LocalOperator{}
+	op, err := operator.NewSessionOperator()
+	if err != nil {
+		return nil, err
+	}
 
-	p, err := planner.NewPlanner(ctx, operator)
+	p, err := planner.NewPlanner(ctx, op)
 	if err != nil {
 		return nil, err
 	}
 
-	e, err := executor.NewExecutor(ctx, operator)
+	e, err := executor.NewExecutor(ctx, op)
 	if err != nil {
 		return nil, err
 	}
 
-	rp, err := replanner.NewReplanner(ctx, operator)
+	rp, err := replanner.NewReplanner(ctx, op)
 	if err != nil {
 		return nil, err
 	}
@@ -178,7 +189,7 @@ func newExcelAgent(ctx context.Context) (adk.Agent, error) {
 		return nil, err
 	}
 
-	reportAgent, err := report.NewReportAgent(ctx, operator)
+	reportAgent, err := report.NewReportAgent(ctx, op)
 	if err != nil {
 		return nil, err
 	}
@@ -25,10 +25,10 @@ import (
 
 	"github.com/cloudwego/eino-ext/components/model/openai"
 	"github.com/cloudwego/eino/adk"
-	"github.com/cloudwego/eino/adk/prebuilt/supervisor"
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/compose"
 
+	"github.com/cloudwego/eino-examples/adk/common/policy"
 	"github.com/cloudwego/eino-examples/adk/common/prints"
 	"github.com/cloudwego/eino-examples/adk/multiagent/integration-project-manager/agents"
 )
@@ -76,9 +76,21 @@ func main() {
 	// Combine agents into ADK supervisor pattern
 	// Supervisor: project manager
 	// Sub-agents: researcher / coder / reviewer
-	supervisorAgent, err := supervisor.New(ctx, &supervisor.Config{
+	// The policy makes the reviewer check every output of the coder and sends
+	// rejected code back to the coder, whatever the project manager decides.
+	supervisorAgent, err := policy.NewSupervisor(ctx, &policy.Config{
 		Supervisor: s,
 		SubAgents:  []adk.Agent{researchAgent, codeAgent, reviewAgent},
+		Reviews: []policy.Review{{
+			Producer: codeAgent.Name(ctx),
+			Reviewer: reviewAgent.Name(ctx),
+			Criteria: []string{
+				"The code is complete and runs as written, without placeholders.",
+				"The code does what the user asked for, including edge cases such as empty or invalid input.",
+				"Errors are handled or reported instead of being ignored.",
+			},
+			MaxRevisions: 2,
+		}},
 	})
 	if err != nil {
 		log.Fatal(err)
@@ -34,6 +34,7 @@ func NewProjectManagerAgent(ctx context.Context, tcm model.ToolCallingChatModel)
   - ResearchAgent: assign this agent when you need to conduct research and generate feasible solutions.
   - CodeAgent: assign this agent when you need generate high-quality code.
   - ReviewAgent: assign this agent when you need evaluate research or coding results.
+- Every output of CodeAgent is reviewed by ReviewAgent and sent back for revision automatically until it passes; follow the workflow policy status you are given and do not present rejected code as done.
 - Dynamically route tasks and user inputs to the appropriate sub-agent based on the current project requirements.
 - Monitor the progress and outputs of each sub-agent to ensure alignment with project goals.
 - Facilitate communication and collaboration among sub-agents to optimize workflow efficiency.
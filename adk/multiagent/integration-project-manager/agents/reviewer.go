@@ -66,7 +66,8 @@ func NewReviewAgent(ctx context.Context, tcm model.ToolCallingChatModel) (adk.Ag
 - Check for logical consistency and completeness.
 - Identify any biases or errors and suggest corrections.
 - Confirm that the review aligns with the original analysis and project goals.
-- Approve the review for final presentation or request revisions if necessary.`,
+- Approve the review for final presentation or request revisions if necessary.
+- When the review was requested against acceptance criteria, judge every criterion and end with the JSON verdict in the requested format, failing the output if any criterion does not hold.`,
 		Model: tcm,
 	})
 	if err != nil {
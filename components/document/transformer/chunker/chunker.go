@@ -0,0 +1,285 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package chunker provides a structure-aware document.Transformer.
+//
+// Documents are split along the structure of their format:
+//   - Markdown by headings; every chunk carries its heading breadcrumb.
+//   - Go by top-level declarations, using go/ast.
+//   - Other source code by top-level blocks, found with a brace heuristic for
+//     C-like languages and an indentation heuristic for Python-like ones.
+//   - Plain prose by paragraphs and sentences, with optional overlap.
+//
+// Pieces that are still larger than the chunk size are split further at
+// paragraph, sentence or line boundaries. Every chunk is an exact slice of the
+// source text and records its byte offsets and line numbers, so callers can
+// link back to the original file.
+//
+// Usage:
+//
+//	tfr, _ := chunker.NewChunker(ctx, &chunker.Config{ChunkSize: 800, Overlap: 100})
+//	g.AddDocumentTransformerNode("Chunker", tfr)
+package chunker
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Format selects how a document is split.
+type Format string
+
+const (
+	FormatText     Format = "text"
+	FormatMarkdown Format = "markdown"
+	FormatGo       Format = "go"
+	FormatBraces   Format = "braces" // C-like source code
+	FormatIndent   Format = "indent" // indentation-structured source code
+)
+
+// Metadata keys set on every chunk produced by Transform.
+const (
+	MetaKeyFormat    = "chunk_format"
+	MetaKeyStart     = "chunk_start"      // byte offset of the chunk in the source document
+	MetaKeyEnd       = "chunk_end"        // byte offset just past the chunk
+	MetaKeyStartLine = "chunk_start_line" // 1-based
+	MetaKeyEndLine   = "chunk_end_line"
+	MetaKeyHeadings  = "chunk_headings" // []string, Markdown heading breadcrumb
+	MetaKeySymbol    = "chunk_symbol"   // declarations contained in a code chunk
+)
+
+// Metadata keys of the eino-ext file loader used to detect the format.
+const (
+	metaKeyExtension = "_extension"
+	metaKeyFileName  = "_file_name"
+	metaKeySource    = "_source"
+)
+
+var extFormats = func() map[string]Format {
+	m := make(map[string]Format)
+	for format, exts := range map[Format][]string{
+		FormatMarkdown: {".md", ".markdown", ".mdx"},
+		FormatGo:       {".go"},
+		FormatBraces: {".c", ".h", ".cc", ".cpp", ".hpp", ".cs", ".java", ".kt", ".scala",
+			".js", ".jsx", ".ts", ".tsx", ".rs", ".swift", ".php", ".dart", ".proto", ".thrift"},
+		FormatIndent: {".py", ".yaml", ".yml"},
+	} {
+		for _, ext := range exts {
+			m[ext] = format
+		}
+	}
+	return m
+}()
+
+// FormatOf returns the format for a file name, FormatText if it is not known.
+func FormatOf(name string) Format {
+	if f, ok := extFormats[strings.ToLower(filepath.Ext(name))]; ok {
+		return f
+	}
+	return FormatText
+}
+
+// Chunk is a contiguous slice of a document. Start and End are byte offsets
+// into the source; lines are 1-based.
+type Chunk struct {
+	Start     int      `json:"start"`
+	End       int      `json:"end"`
+	StartLine int      `json:"start_line"`
+	EndLine   int      `json:"end_line"`
+	Headings  []string `json:"headings,omitempty"`
+	Symbol    string   `json:"symbol,omitempty"`
+	Text      string   `json:"text"`
+}
+
+// Config configures a Chunker.
+type Config struct {
+	// ChunkSize is the maximum chunk length in bytes. Default 800.
+	ChunkSize int
+	// Overlap is the maximum number of bytes of trailing prose sentences
+	// repeated at the start of the next chunk of the same section. Code is
+	// never overlapped. Default 0.
+	Overlap int
+	// Format forces the format of every document. By default it is detected
+	// from the file name in the document metadata, falling back to FormatText.
+	Format Format
+	// IDGenerator names the chunks of a document. By default chunk i of
+	// document "doc" is "doc_i", and chunks of documents without ID have none.
+	IDGenerator func(ctx context.Context, originalID string, splitIndex int) string
+}
+
+// Chunker splits documents along their structure.
+type Chunker struct {
+	size    int
+	overlap int
+	format  Format
+	idGen   func(ctx context.Context, originalID string, splitIndex int) string
+}
+
+var _ document.Transformer = (*Chunker)(nil)
+
+// NewChunker creates a Chunker.
+func NewChunker(ctx context.Context, config *Config) (*Chunker, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	if config.ChunkSize < 0 || config.Overlap < 0 {
+		return nil, fmt.Errorf("chunk size and overlap must not be negative")
+	}
+	c := &Chunker{
+		size:    config.ChunkSize,
+		overlap: config.Overlap,
+		format:  config.Format,
+		idGen:   config.IDGenerator,
+	}
+	if c.size == 0 {
+		c.size = 800
+	}
+	if c.overlap >= c.size {
+		return nil, fmt.Errorf("overlap %d must be smaller than chunk size %d", c.overlap, c.size)
+	}
+	if c.idGen == nil {
+		c.idGen = defaultIDGenerator
+	}
+	return c, nil
+}
+
+func defaultIDGenerator(_ context.Context, originalID string, splitIndex int) string {
+	if originalID == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s_%d", originalID, splitIndex)
+}
+
+// GetType implements components.Typer.
+func (c *Chunker) GetType() string {
+	return "StructureChunker"
+}
+
+// Transform splits every document into chunks. The source metadata is copied
+// to each chunk and extended with the MetaKey* entries.
+func (c *Chunker) Transform(ctx context.Context, src []*schema.Document, _ ...document.TransformerOption) ([]*schema.Document, error) {
+	var out []*schema.Document
+	for _, doc := range src {
+		if doc == nil {
+			continue
+		}
+		format := c.format
+		if format == "" {
+			format = formatOfDocument(doc)
+		}
+		for i, ch := range c.Split(doc.Content, format) {
+			meta := make(map[string]any, len(doc.MetaData)+7)
+			for k, v := range doc.MetaData {
+				meta[k] = v
+			}
+			meta[MetaKeyFormat] = string(format)
+			meta[MetaKeyStart] = ch.Start
+			meta[MetaKeyEnd] = ch.End
+			meta[MetaKeyStartLine] = ch.StartLine
+			meta[MetaKeyEndLine] = ch.EndLine
+			if len(ch.Headings) > 0 {
+				meta[MetaKeyHeadings] = ch.Headings
+			}
+			if ch.Symbol != "" {
+				meta[MetaKeySymbol] = ch.Symbol
+			}
+			out = append(out, &schema.Document{
+				ID:       c.idGen(ctx, doc.ID, i),
+				Content:  ch.Text,
+				MetaData: meta,
+			})
+		}
+	}
+	return out, nil
+}
+
+// formatOfDocument detects the format from the file loader metadata.
+func formatOfDocument(doc *schema.Document) Format {
+	for _, key := range []string{metaKeyExtension, metaKeyFileName, metaKeySource} {
+		if name, ok := doc.MetaData[key].(string); ok && name != "" {
+			if key == metaKeyExtension && !strings.HasPrefix(name, ".") {
+				name = "." + name
+			}
+			return FormatOf(name)
+		}
+	}
+	return FormatText
+}
+
+// Split splits text in the given format. Empty or whitespace-only text has no
+// chunks.
+func (c *Chunker) Split(text string, format Format) []Chunk {
+	var chunks []Chunk
+	switch format {
+	case FormatMarkdown:
+		chunks = c.splitMarkdown(text)
+	case FormatGo:
+		var ok bool
+		if chunks, ok = c.splitGo(text); !ok {
+			// Snippets and files with syntax errors still split as C-like code.
+			chunks = c.splitCode(text, braceUnits(text))
+		}
+	case FormatBraces:
+		chunks = c.splitCode(text, braceUnits(text))
+	case FormatIndent:
+		chunks = c.splitCode(text, indentUnits(text))
+	default:
+		chunks = c.chunks(text, pack(text, proseAtoms(text, 0, len(text)), c.size, c.overlap), nil)
+	}
+	numberLines(text, chunks)
+	return chunks
+}
+
+// chunks turns spans of text into Chunks that share the heading breadcrumb.
+func (c *Chunker) chunks(text string, spans []span, headings []string) []Chunk {
+	out := make([]Chunk, 0, len(spans))
+	for _, sp := range spans {
+		out = append(out, newChunk(text, sp, headings))
+	}
+	return out
+}
+
+// newChunk leaves the line numbers to numberLines.
+func newChunk(text string, sp span, headings []string) Chunk {
+	return Chunk{
+		Start:    sp.s,
+		End:      sp.e,
+		Headings: headings,
+		Text:     text[sp.s:sp.e],
+	}
+}
+
+// numberLines sets the 1-based line numbers of chunks, finding them in an
+// index of the newlines in text rather than recounting from the start for
+// every chunk.
+func numberLines(text string, chunks []Chunk) {
+	var newlines []int
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			newlines = append(newlines, i)
+		}
+	}
+	for i := range chunks {
+		chunks[i].StartLine = sort.SearchInts(newlines, chunks[i].Start) + 1
+		chunks[i].EndLine = sort.SearchInts(newlines, chunks[i].End) + 1
+	}
+}
@@ -0,0 +1,262 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+const markdownDoc = `---
+title: Guide
+---
+
+# Guide
+
+## Install
+
+Run go get.
+
+## Usage
+
+### Graphs
+
+Graphs connect nodes with edges.
+
+` + "```go\n# not a heading\ng := compose.NewGraph()\n```" + `
+
+Setext title
+------------
+
+Body under setext.
+`
+
+const goDoc = `// Package demo is a demo.
+package demo
+
+import (
+	"fmt"
+)
+
+// Greeter greets.
+type Greeter struct{ name string }
+
+// Greet prints a greeting.
+func (g *Greeter) Greet() {
+	fmt.Println("hello", g.name)
+}
+
+const (
+	A = 1
+	B = 2
+)
+`
+
+const jsDoc = `import x from "y";
+
+// add adds.
+function add(a, b) {
+  if (a) { return "}"; }
+  return a + b;
+}
+class Foo {
+  bar() {}
+}
+`
+
+const pyDoc = `import os
+
+@cache
+def load(path):
+    data = open(path)
+
+    return data
+# helpers
+class Store:
+    def get(self):
+        pass
+`
+
+func newTestChunker(t *testing.T, size, overlap int) *Chunker {
+	t.Helper()
+	c, err := NewChunker(context.Background(), &Config{ChunkSize: size, Overlap: overlap})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func checkOffsets(t *testing.T, text string, chunks []Chunk, size int) {
+	t.Helper()
+	for i, c := range chunks {
+		if text[c.Start:c.End] != c.Text {
+			t.Fatalf("chunk %d text does not match its offsets", i)
+		}
+		if c.End-c.Start > size {
+			t.Fatalf("chunk %d is %d bytes, limit %d", i, c.End-c.Start, size)
+		}
+		if want := strings.Count(text[:c.Start], "\n") + 1; c.StartLine != want {
+			t.Fatalf("chunk %d start line = %d, want %d", i, c.StartLine, want)
+		}
+		if want := c.StartLine + strings.Count(c.Text, "\n"); c.EndLine != want {
+			t.Fatalf("chunk %d end line = %d, want %d", i, c.EndLine, want)
+		}
+	}
+}
+
+func TestSplitKeepsOffsets(t *testing.T) {
+	long := strings.Repeat("Sentence number one is here. ", 40) + "\n\n" + strings.Repeat("长", 300)
+	for _, tc := range []struct {
+		format Format
+		text   string
+	}{
+		{FormatText, long},
+		{FormatMarkdown, markdownDoc + "\n" + long},
+		{FormatGo, goDoc + "\nfunc big() {\n" + strings.Repeat("\tx := 1\n", 60) + "}\n"},
+		{FormatGo, "not go { at all }\n" + long},
+		{FormatBraces, jsDoc},
+		{FormatIndent, pyDoc},
+	} {
+		c := newTestChunker(t, 120, 40)
+		chunks := c.Split(tc.text, tc.format)
+		if len(chunks) == 0 {
+			t.Fatalf("%s: no chunks", tc.format)
+		}
+		checkOffsets(t, tc.text, chunks, 120)
+	}
+}
+
+func TestMarkdownHeadings(t *testing.T) {
+	chunks := newTestChunker(t, 800, 0).Split(markdownDoc, FormatMarkdown)
+	var got [][]string
+	for _, c := range chunks {
+		got = append(got, c.Headings)
+	}
+	want := [][]string{
+		nil, // front matter
+		{"Guide", "Install"},
+		{"Guide", "Usage", "Graphs"},
+		{"Guide", "Setext title"}, // "---" underlines a level-2 heading
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("headings = %q, want %q", got, want)
+	}
+	// A heading-only section is merged into the next one.
+	if !strings.HasPrefix(chunks[1].Text, "# Guide\n\n## Install") {
+		t.Fatalf("chunk 1 = %q", chunks[1].Text)
+	}
+	if !strings.Contains(chunks[2].Text, "# not a heading") {
+		t.Fatalf("fenced code was split: %q", chunks[2].Text)
+	}
+}
+
+func TestGoDeclarations(t *testing.T) {
+	chunks := newTestChunker(t, 90, 0).Split(goDoc, FormatGo)
+	var symbols []string
+	for _, c := range chunks {
+		symbols = append(symbols, c.Symbol)
+	}
+	want := []string{"package demo", "type Greeter", "func Greeter.Greet", "const A, B"}
+	if !reflect.DeepEqual(symbols, want) {
+		t.Fatalf("symbols = %q, want %q", symbols, want)
+	}
+	if !strings.HasPrefix(chunks[2].Text, "// Greet prints a greeting.\nfunc") {
+		t.Fatalf("doc comment not attached: %q", chunks[2].Text)
+	}
+
+	// Small declarations are packed together.
+	chunks = newTestChunker(t, 800, 0).Split(goDoc, FormatGo)
+	if len(chunks) != 1 || chunks[0].Symbol != strings.Join(want, "; ") {
+		t.Fatalf("packed chunks = %+v", chunks)
+	}
+}
+
+func TestCodeHeuristics(t *testing.T) {
+	symbols := func(units []codeUnit) []string {
+		var out []string
+		for _, u := range units {
+			out = append(out, u.symbol)
+		}
+		return out
+	}
+	if got, want := symbols(braceUnits(jsDoc)), []string{`import x from "y";`, "function add(a, b)", "class Foo"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("brace symbols = %q, want %q", got, want)
+	}
+	if got, want := symbols(indentUnits(pyDoc)), []string{"import os", "def load(path)", "class Store"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("indent symbols = %q, want %q", got, want)
+	}
+
+	// The tail of a split function is not packed with the next declaration.
+	for _, ch := range newTestChunker(t, 40, 0).Split(jsDoc, FormatBraces) {
+		if strings.Contains(ch.Symbol, ";") && ch.Symbol != `import x from "y";` {
+			t.Fatalf("chunk mixes declarations: %q", ch.Symbol)
+		}
+	}
+}
+
+func TestProseOverlap(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < 12; i++ {
+		b.WriteString("This is sentence " + string(rune('a'+i)) + " of the text. ")
+	}
+	text := b.String()
+	chunks := newTestChunker(t, 100, 40).Split(text, FormatText)
+	checkOffsets(t, text, chunks, 100)
+	if len(chunks) < 2 {
+		t.Fatalf("got %d chunks", len(chunks))
+	}
+	for i := 1; i < len(chunks); i++ {
+		prev, cur := chunks[i-1], chunks[i]
+		if cur.Start >= prev.End || prev.End-cur.Start > 40 {
+			t.Fatalf("chunks %d and %d overlap by %d bytes", i-1, i, prev.End-cur.Start)
+		}
+		if !strings.HasSuffix(prev.Text, ".") || !strings.HasPrefix(cur.Text, "This") {
+			t.Fatalf("chunk %d does not end on a sentence: %q", i-1, prev.Text)
+		}
+	}
+	if chunks[len(chunks)-1].End != len(strings.TrimSpace(text)) {
+		t.Fatal("text is not fully covered")
+	}
+}
+
+func TestTransform(t *testing.T) {
+	c := newTestChunker(t, 800, 0)
+	docs, err := c.Transform(context.Background(), []*schema.Document{
+		{ID: "doc", Content: markdownDoc, MetaData: map[string]any{"_extension": ".md", "k": "v"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 4 {
+		t.Fatalf("got %d docs", len(docs))
+	}
+	d := docs[2]
+	if d.ID != "doc_2" || d.MetaData["k"] != "v" || d.MetaData[MetaKeyFormat] != "markdown" {
+		t.Fatalf("doc = %+v", d)
+	}
+	if !reflect.DeepEqual(d.MetaData[MetaKeyHeadings], []string{"Guide", "Usage", "Graphs"}) {
+		t.Fatalf("headings = %v", d.MetaData[MetaKeyHeadings])
+	}
+	start, end := d.MetaData[MetaKeyStart].(int), d.MetaData[MetaKeyEnd].(int)
+	if markdownDoc[start:end] != d.Content {
+		t.Fatal("metadata offsets do not match content")
+	}
+}
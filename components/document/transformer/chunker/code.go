@@ -0,0 +1,308 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// maxSymbolLen bounds the declaration text kept as a chunk symbol.
+const maxSymbolLen = 80
+
+// codeUnit is one top-level declaration, with the comments above it.
+type codeUnit struct {
+	span
+	symbol string
+}
+
+// splitCode packs consecutive small units into one chunk and splits units
+// larger than a chunk on their own by lines, preferring blank lines. Code is
+// never overlapped: a repeated tail of a function body helps no reader.
+func (c *Chunker) splitCode(text string, units []codeUnit) []Chunk {
+	var spans []span
+	var run []atom
+	for _, u := range units {
+		if u.e-u.s <= c.size {
+			run = append(run, atom{span: u.span, brk: true})
+			continue
+		}
+		spans = append(spans, pack(text, run, c.size, 0)...)
+		run = nil
+		spans = append(spans, pack(text, lineAtoms(text, u.s, u.e), c.size, 0)...)
+	}
+	spans = append(spans, pack(text, run, c.size, 0)...)
+
+	out := make([]Chunk, 0, len(spans))
+	for _, sp := range spans {
+		ch := newChunk(text, sp, nil)
+		var symbols []string
+		for _, u := range units {
+			if u.s < sp.e && u.e > sp.s && u.symbol != "" {
+				symbols = append(symbols, u.symbol)
+			}
+		}
+		ch.Symbol = strings.Join(symbols, "; ")
+		out = append(out, ch)
+	}
+	return out
+}
+
+// splitGo splits a Go file into its package clause with imports and one unit
+// per top-level declaration, doc comments included. It reports false if text
+// does not parse as a Go file.
+func (c *Chunker) splitGo(text string) ([]Chunk, bool) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", text, parser.ParseComments)
+	if err != nil {
+		return nil, false
+	}
+	tf := fset.File(f.Pos())
+
+	// The header runs to the end of the last import declaration.
+	headerEnd := tf.Offset(f.Name.End())
+	for _, d := range f.Decls {
+		gd, ok := d.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT {
+			break
+		}
+		headerEnd = tf.Offset(gd.End())
+	}
+	prev := lineEnd(text, headerEnd)
+	units := []codeUnit{{span: trim(text, 0, prev), symbol: "package " + f.Name.Name}}
+
+	for _, d := range f.Decls {
+		if gd, ok := d.(*ast.GenDecl); ok && gd.Tok == token.IMPORT {
+			continue
+		}
+		end := lineEnd(text, tf.Offset(d.End()))
+		// Free-floating comments between declarations go with the next one.
+		start := lineStart(text, trim(text, prev, end).s)
+		units = append(units, codeUnit{span: span{start, end}, symbol: goSymbol(d)})
+		prev = end
+	}
+	if rest := trim(text, prev, len(text)); rest.s < rest.e {
+		units = append(units, codeUnit{span: span{lineStart(text, rest.s), rest.e}})
+	}
+	return c.splitCode(text, units), true
+}
+
+// goSymbol names a declaration: "func Name", "func Recv.Name", "type T",
+// "const A, B".
+func goSymbol(d ast.Decl) string {
+	switch d := d.(type) {
+	case *ast.FuncDecl:
+		if d.Recv != nil && len(d.Recv.List) > 0 {
+			return "func " + recvName(d.Recv.List[0].Type) + "." + d.Name.Name
+		}
+		return "func " + d.Name.Name
+	case *ast.GenDecl:
+		var names []string
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				names = append(names, s.Name.Name)
+			case *ast.ValueSpec:
+				for _, n := range s.Names {
+					names = append(names, n.Name)
+				}
+			}
+		}
+		return clipSymbol(d.Tok.String() + " " + strings.Join(names, ", "))
+	}
+	return ""
+}
+
+func recvName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return recvName(e.X)
+	case *ast.IndexExpr:
+		return recvName(e.X)
+	case *ast.IndexListExpr:
+		return recvName(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
+
+// braceUnits finds top-level blocks of C-like code. A unit starts at a
+// non-blank line at brace depth zero that follows a blank line or a line that
+// closed a block, so comments and annotations directly above a declaration
+// stay with it.
+func braceUnits(text string) []codeUnit {
+	var (
+		starts  []int
+		depth   int
+		comment bool // inside /* */
+		blank   = true
+		closed  bool // the last non-blank line ended a block
+	)
+	for _, line := range lines(text, 0, len(text)) {
+		l := text[line.s:line.e]
+		if strings.TrimSpace(l) == "" {
+			blank = true
+			continue
+		}
+		if depth == 0 && !comment && (blank || closed) {
+			starts = append(starts, line.s)
+		}
+		before := depth
+		depth, comment = braceDepth(l, depth, comment)
+		closed = depth == 0 && (before > 0 || strings.Contains(l, "}"))
+		blank = false
+	}
+	return unitsAt(text, starts, func(unit string) string {
+		return declLine(unit, []string{"//", "/*", "*", "@"}, "{")
+	})
+}
+
+// braceDepth updates the brace depth with one line, skipping string and
+// character literals and comments.
+func braceDepth(line string, depth int, comment bool) (int, bool) {
+	for i := 0; i < len(line); i++ {
+		if comment {
+			if strings.HasPrefix(line[i:], "*/") {
+				comment = false
+				i++
+			}
+			continue
+		}
+		switch ch := line[i]; ch {
+		case '/':
+			if strings.HasPrefix(line[i:], "//") {
+				return depth, false
+			}
+			if strings.HasPrefix(line[i:], "/*") {
+				comment = true
+				i++
+			}
+		case '"', '\'', '`':
+			for i++; i < len(line) && line[i] != ch; i++ {
+				if line[i] == '\\' {
+					i++
+				}
+			}
+		case '{':
+			depth++
+		case '}':
+			if depth > 0 {
+				depth--
+			}
+		}
+	}
+	return depth, comment
+}
+
+// indentUnits finds top-level blocks of indentation-structured code. A unit
+// starts at an unindented line that follows a blank line or an indented one,
+// unless it continues a statement (closing brackets, else/elif/except/finally).
+func indentUnits(text string) []codeUnit {
+	var starts []int
+	blank, indented := true, false
+	for _, line := range lines(text, 0, len(text)) {
+		l := text[line.s:line.e]
+		if strings.TrimSpace(l) == "" {
+			blank = true
+			continue
+		}
+		top := !isSpace(l[0])
+		if top && (blank || indented) && !continuesBlock(l) {
+			starts = append(starts, line.s)
+		}
+		blank, indented = false, !top
+	}
+	return unitsAt(text, starts, func(unit string) string {
+		return declLine(unit, []string{"#", "@"}, "")
+	})
+}
+
+func continuesBlock(line string) bool {
+	for _, p := range []string{")", "]", "}", "else", "elif", "except", "finally"} {
+		if strings.HasPrefix(line, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// unitsAt cuts text at the given line starts and names each unit.
+func unitsAt(text string, starts []int, name func(unit string) string) []codeUnit {
+	var units []codeUnit
+	if len(starts) == 0 || trim(text, 0, starts[0]).s < starts[0] {
+		starts = append([]int{0}, starts...)
+	}
+	for i, s := range starts {
+		e := len(text)
+		if i+1 < len(starts) {
+			e = starts[i+1]
+		}
+		sp := trim(text, s, e)
+		if sp.s == sp.e {
+			continue
+		}
+		sp.s = lineStart(text, sp.s)
+		units = append(units, codeUnit{span: sp, symbol: name(text[sp.s:sp.e])})
+	}
+	return units
+}
+
+// declLine returns the first line of unit that is not a comment or
+// annotation, cut before body and without a trailing colon.
+func declLine(unit string, skip []string, body string) string {
+next:
+	for _, l := range strings.Split(unit, "\n") {
+		l = strings.TrimSpace(l)
+		if l == "" {
+			continue
+		}
+		for _, p := range skip {
+			if strings.HasPrefix(l, p) {
+				continue next
+			}
+		}
+		if body != "" {
+			l, _, _ = strings.Cut(l, body)
+		}
+		return clipSymbol(strings.TrimSuffix(strings.TrimSpace(l), ":"))
+	}
+	return ""
+}
+
+func clipSymbol(s string) string {
+	if r := []rune(s); len(r) > maxSymbolLen {
+		return string(r[:maxSymbolLen]) + "…"
+	}
+	return s
+}
+
+// lineStart returns the offset of the start of the line containing off.
+func lineStart(text string, off int) int {
+	return strings.LastIndexByte(text[:off], '\n') + 1
+}
+
+// lineEnd returns the offset of the newline ending the line containing off,
+// or len(text).
+func lineEnd(text string, off int) int {
+	if i := strings.IndexByte(text[off:], '\n'); i >= 0 {
+		return off + i
+	}
+	return len(text)
+}
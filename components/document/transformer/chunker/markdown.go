@@ -0,0 +1,218 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"strings"
+)
+
+// mdSection is the text under one heading, up to the next heading.
+type mdSection struct {
+	span
+	body     int // offset where the text after the heading line(s) starts
+	headings []string
+}
+
+// splitMarkdown chunks each heading section separately. A section that is
+// only a heading is merged into the next one, so a title never becomes a
+// chunk of its own. Oversized sections are split by paragraphs and sentences;
+// fenced code blocks are split by lines.
+func (c *Chunker) splitMarkdown(text string) []Chunk {
+	sections := mdSections(text)
+	var out []Chunk
+	for i := 0; i < len(sections); i++ {
+		sec := sections[i]
+		start := sec.s
+		for trim(text, sec.body, sec.e).s == sec.e && i+1 < len(sections) {
+			i++
+			sec = sections[i]
+		}
+		sp := trim(text, start, sec.e)
+		if sp.s == sp.e {
+			continue
+		}
+		if sp.e-sp.s <= c.size {
+			out = append(out, newChunk(text, sp, sec.headings))
+			continue
+		}
+		out = append(out, c.chunks(text, pack(text, mdAtoms(text, sp.s, sp.e), c.size, c.overlap), sec.headings)...)
+	}
+	return out
+}
+
+// mdSections splits text at ATX ("## Title") and setext (underlined) headings
+// outside fenced code blocks. Each section records the breadcrumb of headings
+// it is nested under, including its own.
+func mdSections(text string) []mdSection {
+	var (
+		out   []mdSection
+		stack []mdHeading
+		fence string
+	)
+	cur := mdSection{}
+	open := func(start, body int, h mdHeading) {
+		cur.e = start
+		out = append(out, cur)
+		for len(stack) > 0 && stack[len(stack)-1].level >= h.level {
+			stack = stack[:len(stack)-1]
+		}
+		stack = append(stack, h)
+		crumbs := make([]string, len(stack))
+		for i, s := range stack {
+			crumbs[i] = s.title
+		}
+		cur = mdSection{span: span{start, 0}, body: body, headings: crumbs}
+	}
+
+	ls := lines(text, 0, len(text))
+	for i := frontMatterEnd(text, ls); i < len(ls); i++ {
+		line := text[ls[i].s:ls[i].e]
+		if fence != "" {
+			if closesFence(line, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if fence = fenceMarker(line); fence != "" {
+			continue
+		}
+		if h, ok := atxHeading(line); ok {
+			open(ls[i].s, ls[i].e, h)
+			continue
+		}
+		// Setext: a paragraph line underlined with === or ---.
+		if i+1 < len(ls) && strings.TrimSpace(line) != "" && !isIndented(line) {
+			if level := setextLevel(text[ls[i+1].s:ls[i+1].e]); level > 0 {
+				open(ls[i].s, ls[i+1].e, mdHeading{level: level, title: strings.TrimSpace(line)})
+				i++
+			}
+		}
+	}
+	cur.e = len(text)
+	out = append(out, cur)
+	return out
+}
+
+type mdHeading struct {
+	level int
+	title string
+}
+
+// atxHeading parses "### Title ###".
+func atxHeading(line string) (mdHeading, bool) {
+	if isIndented(line) {
+		return mdHeading{}, false
+	}
+	s := strings.TrimLeft(line, " ")
+	level := 0
+	for level < len(s) && s[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(s) && s[level] != ' ' && s[level] != '\t') {
+		return mdHeading{}, false
+	}
+	title := strings.TrimSpace(s[level:])
+	if t := strings.TrimRight(title, "#"); t == "" || strings.HasSuffix(t, " ") {
+		title = strings.TrimSpace(t)
+	}
+	return mdHeading{level: level, title: title}, true
+}
+
+func setextLevel(line string) int {
+	s := strings.TrimSpace(line)
+	if isIndented(line) || s == "" {
+		return 0
+	}
+	switch {
+	case strings.Trim(s, "=") == "":
+		return 1
+	case strings.Trim(s, "-") == "" && len(s) >= 2:
+		return 2
+	}
+	return 0
+}
+
+// fenceMarker returns the ``` or ~~~ run opening line, or "".
+func fenceMarker(line string) string {
+	if isIndented(line) {
+		return ""
+	}
+	s := strings.TrimLeft(line, " ")
+	for _, ch := range []string{"`", "~"} {
+		n := len(s) - len(strings.TrimLeft(s, ch))
+		if n >= 3 {
+			return s[:n]
+		}
+	}
+	return ""
+}
+
+// closesFence reports whether line closes a block opened with fence: a run
+// of the same character at least as long, with nothing after it.
+func closesFence(line, fence string) bool {
+	marker := fenceMarker(line)
+	return strings.HasPrefix(marker, fence) && strings.TrimSpace(line) == marker
+}
+
+// frontMatterEnd returns the index of the first line after a leading YAML
+// front matter block ("---" ... "---"), or 0 if there is none.
+func frontMatterEnd(text string, ls []span) int {
+	if len(ls) == 0 || strings.TrimSpace(text[ls[0].s:ls[0].e]) != "---" {
+		return 0
+	}
+	for i := 1; i < len(ls); i++ {
+		if s := strings.TrimSpace(text[ls[i].s:ls[i].e]); s == "---" || s == "..." {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// isIndented reports whether line is indented by four or more spaces (an
+// indented code block, or a continuation line).
+func isIndented(line string) bool {
+	return strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")
+}
+
+// mdAtoms returns the sentences of the prose in text[from:to] and the lines
+// of its fenced code blocks, which are never split by sentence.
+func mdAtoms(text string, from, to int) []atom {
+	var out []atom
+	prose := from
+	fence := ""
+	fenceStart := 0
+	for _, line := range lines(text, from, to) {
+		switch {
+		case fence == "":
+			if fence = fenceMarker(text[line.s:line.e]); fence != "" {
+				out = append(out, proseAtoms(text, prose, line.s)...)
+				fenceStart = line.s
+			}
+		case closesFence(text[line.s:line.e], fence):
+			block := lineAtoms(text, fenceStart, line.e)
+			if len(block) > 0 {
+				block[0].brk = true
+			}
+			out = append(out, block...)
+			fence, prose = "", line.e
+		}
+	}
+	if fence != "" {
+		prose = fenceStart
+	}
+	return append(out, proseAtoms(text, prose, to)...)
+}
@@ -0,0 +1,219 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// span is a [s, e) byte range of the source text.
+type span struct{ s, e int }
+
+// atom is the smallest piece pack will not split unless it is larger than a
+// chunk on its own. brk marks a preferred break before the atom, such as the
+// start of a paragraph.
+type atom struct {
+	span
+	brk bool
+}
+
+// pack greedily groups atoms into spans of at most size bytes. When a chunk
+// is full it is cut at its last preferred break if that keeps at least half
+// of it, so chunks end on paragraph boundaries where possible. Each new chunk
+// starts with up to overlap bytes of whole atoms from the end of the previous
+// one.
+func pack(text string, atoms []atom, size, overlap int) []span {
+	var out []span
+	var cur []atom
+	for i := 0; i < len(atoms); {
+		a := atoms[i]
+		if a.e-a.s > size {
+			// Replace the oversized atom by pieces that fit.
+			pieces := hardSplit(text, a, size)
+			atoms = append(atoms[:i:i], append(pieces, atoms[i+1:]...)...)
+			continue
+		}
+		if len(cur) == 0 || a.e-cur[0].s <= size {
+			cur = append(cur, a)
+			i++
+			continue
+		}
+
+		cut := len(cur)
+		for k := len(cur) - 1; k > 0; k-- {
+			if cur[k].brk {
+				if cur[k].s-cur[0].s >= size/2 {
+					cut = k
+				}
+				break
+			}
+		}
+		out = append(out, span{cur[0].s, cur[cut-1].e})
+		rest := cur[cut:]
+
+		// Overlap with the shortest tail of the flushed chunk that still fits
+		// in front of the pending atoms.
+		var tail []atom
+		if overlap > 0 {
+			last := cur[cut-1].e
+			for j := 1; j < cut; j++ {
+				if last-cur[j].s <= overlap && a.e-cur[j].s <= size {
+					tail = cur[j:cut]
+					break
+				}
+			}
+		}
+		cur = append(append([]atom(nil), tail...), rest...)
+	}
+	if len(cur) > 0 {
+		out = append(out, span{cur[0].s, cur[len(cur)-1].e})
+	}
+	return out
+}
+
+// hardSplit cuts an oversized atom into pieces of at most size bytes, breaking
+// at the last newline, then the last space, then a rune boundary.
+func hardSplit(text string, a atom, size int) []atom {
+	var out []atom
+	for s := a.s; s < a.e; {
+		e := a.e
+		if e-s > size {
+			e = s + size
+			if i := strings.LastIndexByte(text[s:e], '\n'); i > 0 {
+				e = s + i
+			} else if i := strings.LastIndexAny(text[s:e], " \t"); i > 0 {
+				e = s + i
+			} else {
+				for e > s+1 && !utf8.RuneStart(text[e]) {
+					e--
+				}
+			}
+		}
+		if sp := trim(text, s, e); sp.s < sp.e {
+			out = append(out, atom{span: sp, brk: len(out) == 0 && a.brk})
+		}
+		s = e
+	}
+	return out
+}
+
+// proseAtoms returns the sentences of text[from:to]. The first sentence of
+// each paragraph is a preferred break.
+func proseAtoms(text string, from, to int) []atom {
+	var out []atom
+	for _, para := range paragraphs(text, from, to) {
+		for i, sent := range sentences(text, para) {
+			out = append(out, atom{span: sent, brk: i == 0})
+		}
+	}
+	return out
+}
+
+// lineAtoms returns the non-blank lines of text[from:to]. A line following a
+// blank line is a preferred break.
+func lineAtoms(text string, from, to int) []atom {
+	var out []atom
+	blank := true
+	for _, line := range lines(text, from, to) {
+		sp := trim(text, line.s, line.e)
+		if sp.s == sp.e {
+			blank = true
+			continue
+		}
+		// Keep indentation: code chunks start at the beginning of the line.
+		out = append(out, atom{span: span{line.s, sp.e}, brk: blank})
+		blank = false
+	}
+	return out
+}
+
+// sentences splits a paragraph after sentence terminators. ASCII terminators
+// must be followed by whitespace; CJK ones end a sentence on their own.
+func sentences(text string, para span) []span {
+	var out []span
+	start := para.s
+	for i := para.s; i < para.e; {
+		r, n := utf8.DecodeRuneInString(text[i:])
+		i += n
+		end := false
+		switch r {
+		case '.', '!', '?', ';':
+			end = i == para.e || isSpace(text[i])
+		case '。', '！', '？', '；':
+			end = true
+		}
+		if end {
+			if sp := trim(text, start, i); sp.s < sp.e {
+				out = append(out, sp)
+			}
+			start = i
+		}
+	}
+	if sp := trim(text, start, para.e); sp.s < sp.e {
+		out = append(out, sp)
+	}
+	return out
+}
+
+// lines returns the lines of text[from:to] without their newlines.
+func lines(text string, from, to int) []span {
+	var out []span
+	for s := from; s < to; {
+		e := to
+		if i := strings.IndexByte(text[s:to], '\n'); i >= 0 {
+			e = s + i
+		}
+		out = append(out, span{s, e})
+		s = e + 1
+	}
+	return out
+}
+
+// paragraphs returns the runs of non-blank lines in text[from:to], trimmed.
+func paragraphs(text string, from, to int) []span {
+	var out []span
+	start := -1
+	for _, line := range lines(text, from, to) {
+		if sp := trim(text, line.s, line.e); sp.s == sp.e {
+			if start >= 0 {
+				out = append(out, trim(text, start, line.s))
+				start = -1
+			}
+		} else if start < 0 {
+			start = sp.s
+		}
+	}
+	if start >= 0 {
+		out = append(out, trim(text, start, to))
+	}
+	return out
+}
+
+func trim(text string, s, e int) span {
+	for s < e && isSpace(text[s]) {
+		s++
+	}
+	for e > s && isSpace(text[e-1]) {
+		e--
+	}
+	return span{s, e}
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\f' || b == '\v'
+}
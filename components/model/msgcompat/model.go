@@ -0,0 +1,89 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package msgcompat
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// ChatModel applies a profile to the input of every Generate and Stream call
+// of the wrapped model.
+type ChatModel struct {
+	model   model.BaseChatModel
+	profile Profile
+}
+
+// Wrap returns m with p applied to every request.
+func Wrap(m model.BaseChatModel, p Profile) *ChatModel {
+	return &ChatModel{model: m, profile: p}
+}
+
+// Profile returns the profile applied by the model.
+func (c *ChatModel) Profile() Profile {
+	return c.profile
+}
+
+// Generate implements model.BaseChatModel.
+func (c *ChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	return c.model.Generate(ctx, c.profile.Transform(input), opts...)
+}
+
+// Stream implements model.BaseChatModel.
+func (c *ChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return c.model.Stream(ctx, c.profile.Transform(input), opts...)
+}
+
+// WithTools implements model.ToolCallingChatModel. It fails if the wrapped
+// model cannot call tools.
+func (c *ChatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	tcm, ok := c.model.(model.ToolCallingChatModel)
+	if !ok {
+		return nil, fmt.Errorf("%T does not support tool calling", c.model)
+	}
+	m, err := tcm.WithTools(tools)
+	if err != nil {
+		return nil, err
+	}
+	return Wrap(m, c.profile), nil
+}
+
+// IsCallbacksEnabled implements components.Checker by delegating to the
+// wrapped model, so its callbacks are reported once.
+func (c *ChatModel) IsCallbacksEnabled() bool {
+	if checker, ok := c.model.(components.Checker); ok {
+		return checker.IsCallbacksEnabled()
+	}
+	return false
+}
+
+// GetType reports the type of the wrapped model.
+func (c *ChatModel) GetType() string {
+	if typ, ok := components.GetType(c.model); ok {
+		return typ
+	}
+	return "MsgCompatChatModel"
+}
+
+var (
+	_ model.ToolCallingChatModel = (*ChatModel)(nil)
+	_ components.Checker         = (*ChatModel)(nil)
+)
@@ -0,0 +1,262 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package msgcompat
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// conversations is the corpus every profile is checked against. It covers
+// the shapes that trip providers up.
+func conversations() map[string][]*schema.Message {
+	reasoning := schema.AssistantMessage("Let me plan.", nil)
+	reasoning.ReasoningContent = "thinking..."
+	reasoning.Extra = map[string]any{"_eino_deepseek_reasoning_content": "thinking...", "keep": 1}
+	call := schema.AssistantMessage("Checking the weather.", []schema.ToolCall{
+		{ID: "1", Function: schema.FunctionCall{Name: "weather", Arguments: `{"city":"Beijing"}`}},
+		{ID: "2", Function: schema.FunctionCall{Name: "weather", Arguments: `{"city":"Shanghai"}`}},
+	})
+	return map[string][]*schema.Message{
+		"plain": {
+			schema.SystemMessage("You are helpful."),
+			schema.UserMessage("Hi"),
+			schema.AssistantMessage("Hello!", nil),
+		},
+		"tools": {
+			schema.SystemMessage("You are helpful."),
+			schema.UserMessage("Weather in Beijing and Shanghai?"),
+			call,
+			schema.ToolMessage("sunny", "1", schema.WithToolName("weather")),
+			schema.ToolMessage("rainy", "2", schema.WithToolName("weather")),
+			schema.AssistantMessage("Beijing is sunny, Shanghai rainy.", nil),
+		},
+		"plan-execute": {
+			schema.SystemMessage("You are the executor."),
+			schema.UserMessage("Plan a day out."),
+			reasoning,
+			schema.AssistantMessage("", nil),
+			schema.AssistantMessage("1. Go to the park.", nil),
+			schema.UserMessage("Execute step 1."),
+			schema.UserMessage("Remember the budget."),
+			schema.SystemMessage("Answer in Chinese."),
+		},
+	}
+}
+
+// conformance holds what each built-in profile guarantees about its output.
+// Every profile in Default must have an entry.
+var conformance = map[string]func(out []*schema.Message) error{
+	ProfileDeepSeek: func(out []*schema.Message) error {
+		return all(out, noReasoning, noEmptyAssistant)
+	},
+	ProfileDeepSeekReasoner: func(out []*schema.Message) error {
+		if err := all(out, noReasoning, noEmptyAssistant, noToolRole); err != nil {
+			return err
+		}
+		return alternating(out)
+	},
+	ProfileArk: func(out []*schema.Message) error {
+		return all(out, noEmptyAssistant)
+	},
+	ProfileNoSystemRole: func(out []*schema.Message) error {
+		if err := all(out, func(m *schema.Message) error {
+			if m.Role == schema.System {
+				return fmt.Errorf("system message left")
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		return alternating(out)
+	},
+}
+
+func TestProfileConformance(t *testing.T) {
+	for _, p := range Default.Profiles() {
+		check, ok := conformance[p.Name]
+		if !ok {
+			t.Errorf("profile %q has no conformance check", p.Name)
+			continue
+		}
+		for name, msgs := range conversations() {
+			t.Run(p.Name+"/"+name, func(t *testing.T) {
+				before := snapshot(msgs)
+				out := p.Transform(msgs)
+				if !reflect.DeepEqual(snapshot(msgs), before) {
+					t.Fatal("input messages were modified")
+				}
+				if err := check(out); err != nil {
+					t.Fatalf("%v\n%s", err, dump(out))
+				}
+				if again := p.Transform(out); !reflect.DeepEqual(snapshot(again), snapshot(out)) {
+					t.Fatalf("not idempotent:\n%s\nthen\n%s", dump(out), dump(again))
+				}
+				// No text the model needs is lost.
+				joined := dump(out)
+				for _, m := range msgs {
+					if m.Content != "" && !strings.Contains(joined, m.Content) {
+						t.Fatalf("content %q lost:\n%s", m.Content, joined)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	for modelName, want := range map[string]string{
+		"deepseek-chat":             ProfileDeepSeek,
+		"deepseek-reasoner":         ProfileDeepSeekReasoner,
+		"DeepSeek-R1-250120":        ProfileDeepSeekReasoner,
+		"doubao-1.5-pro-32k-250115": ProfileArk,
+		"ep-20250101123456-abcde":   ProfileArk,
+		"o1-mini":                   ProfileNoSystemRole,
+		"gpt-4o":                    Passthrough.Name,
+	} {
+		if got := Default.Match(modelName).Name; got != want {
+			t.Errorf("Match(%q) = %q, want %q", modelName, got, want)
+		}
+	}
+
+	// A profile registered later takes precedence; re-registering replaces.
+	r := NewRegistry()
+	_ = r.Register(Profile{Name: "a", Models: regexp.MustCompile("gpt")})
+	_ = r.Register(Profile{Name: "b", Models: regexp.MustCompile("gpt-4")})
+	if got := r.Match("gpt-4o").Name; got != "b" {
+		t.Fatalf("Match = %q, want b", got)
+	}
+	_ = r.Register(Profile{Name: "a", Models: regexp.MustCompile("gpt-4o")})
+	if got := r.Match("gpt-4o").Name; got != "a" || len(r.Profiles()) != 2 {
+		t.Fatalf("Match = %q after re-registering, profiles = %d", got, len(r.Profiles()))
+	}
+	if err := r.Register(Profile{Name: "c"}); err == nil {
+		t.Fatal("profile without a pattern was registered")
+	}
+}
+
+// recordingModel records the messages it is called with.
+type recordingModel struct {
+	got []*schema.Message
+}
+
+func (m *recordingModel) Generate(_ context.Context, in []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	m.got = in
+	return schema.AssistantMessage("ok", nil), nil
+}
+
+func (m *recordingModel) Stream(_ context.Context, in []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	m.got = in
+	return schema.StreamReaderFromArray([]*schema.Message{schema.AssistantMessage("ok", nil)}), nil
+}
+
+func TestWrap(t *testing.T) {
+	inner := &recordingModel{}
+	cm := Wrap(inner, Default.Match("deepseek-r1"))
+	if _, err := cm.Generate(context.Background(), conversations()["tools"]); err != nil {
+		t.Fatal(err)
+	}
+	if err := noToolRole(inner.got[len(inner.got)-1]); err != nil || len(inner.got) != 3 {
+		t.Fatalf("wrapped model got:\n%s", dump(inner.got))
+	}
+	if _, err := cm.WithTools(nil); err == nil {
+		t.Fatal("WithTools succeeded on a model without tool calling")
+	}
+}
+
+func all(out []*schema.Message, checks ...func(*schema.Message) error) error {
+	for i, m := range out {
+		for _, check := range checks {
+			if err := check(m); err != nil {
+				return fmt.Errorf("message %d: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+func noReasoning(m *schema.Message) error {
+	if m.ReasoningContent != "" || hasAnyExtra(m, extraKeysReasoning) {
+		return fmt.Errorf("reasoning content left")
+	}
+	return nil
+}
+
+func noEmptyAssistant(m *schema.Message) error {
+	if m.Role == schema.Assistant && m.Content == "" && len(m.ToolCalls) == 0 {
+		return fmt.Errorf("empty assistant message")
+	}
+	return nil
+}
+
+func noToolRole(m *schema.Message) error {
+	if m.Role == schema.Tool || len(m.ToolCalls) > 0 {
+		return fmt.Errorf("tool message or tool call left")
+	}
+	return nil
+}
+
+// alternating checks that no two user or assistant messages are adjacent.
+// Parallel tool results may follow each other.
+func alternating(out []*schema.Message) error {
+	var last schema.RoleType
+	for i, m := range out {
+		if m.Role == schema.System {
+			continue
+		}
+		if m.Role == last && m.Role != schema.Tool {
+			return fmt.Errorf("messages %d and %d are both %s", i-1, i, m.Role)
+		}
+		last = m.Role
+	}
+	return nil
+}
+
+type msgSnapshot struct {
+	Role      schema.RoleType
+	Content   string
+	Reasoning string
+	ToolCalls int
+	Extra     string
+}
+
+func snapshot(msgs []*schema.Message) []msgSnapshot {
+	out := make([]msgSnapshot, len(msgs))
+	for i, m := range msgs {
+		out[i] = msgSnapshot{m.Role, m.Content, m.ReasoningContent, len(m.ToolCalls), fmt.Sprint(m.Extra)}
+	}
+	return out
+}
+
+func dump(msgs []*schema.Message) string {
+	var b strings.Builder
+	for i, m := range msgs {
+		fmt.Fprintf(&b, "[%d] %s: %q", i, m.Role, m.Content)
+		for _, tc := range m.ToolCalls {
+			fmt.Fprintf(&b, " call %s(%s)", tc.Function.Name, tc.Function.Arguments)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package msgcompat
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// Profile is the set of transformers a family of models needs.
+type Profile struct {
+	Name string
+	// Models matches the model names that use this profile.
+	Models *regexp.Regexp
+	// Transformers are applied in order.
+	Transformers []Transformer
+}
+
+// Transform applies the profile's transformers to msgs.
+func (p Profile) Transform(msgs []*schema.Message) []*schema.Message {
+	return Chain(p.Transformers...)(msgs)
+}
+
+// Passthrough is the profile of models no registered profile matches. It
+// leaves messages unchanged.
+var Passthrough = Profile{Name: "passthrough"}
+
+// Registry selects profiles by model name.
+type Registry struct {
+	mu       sync.RWMutex
+	profiles []Profile
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds a profile, replacing any profile of the same name. Profiles
+// registered later are matched first, so a specific profile can be
+// registered after a general one it overrides.
+func (r *Registry) Register(p Profile) error {
+	if p.Name == "" || p.Models == nil {
+		return fmt.Errorf("profile needs a name and a model pattern")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, old := range r.profiles {
+		if old.Name == p.Name {
+			r.profiles = append(r.profiles[:i], r.profiles[i+1:]...)
+			break
+		}
+	}
+	r.profiles = append(r.profiles, p)
+	return nil
+}
+
+// Match returns the profile for modelName, Passthrough if none matches.
+func (r *Registry) Match(modelName string) Profile {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for i := len(r.profiles) - 1; i >= 0; i-- {
+		if r.profiles[i].Models.MatchString(modelName) {
+			return r.profiles[i]
+		}
+	}
+	return Passthrough
+}
+
+// Profile returns the profile with the given name.
+func (r *Registry) Profile(name string) (Profile, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, p := range r.profiles {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Profile{}, false
+}
+
+// Profiles returns the registered profiles in registration order.
+func (r *Registry) Profiles() []Profile {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]Profile(nil), r.profiles...)
+}
+
+// Names of the built-in profiles.
+const (
+	ProfileDeepSeek         = "deepseek"
+	ProfileDeepSeekReasoner = "deepseek-reasoner"
+	ProfileArk              = "ark"
+	ProfileNoSystemRole     = "no-system-role"
+)
+
+// Default holds the built-in profiles:
+//   - deepseek: DeepSeek chat models, which reject reasoning content sent back.
+//   - deepseek-reasoner: DeepSeek-R1, which on some platforms has no function
+//     calling and requires user and assistant turns to alternate.
+//   - ark: Doubao models and Ark endpoints, which reject empty assistant messages.
+//   - no-system-role: models without a system role, such as o1-mini and Gemma.
+var Default = func() *Registry {
+	r := NewRegistry()
+	for _, p := range []Profile{
+		{
+			Name:         ProfileDeepSeek,
+			Models:       regexp.MustCompile(`(?i)deepseek`),
+			Transformers: []Transformer{StripReasoning, DropEmptyAssistant},
+		},
+		{
+			Name:         ProfileDeepSeekReasoner,
+			Models:       regexp.MustCompile(`(?i)deepseek-(r1|reasoner)`),
+			Transformers: []Transformer{StripReasoning, FlattenToolResults, DropEmptyAssistant, MergeConsecutive},
+		},
+		{
+			Name:         ProfileArk,
+			Models:       regexp.MustCompile(`(?i)^(doubao|ep-)`),
+			Transformers: []Transformer{DropEmptyAssistant},
+		},
+		{
+			Name:         ProfileNoSystemRole,
+			Models:       regexp.MustCompile(`(?i)^(o1-mini|o1-preview|gemma)`),
+			Transformers: []Transformer{MergeSystem, SystemToUser, MergeConsecutive},
+		},
+	} {
+		_ = r.Register(p)
+	}
+	return r
+}()
@@ -0,0 +1,199 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package msgcompat adapts conversations to the quirks of model providers.
+//
+// Providers disagree on what a valid conversation looks like: some reject
+// tool messages, some reject reasoning content sent back to them, some have
+// no system role or require user and assistant turns to alternate. Instead of
+// every agent patching messages for the model it happens to use, a Profile
+// bundles the Transformers a provider needs, a Registry selects the profile
+// by model name, and Wrap applies it to every request of any ChatModel:
+//
+//	cm := msgcompat.Wrap(deepseekModel, msgcompat.Default.Match("deepseek-r1-250120"))
+//
+// Transformers never modify the messages they are given; changed messages are
+// copies.
+package msgcompat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// Transformer rewrites the messages of a request.
+type Transformer func(msgs []*schema.Message) []*schema.Message
+
+// Chain applies transformers in order.
+func Chain(ts ...Transformer) Transformer {
+	return func(msgs []*schema.Message) []*schema.Message {
+		for _, t := range ts {
+			msgs = t(msgs)
+		}
+		return msgs
+	}
+}
+
+// extraKeysReasoning are the Extra keys under which eino-ext models keep the
+// reasoning content of a response.
+var extraKeysReasoning = []string{"_eino_deepseek_reasoning_content", "ark-reasoning-content"}
+
+// StripReasoning removes the reasoning content of earlier responses. Reasoning
+// models such as DeepSeek-R1 reject requests that send it back.
+func StripReasoning(msgs []*schema.Message) []*schema.Message {
+	out := make([]*schema.Message, len(msgs))
+	for i, m := range msgs {
+		out[i] = m
+		if m.ReasoningContent == "" && !hasAnyExtra(m, extraKeysReasoning) {
+			continue
+		}
+		cp := *m
+		cp.ReasoningContent = ""
+		if hasAnyExtra(m, extraKeysReasoning) {
+			cp.Extra = make(map[string]any, len(m.Extra))
+			for k, v := range m.Extra {
+				cp.Extra[k] = v
+			}
+			for _, k := range extraKeysReasoning {
+				delete(cp.Extra, k)
+			}
+		}
+		out[i] = &cp
+	}
+	return out
+}
+
+// DropEmptyAssistant removes assistant messages with neither content nor tool
+// calls, which some providers reject, e.g. the empty answer after a
+// reasoning-only response.
+func DropEmptyAssistant(msgs []*schema.Message) []*schema.Message {
+	out := make([]*schema.Message, 0, len(msgs))
+	for _, m := range msgs {
+		if m.Role == schema.Assistant && m.Content == "" && len(m.ToolCalls) == 0 && len(m.AssistantGenMultiContent) == 0 {
+			continue
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+// FlattenToolResults rewrites tool calls and tool results as plain assistant
+// text, for models served without function calling (e.g. DeepSeek-R1 on some
+// platforms) that reject the tool role.
+func FlattenToolResults(msgs []*schema.Message) []*schema.Message {
+	out := make([]*schema.Message, 0, len(msgs))
+	for _, m := range msgs {
+		switch {
+		case m.Role == schema.Tool:
+			out = append(out, schema.AssistantMessage(m.Content, nil))
+		case m.Role == schema.Assistant && len(m.ToolCalls) > 0:
+			if m.Content != "" {
+				out = append(out, schema.AssistantMessage(m.Content, nil))
+			}
+			for _, tc := range m.ToolCalls {
+				out = append(out, schema.AssistantMessage(fmt.Sprintf("call %s with %s, got response:", tc.Function.Name, tc.Function.Arguments), nil))
+			}
+		default:
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// MergeSystem combines all system messages into one at the start of the
+// conversation, for providers that accept a single leading system prompt.
+func MergeSystem(msgs []*schema.Message) []*schema.Message {
+	var (
+		system []string
+		rest   = make([]*schema.Message, 0, len(msgs))
+	)
+	for _, m := range msgs {
+		if m.Role == schema.System {
+			system = append(system, m.Content)
+			continue
+		}
+		rest = append(rest, m)
+	}
+	if len(system) == 0 {
+		return rest
+	}
+	return append([]*schema.Message{schema.SystemMessage(strings.Join(system, "\n\n"))}, rest...)
+}
+
+// SystemToUser turns system messages into user messages, for models without a
+// system role.
+func SystemToUser(msgs []*schema.Message) []*schema.Message {
+	out := make([]*schema.Message, len(msgs))
+	for i, m := range msgs {
+		out[i] = m
+		if m.Role == schema.System {
+			cp := *m
+			cp.Role = schema.User
+			out[i] = &cp
+		}
+	}
+	return out
+}
+
+// MergeConsecutive joins adjacent user messages, and adjacent assistant
+// messages without tool calls, for providers that require the two roles to
+// alternate. Multimodal messages are kept as they are.
+func MergeConsecutive(msgs []*schema.Message) []*schema.Message {
+	out := make([]*schema.Message, 0, len(msgs))
+	for _, m := range msgs {
+		if n := len(out); n > 0 && mergeable(out[n-1], m) {
+			cp := *out[n-1]
+			cp.Content = joinContent(cp.Content, m.Content)
+			out[n-1] = &cp
+			continue
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+func mergeable(prev, m *schema.Message) bool {
+	if prev.Role != m.Role || (m.Role != schema.User && m.Role != schema.Assistant) {
+		return false
+	}
+	for _, x := range []*schema.Message{prev, m} {
+		if len(x.ToolCalls) > 0 || len(x.MultiContent) > 0 || len(x.UserInputMultiContent) > 0 || len(x.AssistantGenMultiContent) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func joinContent(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	}
+	return a + "\n\n" + b
+}
+
+func hasAnyExtra(m *schema.Message, keys []string) bool {
+	for _, k := range keys {
+		if _, ok := m.Extra[k]; ok {
+			return true
+		}
+	}
+	return false
+}
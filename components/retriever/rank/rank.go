@@ -0,0 +1,88 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package rank provides the text scoring shared by the examples that search
+// without a vector database: a tokenizer that handles CJK text, Okapi BM25 and
+// cosine similarity.
+package rank
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// BM25 parameters.
+const (
+	K1 = 1.2
+	B  = 0.75
+)
+
+// Tokenize lowercases text and splits it into letter/digit runs. Han, kana and
+// hangul characters have no spaces between words, so each one is its own term.
+func Tokenize(text string) []string {
+	var terms []string
+	var buf strings.Builder
+	flush := func() {
+		if buf.Len() > 0 {
+			terms = append(terms, buf.String())
+			buf.Reset()
+		}
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			flush()
+			terms = append(terms, string(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			buf.WriteRune(r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return terms
+}
+
+// IDF is the BM25 inverse document frequency of a term found in df of n documents.
+func IDF(n, df float64) float64 {
+	return math.Log(1 + (n-df+0.5)/(df+0.5))
+}
+
+// BM25 scores one term that occurs tf times in a document of docLen terms,
+// where documents average avgLen terms.
+func BM25(idf, tf, docLen, avgLen float64) float64 {
+	norm := 1 - B + B*docLen/avgLen
+	return idf * tf * (K1 + 1) / (tf + K1*norm)
+}
+
+// Cosine returns the cosine similarity of a and b, 0 if their lengths differ
+// or either is the zero vector.
+func Cosine(a, b []float64) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
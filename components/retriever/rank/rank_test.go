@@ -0,0 +1,57 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rank
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("Hello, eino_v2 世界!")
+	if want := []string{"hello", "eino_v2", "世", "界"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Tokenize = %q, want %q", got, want)
+	}
+}
+
+func TestBM25(t *testing.T) {
+	idf := IDF(10, 1)
+	if rare, common := idf, IDF(10, 9); rare <= common || common <= 0 {
+		t.Fatalf("IDF rare %v, common %v", rare, common)
+	}
+	// More occurrences score higher, longer documents lower.
+	if BM25(idf, 2, 10, 10) <= BM25(idf, 1, 10, 10) || BM25(idf, 1, 20, 10) >= BM25(idf, 1, 10, 10) {
+		t.Fatal("BM25 is not monotonic in tf and document length")
+	}
+}
+
+func TestCosine(t *testing.T) {
+	for _, tc := range []struct {
+		a, b []float64
+		want float64
+	}{
+		{[]float64{1, 0}, []float64{2, 0}, 1},
+		{[]float64{1, 0}, []float64{0, 1}, 0},
+		{[]float64{1, 0}, []float64{1, 0, 0}, 0},
+		{[]float64{0, 0}, []float64{1, 0}, 0},
+	} {
+		if got := Cosine(tc.a, tc.b); math.Abs(got-tc.want) > 1e-9 {
+			t.Fatalf("Cosine(%v, %v) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
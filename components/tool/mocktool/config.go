@@ -0,0 +1,290 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mocktool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/eino-contrib/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the YAML document describing the mock tools.
+type Config struct {
+	Tools []*ToolConfig `yaml:"tools"`
+}
+
+// ToolConfig describes one tool.
+type ToolConfig struct {
+	Name string `yaml:"name"`
+	Desc string `yaml:"desc"`
+	// Parameters is the JSON schema of the arguments. Required properties are
+	// checked before a response is chosen.
+	Parameters map[string]any `yaml:"parameters"`
+	// Data is made available to response templates as .Data, so that large
+	// fixtures are written once and selected by the arguments.
+	Data any `yaml:"data"`
+	// Latency delays every response that sets none of its own.
+	Latency time.Duration `yaml:"latency"`
+	// Responses are tried in order; the first that matches answers.
+	Responses []*Response `yaml:"responses"`
+}
+
+// Response is a canned answer.
+type Response struct {
+	// Match maps argument names to matchers. All must match; an empty Match
+	// matches every call. Nested arguments are addressed with dots, e.g.
+	// "filter.city".
+	Match map[string]*Matcher `yaml:"match"`
+
+	// Exactly one of Body, Template and Error is set. Body is returned as is
+	// if it is a string and as JSON otherwise. Template is a text/template
+	// executed with .Args, .Data and .Count (calls of the tool so far,
+	// including this one).
+	Body     any    `yaml:"body"`
+	Template string `yaml:"template"`
+	Error    string `yaml:"error"`
+
+	// ErrorRate fails the given fraction of matching calls with Error, or a
+	// generic error if Error is empty, and answers the others normally.
+	ErrorRate float64       `yaml:"error_rate"`
+	Latency   time.Duration `yaml:"latency"`
+
+	tmpl *template.Template
+}
+
+// Matcher matches one argument. All conditions set must hold.
+type Matcher struct {
+	Equals   any    `yaml:"equals"`
+	Contains string `yaml:"contains"`
+	Regex    string `yaml:"regex"`
+	// Exists requires the argument to be present, or absent if false.
+	Exists *bool `yaml:"exists"`
+
+	re *regexp.Regexp
+}
+
+// UnmarshalYAML lets a scalar stand for an equals matcher, so `city: 北京`
+// is short for `city: {equals: 北京}`.
+func (m *Matcher) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind != yaml.MappingNode {
+		return n.Decode(&m.Equals)
+	}
+	type plain Matcher
+	return n.Decode((*plain)(m))
+}
+
+// ParseConfig parses and validates a YAML config.
+func ParseConfig(data []byte) (*Config, error) {
+	c := &Config{}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	return c, c.validate()
+}
+
+func (c *Config) validate() error {
+	seen := map[string]bool{}
+	for i, t := range c.Tools {
+		if t == nil || t.Name == "" {
+			return fmt.Errorf("tool %d has no name", i)
+		}
+		if seen[t.Name] {
+			return fmt.Errorf("duplicate tool %s", t.Name)
+		}
+		seen[t.Name] = true
+		if _, err := t.schema(); err != nil {
+			return fmt.Errorf("tool %s: parameters: %w", t.Name, err)
+		}
+		for j, r := range t.Responses {
+			if err := r.compile(); err != nil {
+				return fmt.Errorf("tool %s: response %d: %w", t.Name, j, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (t *ToolConfig) schema() (*jsonschema.Schema, error) {
+	if t.Parameters == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(t.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	s := &jsonschema.Schema{}
+	if err = json.Unmarshal(b, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *Response) compile() error {
+	if r == nil {
+		return errors.New("empty response")
+	}
+	set := 0
+	for _, ok := range []bool{r.Body != nil, r.Template != "", r.Error != "" && r.ErrorRate == 0} {
+		if ok {
+			set++
+		}
+	}
+	if set != 1 {
+		return errors.New("set exactly one of body, template and error")
+	}
+	if r.ErrorRate < 0 || r.ErrorRate > 1 {
+		return fmt.Errorf("error_rate %v is not between 0 and 1", r.ErrorRate)
+	}
+	if r.Template != "" {
+		tmpl, err := template.New("response").Funcs(funcs).Parse(r.Template)
+		if err != nil {
+			return err
+		}
+		r.tmpl = tmpl
+	}
+	for name, m := range r.Match {
+		if m == nil {
+			return fmt.Errorf("match %s: empty matcher", name)
+		}
+		if m.Regex != "" {
+			re, err := regexp.Compile(m.Regex)
+			if err != nil {
+				return fmt.Errorf("match %s: %w", name, err)
+			}
+			m.re = re
+		}
+	}
+	return nil
+}
+
+func (r *Response) matches(args map[string]any) bool {
+	for name, m := range r.Match {
+		v, ok := lookup(args, name)
+		if !m.matches(v, ok) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *Matcher) matches(v any, present bool) bool {
+	if m.Exists != nil && *m.Exists != present {
+		return false
+	}
+	if m.Equals == nil && m.Contains == "" && m.re == nil {
+		return true
+	}
+	if !present {
+		return false
+	}
+	if m.Equals != nil && !equal(m.Equals, v) {
+		return false
+	}
+	s := fmt.Sprint(v)
+	if m.Contains != "" && !strings.Contains(s, m.Contains) {
+		return false
+	}
+	return m.re == nil || m.re.MatchString(s)
+}
+
+// lookup finds a dotted path in decoded JSON arguments.
+func lookup(args map[string]any, path string) (any, bool) {
+	var cur any = args
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = obj[key]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// equal compares a YAML value with a JSON one. Both are normalized through
+// JSON, so that the YAML int 3 equals the JSON number 3.
+func equal(want, got any) bool {
+	return reflect.DeepEqual(normalize(want), normalize(got))
+}
+
+func normalize(v any) any {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out any
+	if json.Unmarshal(b, &out) != nil {
+		return v
+	}
+	return out
+}
+
+// funcs are the functions available to response templates besides the
+// text/template builtins.
+var funcs = template.FuncMap{
+	// json encodes a value.
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	// take returns the first n elements of a list.
+	"take": func(n any, list []any) []any {
+		k := int(toFloat(n))
+		if k < 0 || k > len(list) {
+			k = len(list)
+		}
+		return list[:k]
+	},
+	// default returns v, or def if v is missing or zero.
+	"default": func(def, v any) any {
+		if v == nil || reflect.ValueOf(v).IsZero() {
+			return def
+		}
+		return v
+	},
+	// fail makes the call return an error.
+	"fail": func(format string, args ...any) (string, error) {
+		return "", &failError{fmt.Errorf(format, args...)}
+	},
+}
+
+// failError is the error of the fail template function, returned to the
+// caller without the template position.
+type failError struct{ err error }
+
+func (e *failError) Error() string { return e.err.Error() }
+
+func toFloat(v any) float64 {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case float64:
+		return n
+	}
+	return -1
+}
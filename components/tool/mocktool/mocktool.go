@@ -0,0 +1,278 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package mocktool serves fake tools described in YAML.
+//
+// Each tool has a name, a description and a JSON schema of its parameters,
+// and a list of responses. A response matches calls by their arguments and
+// answers with a canned body, a text/template rendered from the arguments, or
+// an error; latency and random failures can be injected. Every call is
+// recorded, so tests and evaluations can assert what an agent did.
+//
+//	tools:
+//	  - name: get_weather
+//	    desc: Get the weather of a city
+//	    parameters:
+//	      type: object
+//	      properties:
+//	        city: {type: string}
+//	      required: [city]
+//	    responses:
+//	      - match: {city: 北京}
+//	        body: {weather: sunny}
+//	      - template: '{"weather": "rainy in {{.Args.city}}"}'
+//	        latency: 200ms
+//
+//	srv, err := mocktool.Load("tools.yaml")
+//	agent, err := react.NewAgent(ctx, &react.AgentConfig{
+//		ToolsConfig: compose.ToolsNodeConfig{Tools: srv.Tools()},
+//	})
+//	calls := srv.Calls("get_weather")
+package mocktool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+)
+
+// ErrInjected is the error of a call failed by error_rate without an error
+// message.
+var ErrInjected = errors.New("injected failure")
+
+// Call is a recorded tool call.
+type Call struct {
+	Tool      string
+	Arguments string
+	Args      map[string]any // decoded Arguments
+	// Response is the index of the response that answered, -1 if none did.
+	Response int
+	Output   string
+	Err      error
+	Time     time.Time
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithSeed seeds the random failures of error_rate, for reproducible runs.
+func WithSeed(seed int64) Option {
+	return func(s *Server) {
+		s.rand = rand.New(rand.NewSource(seed))
+	}
+}
+
+// Server holds the mock tools of a config and records their calls.
+type Server struct {
+	tools []*mockTool
+
+	mu    sync.Mutex
+	rand  *rand.Rand
+	calls []Call
+}
+
+// Load reads a YAML config from path.
+func Load(path string, opts ...Option) (*Server, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data, opts...)
+}
+
+// Parse creates a Server from a YAML config.
+func Parse(data []byte, opts ...Option) (*Server, error) {
+	c, err := ParseConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	return New(c, opts...)
+}
+
+// New creates a Server from a parsed config.
+func New(c *Config, opts ...Option) (*Server, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	s := &Server{rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+	for _, opt := range opts {
+		opt(s)
+	}
+	for _, tc := range c.Tools {
+		js, _ := tc.schema()
+		info := &schema.ToolInfo{Name: tc.Name, Desc: tc.Desc}
+		var required []string
+		if js != nil {
+			info.ParamsOneOf = schema.NewParamsOneOfByJSONSchema(js)
+			required = js.Required
+		}
+		s.tools = append(s.tools, &mockTool{srv: s, conf: tc, info: info, required: required})
+	}
+	return s, nil
+}
+
+// Tools returns the tools in config order.
+func (s *Server) Tools() []tool.BaseTool {
+	out := make([]tool.BaseTool, 0, len(s.tools))
+	for _, t := range s.tools {
+		out = append(out, t)
+	}
+	return out
+}
+
+// Tool returns the tool called name.
+func (s *Server) Tool(name string) (tool.InvokableTool, bool) {
+	for _, t := range s.tools {
+		if t.conf.Name == name {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// Calls returns the recorded calls of the named tools, or of all tools if
+// none are named, in call order.
+func (s *Server) Calls(names ...string) []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []Call
+	for _, c := range s.calls {
+		if len(names) == 0 || slices.Contains(names, c.Tool) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Reset forgets the recorded calls.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = nil
+}
+
+// roll returns the number of this call of the tool, and whether it fails
+// with probability p.
+func (s *Server) roll(name string, p float64) (count int, fail bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.calls {
+		if c.Tool == name {
+			count++
+		}
+	}
+	return count + 1, p > 0 && s.rand.Float64() < p
+}
+
+func (s *Server) record(c Call) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, c)
+}
+
+type mockTool struct {
+	srv      *Server
+	conf     *ToolConfig
+	info     *schema.ToolInfo
+	required []string
+}
+
+func (t *mockTool) Info(context.Context) (*schema.ToolInfo, error) {
+	return t.info, nil
+}
+
+func (t *mockTool) InvokableRun(ctx context.Context, argumentsInJSON string, _ ...tool.Option) (string, error) {
+	c := Call{Tool: t.conf.Name, Arguments: argumentsInJSON, Response: -1, Time: time.Now()}
+	c.Output, c.Err = t.run(ctx, &c)
+	t.srv.record(c)
+	return c.Output, c.Err
+}
+
+func (t *mockTool) run(ctx context.Context, c *Call) (string, error) {
+	c.Args = map[string]any{}
+	if strings.TrimSpace(c.Arguments) != "" {
+		if err := json.Unmarshal([]byte(c.Arguments), &c.Args); err != nil {
+			return "", fmt.Errorf("invalid arguments: %w", err)
+		}
+	}
+	for _, name := range t.required {
+		if _, ok := c.Args[name]; !ok {
+			return "", fmt.Errorf("missing required argument %s", name)
+		}
+	}
+
+	var r *Response
+	for i, cand := range t.conf.Responses {
+		if cand.matches(c.Args) {
+			r, c.Response = cand, i
+			break
+		}
+	}
+	if r == nil {
+		return "", fmt.Errorf("%s: no response matches arguments %s", t.conf.Name, c.Arguments)
+	}
+
+	latency := r.Latency
+	if latency == 0 {
+		latency = t.conf.Latency
+	}
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	count, fail := t.srv.roll(t.conf.Name, r.ErrorRate)
+	switch {
+	case fail && r.Error == "":
+		return "", ErrInjected
+	case fail || r.Error != "" && r.ErrorRate == 0:
+		return "", errors.New(r.Error)
+	case r.tmpl != nil:
+		var b strings.Builder
+		err := r.tmpl.Execute(&b, map[string]any{"Args": c.Args, "Data": t.conf.Data, "Count": count})
+		var fe *failError
+		if errors.As(err, &fe) {
+			return "", fe.err
+		}
+		if err != nil {
+			return "", err
+		}
+		return b.String(), nil
+	}
+	if s, ok := r.Body.(string); ok {
+		return s, nil
+	}
+	b, err := json.Marshal(r.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
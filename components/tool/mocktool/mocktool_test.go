@@ -0,0 +1,125 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mocktool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+const config = `
+tools:
+  - name: get_weather
+    desc: Get the weather of a city
+    parameters:
+      type: object
+      properties:
+        city: {type: string}
+        days: {type: integer}
+      required: [city]
+    data:
+      forecast: [sunny, cloudy, rainy, snowy]
+    responses:
+      - match: {city: 北京, days: 2}
+        body: {weather: [sunny, cloudy]}
+      - match:
+          city: {regex: "^上"}
+        template: '{{ take (default 1 .Args.days) .Data.forecast | json }}'
+      - match: {city: 火星}
+        error: no weather on mars
+      - match: {city: 伦敦}
+        body: fog
+        error_rate: 1
+      - match: {city: 巴黎}
+        body: slow
+        latency: 1h
+      - template: '{{ fail "unknown city %s (call %d)" .Args.city .Count }}'
+  - name: ping
+    responses:
+      - body: pong
+`
+
+func TestServer(t *testing.T) {
+	srv, err := Parse([]byte(config), WithSeed(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	weather, _ := srv.Tool("get_weather")
+	info, _ := weather.Info(context.Background())
+	if info.ParamsOneOf == nil || len(srv.Tools()) != 2 {
+		t.Fatalf("info = %+v, %d tools", info, len(srv.Tools()))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	for _, tc := range []struct {
+		args, out, err string
+	}{
+		{`{"city": "北京", "days": 2}`, `{"weather":["sunny","cloudy"]}`, ""},
+		{`{"city": "上海", "days": 3}`, `["sunny","cloudy","rainy"]`, ""},
+		{`{"city": "上海"}`, `["sunny"]`, ""},
+		{`{"city": "火星"}`, "", "no weather on mars"},
+		{`{"city": "伦敦"}`, "", ErrInjected.Error()},
+		{`{"city": "巴黎"}`, "", context.DeadlineExceeded.Error()},
+		{`{"city": "东京"}`, "", "unknown city 东京 (call 7)"},
+		{`{"days": 1}`, "", "missing required argument city"},
+		{`{`, "", "invalid arguments: unexpected end of JSON input"},
+	} {
+		out, err := weather.InvokableRun(ctx, tc.args)
+		if out != tc.out || (err == nil) != (tc.err == "") || err != nil && err.Error() != tc.err {
+			t.Errorf("%s: got %q, %v; want %q, %q", tc.args, out, err, tc.out, tc.err)
+		}
+	}
+
+	ping, _ := srv.Tool("ping")
+	if out, err := ping.InvokableRun(context.Background(), ""); out != "pong" || err != nil {
+		t.Fatalf("ping = %q, %v", out, err)
+	}
+
+	calls := srv.Calls("get_weather")
+	if len(calls) != 9 || len(srv.Calls()) != 10 {
+		t.Fatalf("%d calls", len(calls))
+	}
+	if c := calls[1]; c.Response != 1 || c.Args["days"] != float64(3) || c.Err != nil {
+		t.Fatalf("call = %+v", c)
+	}
+	if c := calls[4]; c.Response != 3 || !errors.Is(c.Err, ErrInjected) {
+		t.Fatalf("call = %+v", c)
+	}
+	srv.Reset()
+	if len(srv.Calls()) != 0 {
+		t.Fatal("calls not reset")
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	for name, conf := range map[string]string{
+		"no name":    "tools: [{desc: x}]",
+		"duplicate":  "tools: [{name: a}, {name: a}]",
+		"two bodies": "tools: [{name: a, responses: [{body: x, error: y}]}]",
+		"no body":    "tools: [{name: a, responses: [{match: {x: 1}}]}]",
+		"template":   "tools: [{name: a, responses: [{template: '{{'}]}]",
+		"regex":      "tools: [{name: a, responses: [{body: x, match: {x: {regex: '('}}}]}]",
+		"error rate": "tools: [{name: a, responses: [{body: x, error_rate: 2}]}]",
+	} {
+		if _, err := ParseConfig([]byte(conf)); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package callbacklog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+)
+
+func TestHandler(t *testing.T) {
+	var jsonl, pretty bytes.Buffer
+	ps := NewPrettySink(&pretty)
+	ps.NoColor = true
+	h := NewHandler([]Sink{NewJSONLSink(&jsonl), ps})
+
+	ctx := context.Background()
+	graph := &callbacks.RunInfo{Name: "agent", Component: "Graph"}
+	chat := &callbacks.RunInfo{Name: "chat", Type: "Fake", Component: components.ComponentOfChatModel}
+	search := &callbacks.RunInfo{Name: "search", Component: components.ComponentOfTool}
+
+	gctx := h.OnStart(ctx, graph, nil)
+
+	// A streamed model response is logged as one message.
+	mctx := h.OnStart(gctx, chat, &model.CallbackInput{
+		Messages: []*schema.Message{schema.UserMessage("weather?")},
+		Tools:    []*schema.ToolInfo{{Name: "search"}},
+	})
+	chunks := []callbacks.CallbackOutput{
+		&model.CallbackOutput{Message: schema.AssistantMessage("Let me ", nil)},
+		&model.CallbackOutput{Message: schema.AssistantMessage("check.", []schema.ToolCall{{Index: ptr(0), ID: "1", Function: schema.FunctionCall{Name: "search", Arguments: `{"q":`}}})},
+		&model.CallbackOutput{Message: schema.AssistantMessage("", []schema.ToolCall{{Index: ptr(0), Function: schema.FunctionCall{Arguments: `"weather"}`}}}),
+			TokenUsage: &model.TokenUsage{PromptTokens: 10, CompletionTokens: 5}},
+	}
+	h.OnEndWithStreamOutput(mctx, chat, schema.StreamReaderFromArray(chunks))
+
+	tctx := h.OnStart(gctx, search, &tool.CallbackInput{ArgumentsInJSON: `{"q":"weather"}`})
+	h.OnEnd(tctx, search, &tool.CallbackOutput{Response: "sunny"})
+	h.OnError(gctx, graph, errors.New("boom"))
+	if err := h.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var events []Event
+	sc := bufio.NewScanner(&jsonl)
+	for sc.Scan() {
+		var e Event
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, e)
+	}
+	// The graph's own start is not logged, its error is.
+	if len(events) != 5 {
+		t.Fatalf("%d events:\n%s", len(events), pretty.String())
+	}
+
+	start, end := events[0], eventOf(events, KindEnd, "chat")
+	if start.Kind != KindStart || len(start.Messages) != 1 || start.Tools[0] != "search" || start.Parent != 1 {
+		t.Fatalf("model start = %+v", start)
+	}
+	if !end.Streamed || end.Span != start.Span || end.Message.Content != "Let me check." ||
+		len(end.Message.ToolCalls) != 1 || end.Message.ToolCalls[0].Function.Arguments != `{"q":"weather"}` || end.Usage.CompletionTokens != 5 {
+		t.Fatalf("model end = %+v, message %+v", end, end.Message)
+	}
+	if e := eventOf(events, KindEnd, "search"); e.Response != "sunny" || e.Duration <= 0 {
+		t.Fatalf("tool end = %+v", e)
+	}
+	if e := eventOf(events, KindError, "agent"); e.Error != "boom" || e.Span != 1 {
+		t.Fatalf("error = %+v", e)
+	}
+
+	for _, want := range []string{"▶", "tools: search", "call search({\"q\":\"weather\"})", "tokens 10+5 (stream)", "result: sunny", "✗", "boom"} {
+		if !strings.Contains(pretty.String(), want) {
+			t.Errorf("pretty output lacks %q:\n%s", want, pretty.String())
+		}
+	}
+}
+
+func TestRotatingSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "agent.jsonl")
+	s, err := NewRotatingSink(path, 300, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		if err = s.Write(&Event{Kind: KindEnd, Name: "tool", Response: strings.Repeat("x", 100)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files, _ := filepath.Glob(path + "*")
+	if len(files) != 3 {
+		t.Fatalf("files = %q", files)
+	}
+	for _, f := range files {
+		data, _ := os.ReadFile(f)
+		if len(data) > 300 || !bytes.HasSuffix(data, []byte("}\n")) {
+			t.Fatalf("%s has %d bytes, ends %q", f, len(data), data[max(0, len(data)-3):])
+		}
+	}
+}
+
+func TestRotatingFileReopenFailure(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	r, err := OpenRotatingFile(filepath.Join(dir, "agent.log"), 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if _, err = r.Write([]byte("first line\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	// With the directory replaced by a file, rotating and reopening fail, and
+	// every write reports it.
+	if err = os.Rename(dir, dir+".old"); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(dir, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err = r.Write([]byte("lost\n")); err == nil {
+			t.Fatalf("write %d succeeded without a log file", i)
+		}
+	}
+
+	// Once the directory is back, writes go to a new file.
+	if err = os.Remove(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = r.Write([]byte("again\n")); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "agent.log")); string(data) != "again\n" {
+		t.Fatalf("log = %q", data)
+	}
+}
+
+func eventOf(events []Event, kind Kind, name string) Event {
+	for _, e := range events {
+		if e.Kind == kind && e.Name == name {
+			return e
+		}
+	}
+	return Event{}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
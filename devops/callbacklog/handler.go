@@ -0,0 +1,332 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package callbacklog is a callbacks.Handler that logs what models and tools
+// do.
+//
+// The handler records model inputs and outputs, tool calls and results, the
+// time each took and the errors they returned as Events. Streamed outputs are
+// reassembled, so a streamed model response is logged as one message. Events
+// go to Sinks: a pretty terminal printer, a JSON-lines writer, and a rotating
+// log file.
+//
+//	h := callbacklog.NewHandler([]callbacklog.Sink{callbacklog.NewPrettySink(os.Stdout)})
+//	defer h.Close()
+//	out, err := agent.Generate(ctx, msgs, agent.WithComposeOptions(compose.WithCallbacks(h)))
+package callbacklog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Kind is the kind of an event.
+type Kind string
+
+const (
+	KindStart Kind = "start"
+	KindEnd   Kind = "end"
+	KindError Kind = "error"
+)
+
+// Event is one logged callback.
+type Event struct {
+	Time time.Time `json:"time"`
+	Kind Kind      `json:"kind"`
+	// Span identifies a component run; its start and end events share it.
+	// Parent is the span of the enclosing run, e.g. the graph a model ran in.
+	Span   int64 `json:"span"`
+	Parent int64 `json:"parent,omitempty"`
+
+	Component components.Component `json:"component"`
+	Type      string               `json:"type,omitempty"`
+	Name      string               `json:"name,omitempty"`
+
+	// Model runs.
+	Messages []*schema.Message `json:"messages,omitempty"` // input
+	Tools    []string          `json:"tools,omitempty"`    // names of the tools offered
+	Message  *schema.Message   `json:"message,omitempty"`  // output
+	Usage    *model.TokenUsage `json:"usage,omitempty"`
+	Streamed bool              `json:"streamed,omitempty"` // output was a stream, reassembled
+	Config   *model.Config     `json:"config,omitempty"`
+	Extra    map[string]any    `json:"extra,omitempty"`
+
+	// Tool runs.
+	Arguments string             `json:"arguments,omitempty"`
+	Response  string             `json:"response,omitempty"`
+	Result    *schema.ToolResult `json:"tool_output,omitempty"` // multimodal output
+
+	Duration time.Duration `json:"duration,omitempty"` // on end and error events
+	Error    string        `json:"error,omitempty"`
+}
+
+// Sink receives events. Handlers call Write from one goroutine at a time.
+type Sink interface {
+	Write(e *Event) error
+	Close() error
+}
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithComponents logs runs of the given components, by default models and
+// tools. Errors are logged for every component.
+func WithComponents(cs ...components.Component) Option {
+	return func(h *Handler) {
+		h.components = map[components.Component]bool{}
+		for _, c := range cs {
+			h.components[c] = true
+		}
+	}
+}
+
+// WithErrorHandler receives errors of the sinks, which are dropped otherwise.
+func WithErrorHandler(f func(error)) Option {
+	return func(h *Handler) {
+		h.onSinkError = f
+	}
+}
+
+// Handler is a callbacks.Handler writing events to sinks.
+type Handler struct {
+	sinks       []Sink
+	components  map[components.Component]bool
+	onSinkError func(error)
+
+	mu      sync.Mutex
+	nextID  atomic.Int64
+	pending sync.WaitGroup // stream readers
+}
+
+// NewHandler creates a handler that writes to sinks.
+func NewHandler(sinks []Sink, opts ...Option) *Handler {
+	h := &Handler{
+		sinks:      sinks,
+		components: map[components.Component]bool{components.ComponentOfChatModel: true, components.ComponentOfTool: true},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Close waits for streamed outputs still being read and closes the sinks.
+func (h *Handler) Close() error {
+	h.pending.Wait()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var errs []error
+	for _, s := range h.sinks {
+		errs = append(errs, s.Close())
+	}
+	return errors.Join(errs...)
+}
+
+type spanKey struct{}
+
+type span struct {
+	id, parent int64
+	start      time.Time
+}
+
+func (h *Handler) begin(ctx context.Context) (context.Context, *span) {
+	s := &span{id: h.nextID.Add(1), start: time.Now()}
+	if p, ok := ctx.Value(spanKey{}).(*span); ok {
+		s.parent = p.id
+	}
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+func current(ctx context.Context) *span {
+	if s, ok := ctx.Value(spanKey{}).(*span); ok {
+		return s
+	}
+	return &span{start: time.Now()}
+}
+
+func (h *Handler) event(info *callbacks.RunInfo, s *span, kind Kind) *Event {
+	e := &Event{Time: time.Now(), Kind: kind, Span: s.id, Parent: s.parent}
+	if info != nil {
+		e.Component, e.Type, e.Name = info.Component, info.Type, info.Name
+	}
+	if kind != KindStart {
+		e.Duration = e.Time.Sub(s.start)
+	}
+	return e
+}
+
+func (h *Handler) logged(info *callbacks.RunInfo) bool {
+	return info != nil && h.components[info.Component]
+}
+
+func (h *Handler) write(e *Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, s := range h.sinks {
+		if err := s.Write(e); err != nil && h.onSinkError != nil {
+			h.onSinkError(err)
+		}
+	}
+}
+
+// OnStart implements callbacks.Handler.
+func (h *Handler) OnStart(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
+	ctx, s := h.begin(ctx)
+	if !h.logged(info) {
+		return ctx
+	}
+	e := h.event(info, s, KindStart)
+	switch info.Component {
+	case components.ComponentOfChatModel:
+		if in := model.ConvCallbackInput(input); in != nil {
+			e.Messages, e.Config, e.Extra = in.Messages, in.Config, in.Extra
+			for _, t := range in.Tools {
+				e.Tools = append(e.Tools, t.Name)
+			}
+		}
+	case components.ComponentOfTool:
+		if in := tool.ConvCallbackInput(input); in != nil {
+			e.Arguments = in.ArgumentsInJSON
+		}
+	}
+	h.write(e)
+	return ctx
+}
+
+// OnEnd implements callbacks.Handler.
+func (h *Handler) OnEnd(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+	if h.logged(info) {
+		h.write(h.endEvent(info, current(ctx), output))
+	}
+	return ctx
+}
+
+func (h *Handler) endEvent(info *callbacks.RunInfo, s *span, output callbacks.CallbackOutput) *Event {
+	e := h.event(info, s, KindEnd)
+	switch info.Component {
+	case components.ComponentOfChatModel:
+		if out := model.ConvCallbackOutput(output); out != nil {
+			e.Message, e.Usage = out.Message, out.TokenUsage
+		}
+	case components.ComponentOfTool:
+		if out := tool.ConvCallbackOutput(output); out != nil {
+			e.Response, e.Result = out.Response, out.ToolOutput
+		}
+	}
+	return e
+}
+
+// OnError implements callbacks.Handler.
+func (h *Handler) OnError(ctx context.Context, info *callbacks.RunInfo, err error) context.Context {
+	e := h.event(info, current(ctx), KindError)
+	e.Error = err.Error()
+	h.write(e)
+	return ctx
+}
+
+// OnStartWithStreamInput implements callbacks.Handler. Streamed inputs are not
+// logged.
+func (h *Handler) OnStartWithStreamInput(ctx context.Context, info *callbacks.RunInfo, input *schema.StreamReader[callbacks.CallbackInput]) context.Context {
+	input.Close()
+	ctx, s := h.begin(ctx)
+	if h.logged(info) {
+		h.write(h.event(info, s, KindStart))
+	}
+	return ctx
+}
+
+// OnEndWithStreamOutput implements callbacks.Handler. The stream is read to
+// the end in the background and logged as one output; the duration of the
+// event includes reading the stream.
+func (h *Handler) OnEndWithStreamOutput(ctx context.Context, info *callbacks.RunInfo, output *schema.StreamReader[callbacks.CallbackOutput]) context.Context {
+	if !h.logged(info) {
+		output.Close()
+		return ctx
+	}
+
+	s := current(ctx)
+	h.pending.Add(1)
+	go func() {
+		defer h.pending.Done()
+		defer output.Close()
+
+		var (
+			chunks   []*schema.Message
+			response strings.Builder
+			last     callbacks.CallbackOutput
+			usage    *model.TokenUsage
+		)
+		for {
+			frame, err := output.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				e := h.event(info, s, KindError)
+				e.Error, e.Streamed = err.Error(), true
+				h.write(e)
+				return
+			}
+			last = frame
+			switch info.Component {
+			case components.ComponentOfChatModel:
+				if out := model.ConvCallbackOutput(frame); out != nil {
+					if out.Message != nil {
+						chunks = append(chunks, out.Message)
+					}
+					if out.TokenUsage != nil {
+						usage = out.TokenUsage
+					}
+				}
+			case components.ComponentOfTool:
+				if out := tool.ConvCallbackOutput(frame); out != nil {
+					response.WriteString(out.Response)
+				}
+			}
+		}
+
+		e := h.endEvent(info, s, last)
+		e.Streamed = true
+		switch info.Component {
+		case components.ComponentOfChatModel:
+			if len(chunks) > 0 {
+				msg, err := schema.ConcatMessages(chunks)
+				if err != nil {
+					e.Error = err.Error()
+				}
+				e.Message = msg
+			}
+			e.Usage = usage
+		case components.ComponentOfTool:
+			e.Response = response.String()
+		}
+		h.write(e)
+	}()
+	return ctx
+}
+
+var _ callbacks.Handler = (*Handler)(nil)
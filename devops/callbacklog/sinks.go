@@ -0,0 +1,258 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package callbacklog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorBlue   = "\033[34m"
+	colorGray   = "\033[90m"
+	colorReset  = "\033[0m"
+)
+
+// PrettySink prints events for humans.
+type PrettySink struct {
+	w io.Writer
+	// MaxContent truncates message contents and tool results; 0 prints them in
+	// full. Default 500.
+	MaxContent int
+	// NoColor disables the terminal colors.
+	NoColor bool
+}
+
+// NewPrettySink creates a PrettySink writing to w.
+func NewPrettySink(w io.Writer) *PrettySink {
+	return &PrettySink{w: w, MaxContent: 500}
+}
+
+// Write implements Sink.
+func (p *PrettySink) Write(e *Event) error {
+	var b strings.Builder
+	head := fmt.Sprintf("%s #%d %s %s", e.Time.Format("15:04:05.000"), e.Span, e.Component, e.Name)
+	switch e.Kind {
+	case KindStart:
+		b.WriteString(p.color(colorGreen, "▶ "+head))
+		if len(e.Messages) > 0 {
+			fmt.Fprintf(&b, " (%d messages", len(e.Messages))
+			if len(e.Tools) > 0 {
+				fmt.Fprintf(&b, ", tools: %s", strings.Join(e.Tools, ", "))
+			}
+			b.WriteString(")\n")
+			p.message(&b, e.Messages[len(e.Messages)-1])
+		} else {
+			b.WriteString("\n")
+		}
+		if e.Arguments != "" {
+			fmt.Fprintf(&b, "  args: %s\n", p.truncate(e.Arguments))
+		}
+	case KindEnd:
+		b.WriteString(p.color(colorBlue, fmt.Sprintf("◀ %s %s", head, e.Duration.Round(time.Millisecond))))
+		if e.Usage != nil {
+			fmt.Fprintf(&b, " tokens %d+%d", e.Usage.PromptTokens, e.Usage.CompletionTokens)
+		}
+		if e.Streamed {
+			b.WriteString(" (stream)")
+		}
+		b.WriteString("\n")
+		if e.Message != nil {
+			p.message(&b, e.Message)
+		}
+		if e.Response != "" {
+			fmt.Fprintf(&b, "  result: %s\n", p.truncate(e.Response))
+		}
+	case KindError:
+		b.WriteString(p.color(colorRed, fmt.Sprintf("✗ %s %s: %s", head, e.Duration.Round(time.Millisecond), e.Error)))
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(p.w, b.String())
+	return err
+}
+
+func (p *PrettySink) message(b *strings.Builder, m *schema.Message) {
+	if m.ReasoningContent != "" {
+		fmt.Fprintf(b, "  %s\n", p.color(colorGray, "reasoning: "+p.truncate(m.ReasoningContent)))
+	}
+	if m.Content != "" {
+		fmt.Fprintf(b, "  %s: %s\n", m.Role, p.truncate(m.Content))
+	}
+	for _, tc := range m.ToolCalls {
+		fmt.Fprintf(b, "  %s\n", p.color(colorYellow, fmt.Sprintf("call %s(%s)", tc.Function.Name, p.truncate(tc.Function.Arguments))))
+	}
+}
+
+func (p *PrettySink) truncate(s string) string {
+	if p.MaxContent <= 0 || utf8.RuneCountInString(s) <= p.MaxContent {
+		return s
+	}
+	return string([]rune(s)[:p.MaxContent]) + "…"
+}
+
+func (p *PrettySink) color(code, s string) string {
+	if p.NoColor {
+		return s
+	}
+	return code + s + colorReset
+}
+
+// Close implements Sink. It does not close the writer.
+func (p *PrettySink) Close() error {
+	return nil
+}
+
+// JSONLSink writes every event as a line of JSON.
+type JSONLSink struct {
+	w   io.Writer
+	enc *json.Encoder
+}
+
+// NewJSONLSink creates a JSONLSink writing to w. Close closes w if it is an
+// io.Closer.
+func NewJSONLSink(w io.Writer) *JSONLSink {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &JSONLSink{w: w, enc: enc}
+}
+
+// Write implements Sink.
+func (j *JSONLSink) Write(e *Event) error {
+	return j.enc.Encode(e)
+}
+
+// Close implements Sink.
+func (j *JSONLSink) Close() error {
+	if c, ok := j.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// RotatingFile is a log file that is rotated when it grows beyond maxBytes:
+// path is renamed to path.1, path.1 to path.2 and so on, and the oldest of
+// maxBackups backups is removed.
+type RotatingFile struct {
+	path       string
+	maxBytes   int64
+	maxBackups int
+
+	f    *os.File
+	size int64
+}
+
+// OpenRotatingFile opens path for appending, creating it if needed.
+func OpenRotatingFile(path string, maxBytes int64, maxBackups int) (*RotatingFile, error) {
+	if maxBytes <= 0 {
+		return nil, fmt.Errorf("maxBytes must be positive")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	r := &RotatingFile{path: path, maxBytes: maxBytes, maxBackups: maxBackups}
+	return r, r.open()
+}
+
+func (r *RotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	r.f, r.size = f, info.Size()
+	return nil
+}
+
+// Write appends p, rotating first if p does not fit. A write is never split
+// across files. If a rotation failed to reopen the file, Write retries it.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	if r.f == nil {
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+	if r.size > 0 && r.size+int64(len(p)) > r.maxBytes {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// rotate closes the current file and opens a new one. r.f is nil until the
+// new file is open, so a failure is not followed by writes to a closed file.
+func (r *RotatingFile) rotate() error {
+	err := r.f.Close()
+	r.f = nil
+	if err != nil {
+		return err
+	}
+	if r.maxBackups <= 0 {
+		if err := os.Remove(r.path); err != nil {
+			return err
+		}
+		return r.open()
+	}
+	_ = os.Remove(r.backup(r.maxBackups))
+	for i := r.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(r.backup(i), r.backup(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(r.path, r.backup(1)); err != nil {
+		return err
+	}
+	return r.open()
+}
+
+func (r *RotatingFile) backup(i int) string {
+	return fmt.Sprintf("%s.%d", r.path, i)
+}
+
+// Close closes the current file.
+func (r *RotatingFile) Close() error {
+	if r.f == nil {
+		return nil
+	}
+	return r.f.Close()
+}
+
+// NewRotatingSink writes events as JSON lines to a RotatingFile at path.
+func NewRotatingSink(path string, maxBytes int64, maxBackups int) (*JSONLSink, error) {
+	f, err := OpenRotatingFile(path, maxBytes, maxBackups)
+	if err != nil {
+		return nil, err
+	}
+	return NewJSONLSink(f), nil
+}
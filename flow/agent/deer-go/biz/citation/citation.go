@@ -0,0 +1,391 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package citation 记录研究过程中工具调用取得的来源，并校验最终报告中的引用。
+//
+// 每个来源有一个编号，在工具结果和报告中以脚注标记 [^编号] 引用。
+// Process 删除报告中不存在的编号和不属于任何来源的链接，并在报告末尾生成参考文献。
+package citation
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	KindWeb  = "web"
+	KindFile = "file"
+
+	maxSnippet = 300
+)
+
+// Source 一个被收集的来源
+type Source struct {
+	ID          int       `json:"id"`
+	Kind        string    `json:"kind"` // KindWeb 或 KindFile
+	URL         string    `json:"url,omitempty"`
+	Path        string    `json:"path,omitempty"`
+	Title       string    `json:"title,omitempty"`
+	Snippet     string    `json:"snippet,omitempty"`
+	Tool        string    `json:"tool,omitempty"` // 取得该来源的工具
+	Step        int       `json:"step"`           // 取得该来源的计划步骤，从 1 开始，0 为背景调查
+	RetrievedAt time.Time `json:"retrieved_at"`
+}
+
+// Marker 报告中引用该来源的标记
+func (s *Source) Marker() string {
+	return fmt.Sprintf("[^%d]", s.ID)
+}
+
+func (s *Source) key() string {
+	if s.Kind == KindFile {
+		return "file:" + s.Path
+	}
+	return strings.TrimSuffix(s.URL, "/")
+}
+
+func (s *Source) location() string {
+	if s.Kind == KindFile {
+		return s.Path
+	}
+	return s.URL
+}
+
+// Check 报告引用的校验结果
+type Check struct {
+	Cited        []int    `json:"cited"`                   // 报告引用的来源编号，按编号排序
+	Invalid      []int    `json:"invalid,omitempty"`       // 不存在的编号，已从报告中删除
+	UnknownLinks []string `json:"unknown_links,omitempty"` // 不属于任何来源的链接，已只保留链接文字
+}
+
+// OK 报告中没有无效的引用
+func (c *Check) OK() bool {
+	return len(c.Invalid) == 0 && len(c.UnknownLinks) == 0
+}
+
+// Add 把 found 中的来源加入 list，按 URL 或文件路径去重，新来源依次编号。
+// 返回新的 list 和与 found 一一对应的已编号来源
+func Add(list []*Source, found []*Source) ([]*Source, []*Source) {
+	assigned := make([]*Source, 0, len(found))
+	for _, f := range found {
+		i := slices.IndexFunc(list, func(s *Source) bool { return s.key() == f.key() })
+		if i >= 0 {
+			assigned = append(assigned, list[i])
+			continue
+		}
+		f.ID = len(list) + 1
+		list = append(list, f)
+		assigned = append(assigned, f)
+	}
+	return list, assigned
+}
+
+// Annotate 列出来源和它们的引用标记，附在工具结果或模型输入之后
+func Annotate(sources []*Source) string {
+	if len(sources) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n\n<sources>\n")
+	for _, s := range sources {
+		title := s.Title
+		if title == "" {
+			title = s.location()
+		}
+		fmt.Fprintf(&b, "%s %s <%s>\n", s.Marker(), title, s.location())
+	}
+	b.WriteString("</sources>")
+	return b.String()
+}
+
+var (
+	blockField = regexp.MustCompile(`^(Title|URL|Content):\s*(.*)$`)
+	heading    = regexp.MustCompile(`(?m)^#\s+(.+)$`)
+)
+
+// Extract 从一次工具调用的参数和结果中提取来源：
+// 优先使用结果中的搜索结果（JSON 或 Title/URL/Content 文本块），
+// 否则使用参数中的 url、urls 或文件路径
+func Extract(toolName, args, result string, now time.Time) []*Source {
+	text := unwrapMCP(result)
+	found := fromJSON(text)
+	if len(found) == 0 {
+		found = fromBlocks(text)
+	}
+	if len(found) == 0 {
+		found = fromArgs(args, text)
+	}
+	for _, s := range found {
+		s.Tool, s.RetrievedAt = toolName, now
+		s.Snippet = clip(s.Snippet)
+	}
+	return found
+}
+
+// unwrapMCP MCP 工具的结果是序列化的 CallToolResult，取出其中的文本
+func unwrapMCP(result string) string {
+	var r struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+	if err := json.Unmarshal([]byte(result), &r); err != nil || len(r.Content) == 0 {
+		return result
+	}
+	texts := make([]string, 0, len(r.Content))
+	for _, c := range r.Content {
+		if c.Type == "text" {
+			texts = append(texts, c.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+func fromJSON(text string) []*Source {
+	var v any
+	if err := json.Unmarshal([]byte(text), &v); err != nil {
+		return nil
+	}
+	var items []any
+	switch t := v.(type) {
+	case []any:
+		items = t
+	case map[string]any:
+		items, _ = t["results"].([]any)
+	}
+	var found []*Source
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		url := firstString(m, "url", "link", "href")
+		if url == "" {
+			continue
+		}
+		found = append(found, &Source{
+			Kind:    KindWeb,
+			URL:     url,
+			Title:   firstString(m, "title", "name"),
+			Snippet: firstString(m, "content", "snippet", "description", "text"),
+		})
+	}
+	return found
+}
+
+// fromBlocks 解析 tavily 等搜索工具的文本结果：
+//
+//	Title: ...
+//	URL: ...
+//	Content: ...
+func fromBlocks(text string) []*Source {
+	var found []*Source
+	var cur *Source
+	for _, line := range strings.Split(text, "\n") {
+		m := blockField.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		switch m[1] {
+		case "Title":
+			cur = &Source{Kind: KindWeb, Title: m[2]}
+			found = append(found, cur)
+		case "URL":
+			if cur == nil || cur.URL != "" {
+				cur = &Source{Kind: KindWeb}
+				found = append(found, cur)
+			}
+			cur.URL = m[2]
+		case "Content":
+			if cur != nil {
+				cur.Snippet = m[2]
+			}
+		}
+	}
+	return slices.DeleteFunc(found, func(s *Source) bool { return s.URL == "" })
+}
+
+func fromArgs(args, text string) []*Source {
+	m := map[string]any{}
+	if err := json.Unmarshal([]byte(args), &m); err != nil {
+		return nil
+	}
+	title := ""
+	if h := heading.FindStringSubmatch(text); h != nil {
+		title = strings.TrimSpace(h[1])
+	}
+
+	var found []*Source
+	if url := firstString(m, "url"); url != "" {
+		found = append(found, &Source{Kind: KindWeb, URL: url, Title: title, Snippet: text})
+	}
+	if urls, ok := m["urls"].([]any); ok {
+		for _, u := range urls {
+			if url, ok := u.(string); ok && url != "" {
+				found = append(found, &Source{Kind: KindWeb, URL: url})
+			}
+		}
+	}
+	if path := firstString(m, "path", "file_path", "filename"); path != "" {
+		found = append(found, &Source{Kind: KindFile, Path: path, Title: title, Snippet: text})
+	}
+	return found
+}
+
+func firstString(m map[string]any, keys ...string) string {
+	for _, k := range keys {
+		if s, ok := m[k].(string); ok && s != "" {
+			return strings.TrimSpace(s)
+		}
+	}
+	return ""
+}
+
+func clip(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > maxSnippet {
+		return string(r[:maxSnippet]) + "…"
+	}
+	return s
+}
+
+var (
+	markerRE     = regexp.MustCompile(`\[\^(\d+)\]`)
+	definitionRE = regexp.MustCompile(`(?m)^\[\^\d+\]:.*(\n|$)`)
+	linkRE       = regexp.MustCompile(`(!?)\[([^\]\n]*)\]\([ \t]*<?([^)\s>]+)>?[^)\n]*\)`)
+)
+
+// Process 校验报告中的引用：删除不存在的编号，把不属于任何来源的链接替换为链接文字（图片除外），
+// 并在末尾按编号列出被引用的来源
+func Process(report string, sources []*Source) (string, *Check) {
+	f := NewFilter(sources)
+	out := f.Write(report)
+	tail, check := f.Close()
+	return out + tail, check
+}
+
+// Filter 在报告流式输出时做与 Process 相同的校验，使推送给用户的内容与最终报告一致。
+// 引用标记和链接不会跨行，因此 Filter 按整行处理，未写完的行留到下次 Write 或 Close
+type Filter struct {
+	byID  map[int]*Source
+	urls  map[string]bool
+	check *Check
+	line  string // 未写完的行
+	nl    string // 已处理但未输出的换行，报告末尾的换行由 Close 统一处理
+}
+
+// NewFilter 创建校验 sources 引用的 Filter
+func NewFilter(sources []*Source) *Filter {
+	f := &Filter{byID: map[int]*Source{}, urls: map[string]bool{}, check: &Check{}}
+	for _, s := range sources {
+		f.byID[s.ID] = s
+		if s.Kind == KindWeb {
+			f.urls[s.key()] = true
+		}
+	}
+	return f
+}
+
+// Write 写入报告的下一段，返回其中可以输出的部分
+func (f *Filter) Write(text string) string {
+	f.line += text
+	i := strings.LastIndexByte(f.line, '\n')
+	if i < 0 {
+		return ""
+	}
+	done := f.clean(f.line[:i+1])
+	f.line = f.line[i+1:]
+	return f.emit(done)
+}
+
+// Close 返回报告剩余的部分和参考文献，以及校验结果
+func (f *Filter) Close() (string, *Check) {
+	out := f.emit(f.clean(f.line))
+	f.line, f.nl = "", ""
+	slices.Sort(f.check.Cited)
+	if len(f.check.Cited) > 0 {
+		var b strings.Builder
+		b.WriteString(out)
+		b.WriteString("\n\n## References\n")
+		for _, id := range f.check.Cited {
+			b.WriteString("\n")
+			b.WriteString(Bibliography(f.byID[id]))
+			b.WriteString("\n")
+		}
+		return b.String(), f.check
+	}
+	return out + "\n", f.check
+}
+
+// emit 输出 text，末尾的换行暂不输出，待后面有内容时再补上
+func (f *Filter) emit(text string) string {
+	body := strings.TrimRight(text, "\n")
+	if body == "" {
+		f.nl += text
+		return ""
+	}
+	out := f.nl + body
+	f.nl = text[len(body):]
+	return out
+}
+
+func (f *Filter) clean(text string) string {
+	// 模型自己写的脚注定义以参考文献为准
+	text = definitionRE.ReplaceAllString(text, "")
+	text = markerRE.ReplaceAllStringFunc(text, func(m string) string {
+		id, _ := strconv.Atoi(markerRE.FindStringSubmatch(m)[1])
+		if f.byID[id] == nil {
+			if !slices.Contains(f.check.Invalid, id) {
+				f.check.Invalid = append(f.check.Invalid, id)
+			}
+			return ""
+		}
+		if !slices.Contains(f.check.Cited, id) {
+			f.check.Cited = append(f.check.Cited, id)
+		}
+		return m
+	})
+	return linkRE.ReplaceAllStringFunc(text, func(m string) string {
+		sub := linkRE.FindStringSubmatch(m)
+		if sub[1] == "!" || f.urls[strings.TrimSuffix(sub[3], "/")] {
+			return m
+		}
+		if !slices.Contains(f.check.UnknownLinks, sub[3]) {
+			f.check.UnknownLinks = append(f.check.UnknownLinks, sub[3])
+		}
+		return sub[2]
+	})
+}
+
+// Bibliography 参考文献中的一条，为 Markdown 脚注定义
+func Bibliography(s *Source) string {
+	title := s.Title
+	if title == "" {
+		title = s.location()
+	}
+	title = strings.NewReplacer("[", `\[`, "]", `\]`).Replace(title)
+	date := s.RetrievedAt.Format("2006-01-02")
+	if s.Kind == KindFile {
+		return fmt.Sprintf("%s: %s, `%s`, retrieved %s", s.Marker(), title, s.Path, date)
+	}
+	return fmt.Sprintf("%s: [%s](%s), retrieved %s", s.Marker(), title, s.URL, date)
+}
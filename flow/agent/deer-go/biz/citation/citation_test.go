@@ -0,0 +1,137 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package citation
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func mcpResult(text string) string {
+	b, _ := json.Marshal(map[string]any{"content": []map[string]string{{"type": "text", "text": text}}})
+	return string(b)
+}
+
+func TestExtract(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	urls := func(found []*Source) (out []string) {
+		for _, s := range found {
+			out = append(out, s.Kind+":"+s.URL+s.Path+"|"+s.Title)
+		}
+		return out
+	}
+
+	tavily := "Detailed Results:\n\nTitle: Go 1.24\nURL: https://go.dev/blog/go1.24\nContent: Go 1.24 is released.\n\nTitle: Eino\nURL: https://github.com/cloudwego/eino\nContent: LLM framework.\n"
+	found := Extract("tavily-search", `{"query":"go"}`, mcpResult(tavily), now)
+	if got, want := urls(found), []string{"web:https://go.dev/blog/go1.24|Go 1.24", "web:https://github.com/cloudwego/eino|Eino"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("blocks = %v", got)
+	}
+	if found[0].Snippet != "Go 1.24 is released." || found[0].Tool != "tavily-search" || !found[0].RetrievedAt.Equal(now) {
+		t.Fatalf("source = %+v", found[0])
+	}
+
+	found = Extract("search", `{}`, `{"results":[{"title":"A","url":"https://a.com","content":"aaa"},{"title":"no url"}]}`, now)
+	if got := urls(found); !reflect.DeepEqual(got, []string{"web:https://a.com|A"}) {
+		t.Fatalf("json = %v", got)
+	}
+
+	found = Extract("firecrawl_scrape", `{"url":"https://b.com/page"}`, mcpResult("# Page B\n\nbody"), now)
+	if got := urls(found); !reflect.DeepEqual(got, []string{"web:https://b.com/page|Page B"}) {
+		t.Fatalf("args = %v", got)
+	}
+
+	found = Extract("read_file", `{"path":"data/report.csv"}`, "a,b\n1,2", now)
+	if got := urls(found); !reflect.DeepEqual(got, []string{"file:data/report.csv|"}) {
+		t.Fatalf("file = %v", got)
+	}
+
+	if found = Extract("execute_python", `{"code":"print(1)"}`, mcpResult("1"), now); len(found) != 0 {
+		t.Fatalf("python = %v", urls(found))
+	}
+}
+
+func TestAdd(t *testing.T) {
+	list, assigned := Add(nil, []*Source{
+		{Kind: KindWeb, URL: "https://a.com/"},
+		{Kind: KindWeb, URL: "https://b.com"},
+	})
+	list, assigned = Add(list, []*Source{
+		{Kind: KindWeb, URL: "https://a.com"},
+		{Kind: KindFile, Path: "x.txt"},
+	})
+	if len(list) != 3 || assigned[0].ID != 1 || assigned[1].ID != 3 {
+		t.Fatalf("list = %d, assigned = %v, %v", len(list), assigned[0].ID, assigned[1].ID)
+	}
+	if a := Annotate(assigned); !strings.Contains(a, "[^1] https://a.com/ <https://a.com/>") || !strings.Contains(a, "[^3] x.txt <x.txt>") {
+		t.Fatalf("annotate = %s", a)
+	}
+}
+
+func TestProcess(t *testing.T) {
+	day := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	sources := []*Source{
+		{ID: 1, Kind: KindWeb, URL: "https://a.com", Title: "A [draft]", RetrievedAt: day},
+		{ID: 2, Kind: KindWeb, URL: "https://b.com", Title: "B", RetrievedAt: day},
+		{ID: 3, Kind: KindFile, Path: "data.csv", RetrievedAt: day},
+	}
+	report := "# Title\n\nFact one [^3]. Fact two [^1][^7].\n" +
+		"See [B](https://b.com/) and [fake](https://fake.com/x \"t\").\n" +
+		"![chart](https://img.com/c.png)\n\n" +
+		"[^1]: made up by the model\n"
+
+	got, check := Process(report, sources)
+	want := "# Title\n\nFact one [^3]. Fact two [^1].\n" +
+		"See [B](https://b.com/) and fake.\n" +
+		"![chart](https://img.com/c.png)\n\n" +
+		"## References\n\n" +
+		"[^1]: [A \\[draft\\]](https://a.com), retrieved 2026-01-02\n\n" +
+		"[^3]: data.csv, `data.csv`, retrieved 2026-01-02\n"
+	if got != want {
+		t.Fatalf("report =\n%s\nwant\n%s", got, want)
+	}
+	if !reflect.DeepEqual(check, &Check{Cited: []int{1, 3}, Invalid: []int{7}, UnknownLinks: []string{"https://fake.com/x"}}) || check.OK() {
+		t.Fatalf("check = %+v", check)
+	}
+
+	if got, check = Process("no citations\n", sources); got != "no citations\n" || !check.OK() {
+		t.Fatalf("plain = %q, %+v", got, check)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	day := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	sources := []*Source{{ID: 1, Kind: KindWeb, URL: "https://a.com", Title: "A", RetrievedAt: day}}
+	report := "Fact [^1][^2], see [A](https://a.com) and [x](https://x.com).\n\n[^1]: made up\n\n"
+	want, wantCheck := Process(report, sources)
+
+	// 按任意位置切分流式输出，结果与 Process 一致
+	for size := 1; size <= len(report); size++ {
+		f := NewFilter(sources)
+		var got strings.Builder
+		for i := 0; i < len(report); i += size {
+			got.WriteString(f.Write(report[i:min(i+size, len(report))]))
+		}
+		tail, check := f.Close()
+		got.WriteString(tail)
+		if got.String() != want || !reflect.DeepEqual(check, wantCheck) {
+			t.Fatalf("chunk size %d: report = %q, check = %+v\nwant %q, %+v", size, got.String(), check, want, wantCheck)
+		}
+	}
+}
@@ -26,6 +26,16 @@ const (
 	ResearchTeam           = "research_team"
 	BackgroundInvestigator = "background_investigator"
 	Human                  = "human_feedback"
+	PodcastWriter          = "podcast_script_writer"
+	PPTComposer            = "ppt_composer"
+	ParallelExecutor       = "parallel_executor"
+)
+
+// ==================================== Output ====================================
+// 报告之后可选的产出物，由 ChatRequest.Outputs 指定
+const (
+	OutputPodcast = "podcast"
+	OutputPPT     = "ppt"
 )
 
 // ==================================== Human Option ====================================
@@ -21,9 +21,11 @@ import (
 
 	"github.com/RanFeng/ilog"
 	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
 
 	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/consts"
 	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/model"
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/store"
 )
 
 // type I = string
@@ -37,11 +39,42 @@ func agentHandOff(ctx context.Context, input string) (next string, err error) {
 	}()
 	_ = compose.ProcessState[*model.State](ctx, func(_ context.Context, state *model.State) error {
 		next = state.Goto
+		recordThread(ctx, state)
 		return nil
 	})
 	return next, nil
 }
 
+// recordThread 每个 agent 执行完后把计划、报告等保存到会话记录中
+func recordThread(ctx context.Context, state *model.State) {
+	if state.ThreadID == "" {
+		return
+	}
+	err := store.Default().UpdateThread(state.ThreadID, func(t *model.Thread) {
+		if t.Title == "" {
+			for _, msg := range state.Messages {
+				if msg.Role == schema.User {
+					t.Title = msg.Content
+					break
+				}
+			}
+		}
+		t.Status, t.Error = model.ThreadRunning, ""
+		if state.Goto == compose.END {
+			t.Status = model.ThreadFinished
+		}
+		t.Locale = state.Locale
+		t.Plan = state.CurrentPlan
+		t.Report = state.Report
+		t.Artifacts = state.Artifacts
+		t.Sources = state.Sources
+		t.Citations = state.Citations
+	})
+	if err != nil {
+		ilog.EventError(ctx, err, "record_thread_fail", "thread_id", state.ThreadID)
+	}
+}
+
 // Builder 初始化全部子图并连接
 func Builder[I, O, S any](ctx context.Context, genFunc compose.GenLocalState[S]) compose.Runnable[I, O] {
 	//tools := map[string]Tool{}
@@ -72,6 +105,9 @@ func Builder[I, O, S any](ctx context.Context, genFunc compose.GenLocalState[S])
 		consts.Coder:                  true,
 		consts.BackgroundInvestigator: true,
 		consts.Human:                  true,
+		consts.PodcastWriter:          true,
+		consts.PPTComposer:            true,
+		consts.ParallelExecutor:       true,
 		compose.END:                   true,
 	}
 
@@ -83,6 +119,9 @@ func Builder[I, O, S any](ctx context.Context, genFunc compose.GenLocalState[S])
 	bIGraph := NewBAgent[I, O](ctx)
 	coder := NewCoder[I, O](ctx)
 	human := NewHumanNode[I, O](ctx)
+	podcastWriter := NewPodcastWriter[I, O](ctx)
+	pptComposer := NewPPTComposer[I, O](ctx)
+	parallelExecutor := NewParallelExecutor[I, O](ctx)
 
 	_ = g.AddGraphNode(consts.Coordinator, coordinatorGraph, compose.WithNodeName(consts.Coordinator))
 	_ = g.AddGraphNode(consts.Planner, plannerGraph, compose.WithNodeName(consts.Planner))
@@ -92,6 +131,9 @@ func Builder[I, O, S any](ctx context.Context, genFunc compose.GenLocalState[S])
 	_ = g.AddGraphNode(consts.Coder, coder, compose.WithNodeName(consts.Coder))
 	_ = g.AddGraphNode(consts.BackgroundInvestigator, bIGraph, compose.WithNodeName(consts.BackgroundInvestigator))
 	_ = g.AddGraphNode(consts.Human, human, compose.WithNodeName(consts.Human))
+	_ = g.AddGraphNode(consts.PodcastWriter, podcastWriter, compose.WithNodeName(consts.PodcastWriter))
+	_ = g.AddGraphNode(consts.PPTComposer, pptComposer, compose.WithNodeName(consts.PPTComposer))
+	_ = g.AddGraphNode(consts.ParallelExecutor, parallelExecutor, compose.WithNodeName(consts.ParallelExecutor))
 
 	_ = g.AddBranch(consts.Coordinator, compose.NewGraphBranch(agentHandOff, outMap))
 	_ = g.AddBranch(consts.Planner, compose.NewGraphBranch(agentHandOff, outMap))
@@ -101,13 +143,16 @@ func Builder[I, O, S any](ctx context.Context, genFunc compose.GenLocalState[S])
 	_ = g.AddBranch(consts.Coder, compose.NewGraphBranch(agentHandOff, outMap))
 	_ = g.AddBranch(consts.BackgroundInvestigator, compose.NewGraphBranch(agentHandOff, outMap))
 	_ = g.AddBranch(consts.Human, compose.NewGraphBranch(agentHandOff, outMap))
+	_ = g.AddBranch(consts.PodcastWriter, compose.NewGraphBranch(agentHandOff, outMap))
+	_ = g.AddBranch(consts.PPTComposer, compose.NewGraphBranch(agentHandOff, outMap))
+	_ = g.AddBranch(consts.ParallelExecutor, compose.NewGraphBranch(agentHandOff, outMap))
 
 	_ = g.AddEdge(compose.START, consts.Coordinator)
 
 	r, err := g.Compile(ctx,
 		compose.WithGraphName("EinoDeer"),
 		compose.WithNodeTriggerMode(compose.AnyPredecessor),
-		compose.WithCheckPointStore(model.NewDeerCheckPoint(ctx)), // 指定Graph CheckPointStore
+		compose.WithCheckPointStore(store.Default()), // 指定Graph CheckPointStore
 	)
 	if err != nil {
 		ilog.EventError(ctx, err, "compile failed")
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eino
+
+import (
+	"context"
+	"time"
+
+	"github.com/RanFeng/ilog"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/citation"
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/model"
+)
+
+type stepKey struct{}
+
+// withStep 指定工具调用所属的步骤下标，用于并行执行的步骤，否则取 state.CurrentStep
+func withStep(ctx context.Context, i int) context.Context {
+	return context.WithValue(ctx, stepKey{}, i)
+}
+
+// citedTool 把工具结果中的来源记录到 state.Sources，并在结果后附上来源的引用标记
+type citedTool struct {
+	tool.InvokableTool
+	name string
+}
+
+func (t *citedTool) InvokableRun(ctx context.Context, args string, opts ...tool.Option) (string, error) {
+	result, err := t.InvokableTool.InvokableRun(ctx, args, opts...)
+	if err != nil {
+		return result, err
+	}
+	found := citation.Extract(t.name, args, result, time.Now())
+	if len(found) == 0 {
+		return result, nil
+	}
+
+	var sources []*citation.Source
+	err = compose.ProcessState[*model.State](ctx, func(_ context.Context, state *model.State) error {
+		i, ok := ctx.Value(stepKey{}).(int)
+		if !ok {
+			i, _ = state.CurrentStep()
+		}
+		sources = state.AddSources(i+1, found)
+		return nil
+	})
+	if err != nil {
+		ilog.EventWarn(ctx, "record_sources_fail", "tool", t.name, "err", err)
+		return result, nil
+	}
+	return result + citation.Annotate(sources), nil
+}
+
+// citedTools 为可调用的工具加上来源记录
+func citedTools(ctx context.Context, ts []tool.BaseTool) []tool.BaseTool {
+	out := make([]tool.BaseTool, 0, len(ts))
+	for _, t := range ts {
+		it, ok := t.(tool.InvokableTool)
+		if !ok {
+			out = append(out, t)
+			continue
+		}
+		info, err := t.Info(ctx)
+		if err != nil {
+			ilog.EventError(ctx, err, "tool_info_error")
+			out = append(out, t)
+			continue
+		}
+		out = append(out, &citedTool{InvokableTool: it, name: info.Name})
+	}
+	return out
+}
@@ -37,46 +37,44 @@ import (
 
 func loadCoderMsg(ctx context.Context, name string, opts ...any) (output []*schema.Message, err error) {
 	err = compose.ProcessState[*model.State](ctx, func(_ context.Context, state *model.State) error {
-		sysPrompt, err := infra.GetPromptTemplate(ctx, name)
-		if err != nil {
-			ilog.EventError(ctx, err, "get prompt template error")
-			return err
-		}
-
-		promptTemp := prompt.FromMessages(schema.Jinja2,
-			schema.SystemMessage(sysPrompt),
-			schema.MessagesPlaceholder("user_input", true),
-		)
-
-		var curStep *model.Step
-		for i := range state.CurrentPlan.Steps {
-			if state.CurrentPlan.Steps[i].ExecutionRes == nil {
-				curStep = &state.CurrentPlan.Steps[i]
-				break
-			}
-		}
-
+		i, curStep := state.CurrentStep()
 		if curStep == nil {
 			panic("no step found")
 		}
-
-		msg := []*schema.Message{}
-		msg = append(msg,
-			schema.UserMessage(fmt.Sprintf("#Task\n\n##title\n\n %v \n\n##description\n\n %v \n\n##locale\n\n %v", curStep.Title, curStep.Description, state.Locale)),
-		)
-		variables := map[string]any{
-			"locale":              state.Locale,
-			"max_step_num":        state.MaxStepNum,
-			"max_plan_iterations": state.MaxPlanIterations,
-			"CURRENT_TIME":        time.Now().Format("2006-01-02 15:04:05"),
-			"user_input":          msg,
-		}
-		output, err = promptTemp.Format(ctx, variables)
+		output, err = coderMsg(ctx, name, state, i)
 		return err
 	})
 	return output, err
 }
 
+// coderMsg 生成执行第 i 个步骤的输入，顺序执行和并行执行共用
+func coderMsg(ctx context.Context, name string, state *model.State, i int) ([]*schema.Message, error) {
+	sysPrompt, err := infra.GetPromptTemplate(ctx, name)
+	if err != nil {
+		ilog.EventError(ctx, err, "get prompt template error")
+		return nil, err
+	}
+
+	promptTemp := prompt.FromMessages(schema.Jinja2,
+		schema.SystemMessage(sysPrompt),
+		schema.MessagesPlaceholder("user_input", true),
+	)
+
+	curStep := &state.CurrentPlan.Steps[i]
+	msg := dependencyMsgs(state, i)
+	msg = append(msg,
+		schema.UserMessage(fmt.Sprintf("#Task\n\n##title\n\n %v \n\n##description\n\n %v \n\n##locale\n\n %v", curStep.Title, curStep.Description, state.Locale)),
+	)
+	variables := map[string]any{
+		"locale":              state.Locale,
+		"max_step_num":        state.MaxStepNum,
+		"max_plan_iterations": state.MaxPlanIterations,
+		"CURRENT_TIME":        time.Now().Format("2006-01-02 15:04:05"),
+		"user_input":          msg,
+	}
+	return promptTemp.Format(ctx, variables)
+}
+
 func routerCoder(ctx context.Context, input *schema.Message, opts ...any) (output string, err error) {
 	// ilog.EventInfo(ctx, "routerResearcher", "input", input)
 	last := input
@@ -84,13 +82,11 @@ func routerCoder(ctx context.Context, input *schema.Message, opts ...any) (outpu
 		defer func() {
 			output = state.Goto
 		}()
-		for i, step := range state.CurrentPlan.Steps {
-			if step.ExecutionRes == nil {
-				str := strings.Clone(last.Content)
-				state.CurrentPlan.Steps[i].ExecutionRes = &str
-				break
-			}
+		if i, _ := state.CurrentStep(); i >= 0 {
+			str := strings.Clone(last.Content)
+			state.CurrentPlan.Steps[i].ExecutionRes = &str
 		}
+		state.RunningSteps = nil
 		ilog.EventInfo(ctx, "coder_end", "plan", state.CurrentPlan)
 		state.Goto = consts.ResearchTeam
 		return nil
@@ -117,9 +113,8 @@ func modifyCoderfunc(ctx context.Context, input []*schema.Message) []*schema.Mes
 	return input
 }
 
-func NewCoder[I, O any](ctx context.Context) *compose.Graph[I, O] {
-	cag := compose.NewGraph[I, O]()
-
+// newCoderAgent 只能使用 python MCP 工具的 react agent
+func newCoderAgent(ctx context.Context) *react.Agent {
 	researchTools := []tool.BaseTool{}
 	for mcpName, cli := range infra.MCPServer {
 		ts, err := mcp.GetTools(ctx, &mcp.Config{Cli: cli})
@@ -130,6 +125,7 @@ func NewCoder[I, O any](ctx context.Context) *compose.Graph[I, O] {
 			researchTools = append(researchTools, ts...)
 		}
 	}
+	researchTools = citedTools(ctx, researchTools)
 	ilog.EventDebug(ctx, "coder_end", "coder_tools", researchTools)
 
 	agent, err := react.NewAgent(ctx, &react.AgentConfig{
@@ -139,7 +135,16 @@ func NewCoder[I, O any](ctx context.Context) *compose.Graph[I, O] {
 		MessageModifier:       modifyCoderfunc,
 		StreamToolCallChecker: toolCallChecker,
 	})
+	if err != nil {
+		panic(err)
+	}
+	return agent
+}
+
+func NewCoder[I, O any](ctx context.Context) *compose.Graph[I, O] {
+	cag := compose.NewGraph[I, O]()
 
+	agent := newCoderAgent(ctx)
 	agentLambda, err := compose.AnyLambda(agent.Generate, agent.Stream, nil, nil)
 	if err != nil {
 		panic(err)
@@ -20,12 +20,14 @@ import (
 	"context"
 	"encoding/json"
 	"strings"
+	"time"
 
 	"github.com/RanFeng/ilog"
 	"github.com/cloudwego/eino-ext/components/tool/mcp"
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/compose"
 
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/citation"
 	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/consts"
 	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/infra"
 	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/model"
@@ -33,6 +35,7 @@ import (
 
 func search(ctx context.Context, name string, opts ...any) (output string, err error) {
 	var searchTool tool.InvokableTool
+	var toolName string
 	for _, cli := range infra.MCPServer {
 		if searchTool != nil {
 			break
@@ -46,6 +49,7 @@ func search(ctx context.Context, name string, opts ...any) (output string, err e
 			info, _ := t.Info(ctx)
 			if strings.HasSuffix(info.Name, "search") {
 				searchTool, _ = t.(tool.InvokableTool)
+				toolName = info.Name
 				break
 			}
 		}
@@ -65,7 +69,9 @@ func search(ctx context.Context, name string, opts ...any) (output string, err e
 			ilog.EventError(ctx, err, "search_result_error")
 		}
 		ilog.EventDebug(ctx, "back_search_result", "result", result)
-		state.BackgroundInvestigationResults = result
+		// 背景调查取得的来源记为第 0 步
+		sources := state.AddSources(0, citation.Extract(toolName, string(argsBytes), result, time.Now()))
+		state.BackgroundInvestigationResults = result + citation.Annotate(sources)
 		return nil
 	})
 	return output, err
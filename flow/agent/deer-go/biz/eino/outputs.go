@@ -0,0 +1,149 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eino
+
+import (
+	"context"
+	"slices"
+
+	"github.com/RanFeng/ilog"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/consts"
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/infra"
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/model"
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/output"
+)
+
+// outputAgents 报告之后的产出物子图，按此顺序依次执行被选中的那些
+var outputAgents = []struct{ output, agent string }{
+	{consts.OutputPodcast, consts.PodcastWriter},
+	{consts.OutputPPT, consts.PPTComposer},
+}
+
+// nextOutput 返回 after 之后下一个被选中的产出物子图，没有则结束
+func nextOutput(state *model.State, after string) string {
+	passed := after == consts.Reporter
+	for _, o := range outputAgents {
+		if passed && slices.Contains(state.Outputs, o.output) {
+			return o.agent
+		}
+		if o.agent == after {
+			passed = true
+		}
+	}
+	return compose.END
+}
+
+// loadOutputMsg 产出物子图共用：以 name 对应的 prompt 为系统提示，最终报告为输入
+func loadOutputMsg(ctx context.Context, name string, opts ...any) (output []*schema.Message, err error) {
+	err = compose.ProcessState[*model.State](ctx, func(_ context.Context, state *model.State) error {
+		sysPrompt, err := infra.GetPromptTemplate(ctx, name)
+		if err != nil {
+			ilog.EventInfo(ctx, "get prompt template fail")
+			return err
+		}
+		output = []*schema.Message{
+			schema.SystemMessage(sysPrompt),
+			schema.UserMessage(state.Report),
+		}
+		return nil
+	})
+	return output, err
+}
+
+// saveArtifact 写入产出物文件并记录到 state.Artifacts，失败不影响后续产出物
+func saveArtifact(ctx context.Context, state *model.State, name string, data []byte) {
+	path, err := output.Save(state.OutputDir, name, data)
+	if err != nil {
+		ilog.EventError(ctx, err, "save_artifact_fail", "name", name)
+		return
+	}
+	state.Artifacts = append(state.Artifacts, path)
+	ilog.EventInfo(ctx, "save_artifact", "path", path)
+}
+
+func routerPodcast(ctx context.Context, input *schema.Message, opts ...any) (next string, err error) {
+	err = compose.ProcessState[*model.State](ctx, func(_ context.Context, state *model.State) error {
+		defer func() {
+			next = state.Goto
+		}()
+		state.Goto = nextOutput(state, consts.PodcastWriter)
+		script, err := output.ParseScript(input.Content)
+		if err != nil {
+			ilog.EventInfo(ctx, "gen_podcast_fail", "input.Content", input.Content, "err", err)
+			return nil
+		}
+		saveArtifact(ctx, state, "podcast.jsonl", script.JSONL())
+		return nil
+	})
+	return next, err
+}
+
+func routerPPT(ctx context.Context, input *schema.Message, opts ...any) (next string, err error) {
+	err = compose.ProcessState[*model.State](ctx, func(_ context.Context, state *model.State) error {
+		defer func() {
+			next = state.Goto
+		}()
+		state.Goto = nextOutput(state, consts.PPTComposer)
+		deck := output.ParseDeck(input.Content)
+		if len(deck.Slides) == 0 {
+			ilog.EventInfo(ctx, "gen_ppt_fail", "input.Content", input.Content)
+			return nil
+		}
+		saveArtifact(ctx, state, "slides.md", []byte(deck.Markdown()))
+		html, err := deck.RevealHTML()
+		if err != nil {
+			ilog.EventError(ctx, err, "render_ppt_fail")
+			return nil
+		}
+		saveArtifact(ctx, state, "slides.html", html)
+		return nil
+	})
+	return next, err
+}
+
+// NewPodcastWriter 把最终报告改写成男女双人播客脚本，输出为每句一行的 podcast.jsonl
+func NewPodcastWriter[I, O any](ctx context.Context) *compose.Graph[I, O] {
+	cag := compose.NewGraph[I, O]()
+
+	_ = cag.AddLambdaNode("load", compose.InvokableLambdaWithOption(loadOutputMsg))
+	_ = cag.AddChatModelNode("agent", infra.ChatModel)
+	_ = cag.AddLambdaNode("router", compose.InvokableLambdaWithOption(routerPodcast))
+
+	_ = cag.AddEdge(compose.START, "load")
+	_ = cag.AddEdge("load", "agent")
+	_ = cag.AddEdge("agent", "router")
+	_ = cag.AddEdge("router", compose.END)
+	return cag
+}
+
+// NewPPTComposer 把最终报告整理成 Markdown 幻灯片，输出 slides.md 和 reveal.js 页面 slides.html
+func NewPPTComposer[I, O any](ctx context.Context) *compose.Graph[I, O] {
+	cag := compose.NewGraph[I, O]()
+
+	_ = cag.AddLambdaNode("load", compose.InvokableLambdaWithOption(loadOutputMsg))
+	_ = cag.AddChatModelNode("agent", infra.ChatModel)
+	_ = cag.AddLambdaNode("router", compose.InvokableLambdaWithOption(routerPPT))
+
+	_ = cag.AddEdge(compose.START, "load")
+	_ = cag.AddEdge("load", "agent")
+	_ = cag.AddEdge("agent", "router")
+	_ = cag.AddEdge("router", compose.END)
+	return cag
+}
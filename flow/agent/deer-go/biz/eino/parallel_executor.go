@@ -0,0 +1,132 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eino
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/RanFeng/ilog"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/flow/agent/react"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/consts"
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/infra"
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/model"
+)
+
+// stepRun 并行执行中的一个步骤
+type stepRun struct {
+	index  int
+	agent  string
+	input  []*schema.Message
+	result string
+	err    error
+}
+
+// NewParallelExecutor 同时执行 research_team 派发的多个步骤（state.RunningSteps），
+// 每个步骤由 researcher 或 coder 的 react agent 执行，结果按步骤顺序写回 state
+func NewParallelExecutor[I, O any](ctx context.Context) *compose.Graph[I, O] {
+	cag := compose.NewGraph[I, O]()
+
+	agents := map[string]*react.Agent{
+		consts.Researcher: newResearchAgent(ctx),
+		consts.Coder:      newCoderAgent(ctx),
+	}
+
+	load := func(ctx context.Context, name string, opts ...any) (output []*stepRun, err error) {
+		err = compose.ProcessState[*model.State](ctx, func(_ context.Context, state *model.State) error {
+			for _, i := range state.RunningSteps {
+				r := &stepRun{index: i, agent: stepAgent(&state.CurrentPlan.Steps[i])}
+				if r.agent == consts.Coder {
+					r.input, err = coderMsg(ctx, r.agent, state, i)
+				} else {
+					r.input, err = researcherMsg(ctx, r.agent, state, i)
+				}
+				if err != nil {
+					return err
+				}
+				output = append(output, r)
+			}
+			return nil
+		})
+		return output, err
+	}
+
+	run := func(ctx context.Context, runs []*stepRun, opts ...any) ([]*stepRun, error) {
+		var wg sync.WaitGroup
+		for _, r := range runs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() {
+					if p := recover(); p != nil {
+						r.err = fmt.Errorf("step %d panic: %v", r.index+1, p)
+					}
+				}()
+				// 用 Stream 执行，使各步骤的输出像顺序执行时一样推送到前端
+				sr, err := agents[r.agent].Stream(withStep(infra.WithAgentName(ctx, r.agent), r.index), r.input)
+				if err != nil {
+					r.err = err
+					return
+				}
+				msg, err := schema.ConcatMessageStream(sr)
+				if err != nil {
+					r.err = err
+					return
+				}
+				r.result = msg.Content
+			}()
+		}
+		wg.Wait()
+		return runs, nil
+	}
+
+	router := func(ctx context.Context, runs []*stepRun, opts ...any) (output string, err error) {
+		err = compose.ProcessState[*model.State](ctx, func(_ context.Context, state *model.State) error {
+			defer func() {
+				output = state.Goto
+			}()
+			// runs 按派发顺序排列，与完成先后无关；失败的步骤记录失败原因，不影响其他步骤的结果
+			for _, r := range runs {
+				res := r.result
+				if r.err != nil {
+					ilog.EventError(ctx, r.err, "parallel_step_fail", "step", r.index+1)
+					res = fmt.Sprintf("Step %d failed: %v", r.index+1, r.err)
+				}
+				state.CurrentPlan.Steps[r.index].ExecutionRes = &res
+			}
+			state.RunningSteps = nil
+			ilog.EventInfo(ctx, "parallel_executor_end", "plan", state.CurrentPlan)
+			state.Goto = consts.ResearchTeam
+			return nil
+		})
+		return output, err
+	}
+
+	_ = cag.AddLambdaNode("load", compose.InvokableLambdaWithOption(load))
+	_ = cag.AddLambdaNode("agents", compose.InvokableLambdaWithOption(run))
+	_ = cag.AddLambdaNode("router", compose.InvokableLambdaWithOption(router))
+
+	_ = cag.AddEdge(compose.START, "load")
+	_ = cag.AddEdge("load", "agents")
+	_ = cag.AddEdge("agents", "router")
+	_ = cag.AddEdge("router", compose.END)
+	return cag
+}
@@ -18,7 +18,10 @@ package eino
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/RanFeng/ilog"
@@ -26,6 +29,8 @@ import (
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
 
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/citation"
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/consts"
 	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/infra"
 	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/model"
 )
@@ -46,11 +51,14 @@ func loadReporterMsg(ctx context.Context, name string, opts ...any) (output []*s
 		msg := []*schema.Message{}
 		msg = append(msg,
 			schema.UserMessage(fmt.Sprintf("# Research Requirements\n\n## Task\n\n %v \n\n## Description\n\n %v", state.CurrentPlan.Title, state.CurrentPlan.Thought)),
-			schema.SystemMessage("IMPORTANT: Structure your report according to the format in the prompt. Remember to include:\n\n1. Key Points - A bulleted list of the most important findings\n2. Overview - A brief introduction to the topic\n3. Detailed Analysis - Organized into logical sections\n4. Survey Note (optional) - For more comprehensive reports\n5. Key Citations - List all references at the end\n\nFor citations, DO NOT include inline citations in the text. Instead, place all citations in the 'Key Citations' section at the end using the format: `- [Source Title](URL)`. Include an empty line between each citation for better readability.\n\nPRIORITIZE USING MARKDOWN TABLES for data presentation and comparison. Use tables whenever presenting comparative data, statistics, features, or options. Structure tables with clear headers and aligned columns. Example table format:\n\n| Feature | Description | Pros | Cons |\n|---------|-------------|------|------|\n| Feature 1 | Description 1 | Pros 1 | Cons 1 |\n| Feature 2 | Description 2 | Pros 2 | Cons 2 |"),
+			schema.SystemMessage("IMPORTANT: Structure your report according to the format in the prompt. Remember to include:\n\n1. Key Points - A bulleted list of the most important findings\n2. Overview - A brief introduction to the topic\n3. Detailed Analysis - Organized into logical sections\n4. Survey Note (optional) - For more comprehensive reports\n5. Inline citations - Cite every fact with the marker of its source, e.g. `[^3]`\n\nFor citations, ONLY use the markers listed in the collected sources below. Do not write a citation list or link to URLs that are not collected sources: unknown markers and links are removed, and the References section is generated from the markers.\n\nPRIORITIZE USING MARKDOWN TABLES for data presentation and comparison. Use tables whenever presenting comparative data, statistics, features, or options. Structure tables with clear headers and aligned columns. Example table format:\n\n| Feature | Description | Pros | Cons |\n|---------|-------------|------|------|\n| Feature 1 | Description 1 | Pros 1 | Cons 1 |\n| Feature 2 | Description 2 | Pros 2 | Cons 2 |"),
 		)
 		for _, step := range state.CurrentPlan.Steps {
 			msg = append(msg, schema.UserMessage(fmt.Sprintf("Below are some observations for the research task:\n\n %v", *step.ExecutionRes)))
 		}
+		if len(state.Sources) > 0 {
+			msg = append(msg, schema.UserMessage("Collected sources, cite them with their markers:"+citation.Annotate(state.Sources)))
+		}
 		variables := map[string]any{
 			"locale":              state.Locale,
 			"max_step_num":        state.MaxStepNum,
@@ -64,13 +72,72 @@ func loadReporterMsg(ctx context.Context, name string, opts ...any) (output []*s
 	return output, err
 }
 
+// streamReport 流式生成报告。模型的原始输出不推送到前端，推送的是经过引用校验、
+// 附上参考文献后的内容，与保存的报告一致
+func streamReport(ctx context.Context, input []*schema.Message, opts ...any) (*schema.StreamReader[*schema.Message], error) {
+	var sources []*citation.Source
+	err := compose.ProcessState[*model.State](ctx, func(_ context.Context, state *model.State) error {
+		sources = state.Sources
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sr, err := infra.ChatModel.Stream(infra.SkipPush(ctx), input)
+	if err != nil {
+		return nil, err
+	}
+
+	out, w := schema.Pipe[*schema.Message](1)
+	go func() {
+		defer w.Close()
+		defer sr.Close()
+		filter := citation.NewFilter(sources)
+		var meta *schema.ResponseMeta
+		for {
+			chunk, err := sr.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				w.Send(nil, err)
+				return
+			}
+			if chunk.ResponseMeta != nil {
+				meta = chunk.ResponseMeta
+			}
+			if text := filter.Write(chunk.Content); text != "" {
+				w.Send(schema.AssistantMessage(text, nil), nil)
+			}
+		}
+		tail, check := filter.Close()
+		_ = compose.ProcessState[*model.State](ctx, func(_ context.Context, state *model.State) error {
+			state.Citations = check
+			return nil
+		})
+		last := schema.AssistantMessage(tail, nil)
+		last.ResponseMeta = meta
+		w.Send(last, nil)
+	}()
+	return out, nil
+}
+
 func routerReporter(ctx context.Context, input *schema.Message, opts ...any) (output string, err error) {
 	err = compose.ProcessState[*model.State](ctx, func(_ context.Context, state *model.State) error {
 		defer func() {
 			output = state.Goto
 		}()
 		ilog.EventInfo(ctx, "report_end", "report", input.Content)
-		state.Goto = compose.END
+		// streamReport 已校验引用并生成参考文献，无效的引用和链接已被删除
+		state.Report = input.Content
+		if state.Citations != nil && !state.Citations.OK() {
+			ilog.EventWarn(ctx, "report_citation_invalid", "invalid", state.Citations.Invalid, "unknown_links", state.Citations.UnknownLinks)
+		}
+		saveArtifact(ctx, state, "report.md", []byte(state.Report))
+		if data, err := json.MarshalIndent(state.Sources, "", "  "); err == nil {
+			saveArtifact(ctx, state, "sources.json", data)
+		}
+		state.Goto = nextOutput(state, consts.Reporter)
 		return nil
 	})
 	return output, nil
@@ -80,7 +147,7 @@ func NewReporter[I, O any](ctx context.Context) *compose.Graph[I, O] {
 	cag := compose.NewGraph[I, O]()
 
 	_ = cag.AddLambdaNode("load", compose.InvokableLambdaWithOption(loadReporterMsg))
-	_ = cag.AddChatModelNode("agent", infra.ChatModel)
+	_ = cag.AddLambdaNode("agent", compose.StreamableLambdaWithOption(streamReport))
 	_ = cag.AddLambdaNode("router", compose.InvokableLambdaWithOption(routerReporter))
 
 	_ = cag.AddEdge(compose.START, "load")
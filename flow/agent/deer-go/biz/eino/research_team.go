@@ -18,9 +18,11 @@ package eino
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/RanFeng/ilog"
 	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
 
 	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/consts"
 	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/model"
@@ -36,19 +38,16 @@ func routerResearchTeam(ctx context.Context, input string, opts ...any) (output
 		if state.CurrentPlan == nil {
 			return nil
 		}
-		for i, step := range state.CurrentPlan.Steps {
-			if step.ExecutionRes != nil {
-				continue
-			}
-			ilog.EventInfo(ctx, "research_team_step", "step", step, "index", i)
-			switch step.StepType {
-			case model.Research:
-				state.Goto = consts.Researcher
-				return nil
-			case model.Processing:
-				state.Goto = consts.Coder
-				return nil
-			}
+		// 依赖已满足的步骤一起派发，多于一个时并行执行
+		state.RunningSteps = state.CurrentPlan.ReadySteps(state.MaxParallelSteps)
+		ilog.EventInfo(ctx, "research_team_steps", "steps", state.RunningSteps)
+		switch {
+		case len(state.RunningSteps) > 1:
+			state.Goto = consts.ParallelExecutor
+			return nil
+		case len(state.RunningSteps) == 1:
+			state.Goto = stepAgent(&state.CurrentPlan.Steps[state.RunningSteps[0]])
+			return nil
 		}
 		if state.PlanIterations >= state.MaxPlanIterations {
 			state.Goto = consts.Reporter
@@ -59,6 +58,27 @@ func routerResearchTeam(ctx context.Context, input string, opts ...any) (output
 	return output, nil
 }
 
+// stepAgent 执行该步骤的 agent
+func stepAgent(step *model.Step) string {
+	if step.StepType == model.Processing {
+		return consts.Coder
+	}
+	return consts.Researcher
+}
+
+// dependencyMsgs 把步骤 i 所依赖步骤的执行结果作为输入的一部分
+func dependencyMsgs(state *model.State, i int) []*schema.Message {
+	msg := []*schema.Message{}
+	for _, d := range state.CurrentPlan.Dependencies(i) {
+		dep := state.CurrentPlan.Steps[d]
+		if dep.ExecutionRes == nil {
+			continue
+		}
+		msg = append(msg, schema.UserMessage(fmt.Sprintf("Below are the results of step %d (%s), which this task depends on:\n\n %v", d+1, dep.Title, *dep.ExecutionRes)))
+	}
+	return msg
+}
+
 func NewResearchTeamNode[I, O any](ctx context.Context) *compose.Graph[I, O] {
 	cag := compose.NewGraph[I, O]()
 	_ = cag.AddLambdaNode("router", compose.InvokableLambdaWithOption(routerResearchTeam))
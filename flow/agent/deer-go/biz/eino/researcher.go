@@ -38,47 +38,45 @@ import (
 
 func loadResearcherMsg(ctx context.Context, name string, opts ...any) (output []*schema.Message, err error) {
 	err = compose.ProcessState[*model.State](ctx, func(_ context.Context, state *model.State) error {
-		sysPrompt, err := infra.GetPromptTemplate(ctx, name)
-		if err != nil {
-			ilog.EventInfo(ctx, "get prompt template fail")
-			return err
-		}
-
-		promptTemp := prompt.FromMessages(schema.Jinja2,
-			schema.SystemMessage(sysPrompt),
-			schema.MessagesPlaceholder("user_input", true),
-		)
-
-		var curStep *model.Step
-		for i := range state.CurrentPlan.Steps {
-			if state.CurrentPlan.Steps[i].ExecutionRes == nil {
-				curStep = &state.CurrentPlan.Steps[i]
-				break
-			}
-		}
-
+		i, curStep := state.CurrentStep()
 		if curStep == nil {
 			panic("no step found")
 		}
-
-		msg := []*schema.Message{}
-		msg = append(msg,
-			schema.UserMessage(fmt.Sprintf("#Task\n\n##title\n\n %v \n\n##description\n\n %v \n\n##locale\n\n %v", curStep.Title, curStep.Description, state.Locale)),
-			schema.SystemMessage("IMPORTANT: DO NOT include inline citations in the text. Instead, track all sources and include a References section at the end using link reference format. Include an empty line between each citation for better readability. Use this format for each reference:\n- [Source Title](URL)\n\n- [Another Source](URL)"),
-		)
-		variables := map[string]any{
-			"locale":              state.Locale,
-			"max_step_num":        state.MaxStepNum,
-			"max_plan_iterations": state.MaxPlanIterations,
-			"CURRENT_TIME":        time.Now().Format("2006-01-02 15:04:05"),
-			"user_input":          msg,
-		}
-		output, err = promptTemp.Format(ctx, variables)
+		output, err = researcherMsg(ctx, name, state, i)
 		return err
 	})
 	return output, err
 }
 
+// researcherMsg 生成执行第 i 个步骤的输入，顺序执行和并行执行共用
+func researcherMsg(ctx context.Context, name string, state *model.State, i int) ([]*schema.Message, error) {
+	sysPrompt, err := infra.GetPromptTemplate(ctx, name)
+	if err != nil {
+		ilog.EventInfo(ctx, "get prompt template fail")
+		return nil, err
+	}
+
+	promptTemp := prompt.FromMessages(schema.Jinja2,
+		schema.SystemMessage(sysPrompt),
+		schema.MessagesPlaceholder("user_input", true),
+	)
+
+	curStep := &state.CurrentPlan.Steps[i]
+	msg := dependencyMsgs(state, i)
+	msg = append(msg,
+		schema.UserMessage(fmt.Sprintf("#Task\n\n##title\n\n %v \n\n##description\n\n %v \n\n##locale\n\n %v", curStep.Title, curStep.Description, state.Locale)),
+		schema.SystemMessage("IMPORTANT: Tool results end with a <sources> list giving each source a citation marker such as [^3]. Cite every fact inline with the marker of its source, e.g. `Revenue grew 20% in 2024 [^3].` Only use markers from the <sources> lists, never invent markers or URLs, and do not add a References section."),
+	)
+	variables := map[string]any{
+		"locale":              state.Locale,
+		"max_step_num":        state.MaxStepNum,
+		"max_plan_iterations": state.MaxPlanIterations,
+		"CURRENT_TIME":        time.Now().Format("2006-01-02 15:04:05"),
+		"user_input":          msg,
+	}
+	return promptTemp.Format(ctx, variables)
+}
+
 func routerResearcher(ctx context.Context, input *schema.Message, opts ...any) (output string, err error) {
 	// ilog.EventInfo(ctx, "routerResearcher", "input", input)
 	last := input
@@ -86,13 +84,11 @@ func routerResearcher(ctx context.Context, input *schema.Message, opts ...any) (
 		defer func() {
 			output = state.Goto
 		}()
-		for i, step := range state.CurrentPlan.Steps {
-			if step.ExecutionRes == nil {
-				str := strings.Clone(last.Content)
-				state.CurrentPlan.Steps[i].ExecutionRes = &str
-				break
-			}
+		if i, _ := state.CurrentStep(); i >= 0 {
+			str := strings.Clone(last.Content)
+			state.CurrentPlan.Steps[i].ExecutionRes = &str
 		}
+		state.RunningSteps = nil
 		ilog.EventInfo(ctx, "researcher_end", "plan", state.CurrentPlan)
 		state.Goto = consts.ResearchTeam
 		return nil
@@ -137,9 +133,8 @@ func toolCallChecker(_ context.Context, sr *schema.StreamReader[*schema.Message]
 	}
 }
 
-func NewResearcher[I, O any](ctx context.Context) *compose.Graph[I, O] {
-	cag := compose.NewGraph[I, O]()
-
+// newResearchAgent 可以使用全部 MCP 工具的 react agent
+func newResearchAgent(ctx context.Context) *react.Agent {
 	researchTools := []tool.BaseTool{}
 	for _, cli := range infra.MCPServer {
 		ts, err := mcp.GetTools(ctx, &mcp.Config{Cli: cli})
@@ -148,6 +143,7 @@ func NewResearcher[I, O any](ctx context.Context) *compose.Graph[I, O] {
 		}
 		researchTools = append(researchTools, ts...)
 	}
+	researchTools = citedTools(ctx, researchTools)
 	ilog.EventDebug(ctx, "researcher_end", "research_tools", len(researchTools))
 
 	agent, err := react.NewAgent(ctx, &react.AgentConfig{
@@ -157,7 +153,16 @@ func NewResearcher[I, O any](ctx context.Context) *compose.Graph[I, O] {
 		MessageModifier:       modifyInputfunc,
 		StreamToolCallChecker: toolCallChecker,
 	})
+	if err != nil {
+		panic(err)
+	}
+	return agent
+}
+
+func NewResearcher[I, O any](ctx context.Context) *compose.Graph[I, O] {
+	cag := compose.NewGraph[I, O]()
 
+	agent := newResearchAgent(ctx)
 	agentLambda, err := compose.AnyLambda(agent.Generate, agent.Stream, nil, nil)
 	if err != nil {
 		panic(err)
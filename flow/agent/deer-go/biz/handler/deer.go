@@ -22,20 +22,41 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"path/filepath"
 
 	"github.com/RanFeng/ilog"
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/utils"
 	"github.com/cloudwego/hertz/pkg/protocol/sse"
 
 	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/consts"
 	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/eino"
 	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/infra"
 	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/model"
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/store"
 	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/util"
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/conf"
 )
 
 func ChatStreamEino(ctx context.Context, c *app.RequestContext) {
+	// 请求体校验，失败时流尚未开始，直接返回 400
+	req := new(model.ChatRequest)
+	err := c.BindAndValidate(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.H{"error": err.Error()})
+		return
+	}
+	ilog.EventInfo(ctx, "ChatStream_begin", "req", req)
+	// thread_id 会被拼成产出目录的路径，先校验以免越出 output_dir
+	if req.ThreadID != "" {
+		if err = store.CheckID(req.ThreadID); err != nil {
+			ilog.EventWarn(ctx, "ChatStream_invalid_thread_id", "err", err)
+			c.JSON(http.StatusBadRequest, utils.H{"error": err.Error()})
+			return
+		}
+	}
+
 	// 设置响应头（NewStream 会自动设置部分头，但建议显式声明）
 	c.SetContentType("text/event-stream; charset=utf-8")
 	c.Response.Header.Set("Cache-Control", "no-cache")
@@ -47,22 +68,22 @@ func ChatStreamEino(ctx context.Context, c *app.RequestContext) {
 	w := sse.NewWriter(c)
 	defer w.Close()
 
-	// 请求体校验
-	req := new(model.ChatRequest)
-	err := c.BindAndValidate(req)
-	if err != nil {
-		return
+	if req.MaxParallelSteps == 0 {
+		req.MaxParallelSteps = conf.Config.Setting.MaxParallelSteps
 	}
-	ilog.EventInfo(ctx, "ChatStream_begin", "req", req)
 
 	// 根据前端参数生成Graph State
 	genFunc := func(ctx context.Context) *model.State {
 		return &model.State{
+			ThreadID:                      req.ThreadID,
 			MaxPlanIterations:             req.MaxPlanIterations,
 			MaxStepNum:                    req.MaxStepNum,
+			MaxParallelSteps:              req.MaxParallelSteps,
 			Messages:                      req.Messages,
 			Goto:                          consts.Coordinator,
 			EnableBackgroundInvestigation: req.EnableBackgroundInvestigation,
+			Outputs:                       req.Outputs,
+			OutputDir:                     filepath.Join(conf.Config.Setting.OutputDir, req.ThreadID),
 		}
 	}
 
@@ -90,7 +111,9 @@ func ChatStreamEino(ctx context.Context, c *app.RequestContext) {
 	)
 
 	// 将interrupt信号传递到前端
-	if info, ok := compose.ExtractInterruptInfo(err); ok {
+	info, interrupted := compose.ExtractInterruptInfo(err)
+	updateThreadStatus(ctx, req.ThreadID, interrupted, err)
+	if interrupted {
 		ilog.EventDebug(ctx, "ChatStream_interrupt", "info", info)
 		data := &model.ChatResp{
 			ThreadID:     req.ThreadID,
@@ -116,3 +139,20 @@ func ChatStreamEino(ctx context.Context, c *app.RequestContext) {
 		ilog.EventError(ctx, err, "ChatStream_error")
 	}
 }
+
+// updateThreadStatus 记录一次运行的结果，运行结束的状态由 agent 流转时记录
+func updateThreadStatus(ctx context.Context, threadID string, interrupted bool, err error) {
+	if threadID == "" || (!interrupted && err == nil) {
+		return
+	}
+	uErr := store.Default().UpdateThread(threadID, func(t *model.Thread) {
+		if interrupted {
+			t.Status, t.Error = model.ThreadInterrupted, ""
+			return
+		}
+		t.Status, t.Error = model.ThreadFailed, err.Error()
+	})
+	if uErr != nil {
+		ilog.EventError(ctx, uErr, "update_thread_fail", "thread_id", threadID)
+	}
+}
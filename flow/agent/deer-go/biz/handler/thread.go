@@ -0,0 +1,106 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/RanFeng/ilog"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/utils"
+
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/model"
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/store"
+)
+
+// ListThreads GET /api/threads 列出历史会话，最近更新的在前
+func ListThreads(ctx context.Context, c *app.RequestContext) {
+	threads, err := store.Default().ListThreads()
+	if err != nil {
+		storeError(ctx, c, err)
+		return
+	}
+	summaries := make([]*model.ThreadSummary, 0, len(threads))
+	for _, t := range threads {
+		summaries = append(summaries, t.Summary())
+	}
+	c.JSON(http.StatusOK, utils.H{"threads": summaries})
+}
+
+// GetThread GET /api/threads/:thread_id 返回会话的计划和各步骤的执行结果
+func GetThread(ctx context.Context, c *app.RequestContext) {
+	if t := loadThread(ctx, c); t != nil {
+		c.JSON(http.StatusOK, t)
+	}
+}
+
+// GetThreadReport GET /api/threads/:thread_id/report 返回会话的最终报告
+func GetThreadReport(ctx context.Context, c *app.RequestContext) {
+	t := loadThread(ctx, c)
+	if t == nil {
+		return
+	}
+	if t.Report == "" {
+		c.JSON(http.StatusNotFound, utils.H{"error": "report not ready", "status": t.Status})
+		return
+	}
+	c.JSON(http.StatusOK, utils.H{
+		"thread_id": t.ID,
+		"report":    t.Report,
+		"artifacts": t.Artifacts,
+		"sources":   t.Sources,
+		"citations": t.Citations,
+	})
+}
+
+// DeleteThread DELETE /api/threads/:thread_id 删除会话和它的 CheckPoint
+func DeleteThread(ctx context.Context, c *app.RequestContext) {
+	ok, err := store.Default().DeleteThread(c.Param("thread_id"))
+	if err != nil {
+		storeError(ctx, c, err)
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusNotFound, utils.H{"error": "thread not found"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func loadThread(ctx context.Context, c *app.RequestContext) *model.Thread {
+	t, ok, err := store.Default().GetThread(c.Param("thread_id"))
+	if err != nil {
+		storeError(ctx, c, err)
+		return nil
+	}
+	if !ok {
+		c.JSON(http.StatusNotFound, utils.H{"error": "thread not found"})
+		return nil
+	}
+	return t
+}
+
+func storeError(ctx context.Context, c *app.RequestContext, err error) {
+	if errors.Is(err, store.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, utils.H{"error": err.Error()})
+		return
+	}
+	ilog.EventError(ctx, err, "thread_store_error")
+	c.JSON(http.StatusInternalServerError, utils.H{"error": err.Error()})
+}
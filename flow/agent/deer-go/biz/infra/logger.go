@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 
 	"github.com/RanFeng/ilog"
 	"github.com/cloudwego/eino/callbacks"
@@ -35,12 +36,28 @@ import (
 	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/util"
 )
 
+type agentNameKey struct{}
+
+// WithAgentName 指定推送到前端的 agent 名字，用于并行执行的步骤，否则取 state.Goto
+func WithAgentName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, agentNameKey{}, name)
+}
+
+type skipPushKey struct{}
+
+// SkipPush 标记 ctx 中产生的模型输出不推送到前端，用于调用方处理输出后再自行推送的场景
+func SkipPush(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipPushKey{}, true)
+}
+
 type LoggerCallback struct {
 	callbacks.HandlerBuilder // 可以用 callbacks.HandlerBuilder 来辅助实现 callback
 
 	ID  string
 	SSE *sse.Writer
 	Out chan string
+
+	mu sync.Mutex // 并行步骤共用 SSE，逐帧写出，避免交错
 }
 
 func (cb *LoggerCallback) pushF(ctx context.Context, event string, data *model.ChatResp) error {
@@ -50,7 +67,9 @@ func (cb *LoggerCallback) pushF(ctx context.Context, event string, data *model.C
 		return err
 	}
 	if cb.SSE != nil {
+		cb.mu.Lock()
 		err = cb.SSE.WriteEvent("", event, dataByte)
+		cb.mu.Unlock()
 	}
 	if cb.Out != nil {
 		cb.Out <- data.Content
@@ -63,11 +82,13 @@ func (cb *LoggerCallback) pushMsg(ctx context.Context, msgID string, msg *schema
 		return nil
 	}
 
-	agentName := ""
-	_ = compose.ProcessState[*model.State](ctx, func(_ context.Context, state *model.State) error {
-		agentName = state.Goto
-		return nil
-	})
+	agentName, _ := ctx.Value(agentNameKey{}).(string)
+	if agentName == "" {
+		_ = compose.ProcessState[*model.State](ctx, func(_ context.Context, state *model.State) error {
+			agentName = state.Goto
+			return nil
+		})
+	}
 
 	fr := ""
 	if msg.ResponseMeta != nil {
@@ -153,6 +174,10 @@ func (cb *LoggerCallback) OnError(ctx context.Context, info *callbacks.RunInfo,
 func (cb *LoggerCallback) OnEndWithStreamOutput(ctx context.Context, info *callbacks.RunInfo,
 	output *schema.StreamReader[callbacks.CallbackOutput],
 ) context.Context {
+	if skip, _ := ctx.Value(skipPushKey{}).(bool); skip {
+		output.Close()
+		return ctx
+	}
 	msgID := util.RandStr(20)
 	go func() {
 		defer output.Close() // remember to close the stream in defer
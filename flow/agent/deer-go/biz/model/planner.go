@@ -31,6 +31,13 @@ type Step struct {
 	Description   string   `json:"description" validate:"required"`
 	StepType      StepType `json:"step_type" validate:"required"`
 	ExecutionRes  *string  `json:"execution_res,omitempty"`
+	// DependsOn 依赖的前序步骤编号（从 1 开始），没有依赖的步骤可以并行执行
+	DependsOn []int `json:"depends_on,omitempty"`
+}
+
+// Executable 只有 research 和 processing 类型的步骤会被执行
+func (s *Step) Executable() bool {
+	return s.StepType == Research || s.StepType == Processing
 }
 
 // Plan 定义计划的结构体
@@ -41,3 +48,47 @@ type Plan struct {
 	Title            string `json:"title" validate:"required"`
 	Steps            []Step `json:"steps"`
 }
+
+// ReadySteps 按计划顺序返回最多 limit 个可以同时执行的步骤下标：尚未执行，且依赖的步骤都已执行完。
+// 依赖无法满足时（例如循环依赖）返回第一个未执行的步骤，保证计划总能推进。
+func (p *Plan) ReadySteps(limit int) []int {
+	limit = max(limit, 1)
+	var ready []int
+	first := -1
+	for i := range p.Steps {
+		step := &p.Steps[i]
+		if step.ExecutionRes != nil || !step.Executable() {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		if len(ready) < limit && p.depsDone(i) {
+			ready = append(ready, i)
+		}
+	}
+	if len(ready) == 0 && first >= 0 {
+		ready = append(ready, first)
+	}
+	return ready
+}
+
+// Dependencies 返回步骤 i 依赖的步骤下标，忽略越界的和指向自己的编号
+func (p *Plan) Dependencies(i int) []int {
+	var deps []int
+	for _, n := range p.Steps[i].DependsOn {
+		if d := n - 1; d >= 0 && d < len(p.Steps) && d != i {
+			deps = append(deps, d)
+		}
+	}
+	return deps
+}
+
+func (p *Plan) depsDone(i int) bool {
+	for _, d := range p.Dependencies(i) {
+		if dep := &p.Steps[d]; dep.ExecutionRes == nil && dep.Executable() {
+			return false
+		}
+	}
+	return true
+}
@@ -26,10 +26,12 @@ type ChatRequest struct {
 	ThreadID                      string                 `json:"thread_id,omitempty" form:"thread_id"`
 	MaxPlanIterations             int                    `json:"max_plan_iterations,omitempty" form:"max_plan_iterations"`
 	MaxStepNum                    int                    `json:"max_step_num,omitempty" form:"max_step_num"`
+	MaxParallelSteps              int                    `json:"max_parallel_steps,omitempty" form:"max_parallel_steps"` // 不指定时使用配置文件中的 setting.max_parallel_steps
 	AutoAcceptedPlan              bool                   `json:"auto_accepted_plan,omitempty" form:"auto_accepted_plan"`
 	InterruptFeedback             string                 `json:"interrupt_feedback,omitempty" form:"interrupt_feedback"`
 	MCPSettings                   map[string]interface{} `json:"mcp_settings,omitempty" form:"mcp_settings"`
 	EnableBackgroundInvestigation bool                   `json:"enable_background_investigation,omitempty" form:"enable_background_investigation"`
+	Outputs                       []string               `json:"outputs,omitempty" form:"outputs"` // 报告之后的产出物: podcast, ppt
 }
 
 type ToolResp struct {
@@ -17,11 +17,12 @@
 package model
 
 import (
-	"context"
 	"encoding/json"
 
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/citation"
 )
 
 func init() {
@@ -35,6 +36,9 @@ type State struct {
 	// 用户输入的信息
 	Messages []*schema.Message `json:"messages,omitempty"`
 
+	// 会话 id，即 ChatRequest.ThreadID，为空时不记录会话
+	ThreadID string `json:"thread_id,omitempty"`
+
 	// 子图共享变量
 	Goto                           string `json:"goto,omitempty"`
 	CurrentPlan                    *Plan  `json:"current_plan,omitempty"`
@@ -42,14 +46,54 @@ type State struct {
 	PlanIterations                 int    `json:"plan_iterations,omitempty"`
 	BackgroundInvestigationResults string `json:"background_investigation_results"`
 	InterruptFeedback              string `json:"interrupt_feedback,omitempty"`
+	RunningSteps                   []int  `json:"running_steps,omitempty"` // research_team 派发执行的步骤下标
+	Report                         string `json:"report,omitempty"`
+
+	// 工具调用取得的来源，编号即 Source.ID，以及最终报告的引用校验结果
+	Sources   []*citation.Source `json:"sources,omitempty"`
+	Citations *citation.Check    `json:"citations,omitempty"`
+
+	// 报告之后的产出物
+	Outputs   []string `json:"outputs,omitempty"`    // 要生成的产出物，见 consts.OutputPodcast 等
+	OutputDir string   `json:"output_dir,omitempty"` // 产出物文件的写入目录
+	Artifacts []string `json:"artifacts,omitempty"`  // 已写入的产出物文件
 
 	// 全局配置变量
 	MaxPlanIterations             int  `json:"max_plan_iterations,omitempty"`
 	MaxStepNum                    int  `json:"max_step_num,omitempty"`
+	MaxParallelSteps              int  `json:"max_parallel_steps,omitempty"` // 同时执行的步骤数上限，不大于 1 时逐个执行
 	AutoAcceptedPlan              bool `json:"auto_accepted_plan"`
 	EnableBackgroundInvestigation bool `json:"enable_background_investigation"`
 }
 
+// CurrentStep 返回派发给 researcher 或 coder 的步骤，未派发时返回第一个未执行的步骤
+func (s *State) CurrentStep() (int, *Step) {
+	if s.CurrentPlan == nil {
+		return -1, nil
+	}
+	if len(s.RunningSteps) > 0 {
+		if i := s.RunningSteps[0]; i < len(s.CurrentPlan.Steps) {
+			return i, &s.CurrentPlan.Steps[i]
+		}
+	}
+	for i := range s.CurrentPlan.Steps {
+		if s.CurrentPlan.Steps[i].ExecutionRes == nil {
+			return i, &s.CurrentPlan.Steps[i]
+		}
+	}
+	return -1, nil
+}
+
+// AddSources 记录第 step 个步骤（从 1 开始，0 为背景调查）取得的来源，返回它们的编号后的记录
+func (s *State) AddSources(step int, found []*citation.Source) []*citation.Source {
+	for _, f := range found {
+		f.Step = step
+	}
+	var assigned []*citation.Source
+	s.Sources, assigned = citation.Add(s.Sources, found)
+	return assigned
+}
+
 func (s *State) MarshalJSON() ([]byte, error) {
 	b, err := json.Marshal(*s)
 	if err != nil {
@@ -67,29 +111,3 @@ func (s *State) UnmarshalJSON(b []byte) error {
 	*s = State(tmp)
 	return nil
 }
-
-// DeerCheckPoint DeerGo的全局状态存储点，
-// 实现CheckPointStore接口，用checkPointID进行索引
-// 此处粗略使用map实现，工程上可以用工业存储组件实现
-type DeerCheckPoint struct {
-	buf map[string][]byte
-}
-
-func (dc *DeerCheckPoint) Get(ctx context.Context, checkPointID string) ([]byte, bool, error) {
-	data, ok := dc.buf[checkPointID]
-	return data, ok, nil
-}
-
-func (dc *DeerCheckPoint) Set(ctx context.Context, checkPointID string, checkPoint []byte) error {
-	dc.buf[checkPointID] = checkPoint
-	return nil
-}
-
-// 创建一个全局状态存储点实例并返回
-var deerCheckPoint = DeerCheckPoint{
-	buf: make(map[string][]byte),
-}
-
-func NewDeerCheckPoint(ctx context.Context) compose.CheckPointStore {
-	return &deerCheckPoint
-}
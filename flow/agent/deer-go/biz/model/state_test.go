@@ -18,6 +18,7 @@ package model
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -51,3 +52,44 @@ func TestState(t *testing.T) {
 	}
 	fmt.Println(string(bt))
 }
+
+func TestReadySteps(t *testing.T) {
+	done := "done"
+	plan := &Plan{Steps: []Step{
+		{Title: "1", StepType: Research},
+		{Title: "2", StepType: Research},
+		{Title: "3", StepType: Processing, DependsOn: []int{1, 2}},
+		{Title: "4", StepType: Research, DependsOn: []int{4, 9}}, // 无效的依赖被忽略
+		{Title: "5", StepType: Processing, DependsOn: []int{3}},
+	}}
+	check := func(limit int, want ...int) {
+		t.Helper()
+		if got := plan.ReadySteps(limit); !reflect.DeepEqual(got, want) {
+			t.Fatalf("ReadySteps(%d) = %v, want %v", limit, got, want)
+		}
+	}
+	check(0, 0)
+	check(2, 0, 1)
+	check(5, 0, 1, 3)
+
+	plan.Steps[0].ExecutionRes, plan.Steps[1].ExecutionRes, plan.Steps[3].ExecutionRes = &done, &done, &done
+	check(5, 2)
+	plan.Steps[2].ExecutionRes = &done
+	check(5, 4)
+	plan.Steps[4].ExecutionRes = &done
+	check(5)
+
+	// 循环依赖时退化为顺序执行
+	cyclic := &Plan{Steps: []Step{
+		{StepType: Research, DependsOn: []int{2}},
+		{StepType: Research, DependsOn: []int{1}},
+	}}
+	if got := cyclic.ReadySteps(3); !reflect.DeepEqual(got, []int{0}) {
+		t.Fatalf("cyclic ReadySteps = %v", got)
+	}
+
+	state := &State{CurrentPlan: plan, RunningSteps: []int{3}}
+	if i, step := state.CurrentStep(); i != 3 || step.Title != "4" {
+		t.Fatalf("CurrentStep = %d, %+v", i, step)
+	}
+}
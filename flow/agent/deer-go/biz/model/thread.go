@@ -0,0 +1,73 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"time"
+
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/citation"
+)
+
+// ThreadStatus 研究会话的状态
+type ThreadStatus string
+
+const (
+	ThreadRunning     ThreadStatus = "running"
+	ThreadInterrupted ThreadStatus = "interrupted" // 等待用户确认计划
+	ThreadFinished    ThreadStatus = "finished"
+	ThreadFailed      ThreadStatus = "failed"
+)
+
+// Thread 一次研究会话（ChatRequest.ThreadID）的记录，每个 agent 执行完都会更新，
+// 前端可据此重新打开历史会话
+type Thread struct {
+	ID        string       `json:"thread_id"`
+	Title     string       `json:"title"` // 用户的第一条消息
+	Status    ThreadStatus `json:"status"`
+	Error     string       `json:"error,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+
+	Locale    string   `json:"locale,omitempty"`
+	Plan      *Plan    `json:"plan,omitempty"` // 含各步骤的执行结果
+	Report    string   `json:"report,omitempty"`
+	Artifacts []string `json:"artifacts,omitempty"`
+
+	Sources   []*citation.Source `json:"sources,omitempty"`
+	Citations *citation.Check    `json:"citations,omitempty"`
+}
+
+// ThreadSummary 会话列表中的一项
+type ThreadSummary struct {
+	ID        string       `json:"thread_id"`
+	Title     string       `json:"title"`
+	Status    ThreadStatus `json:"status"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	HasReport bool         `json:"has_report"`
+}
+
+func (t *Thread) Summary() *ThreadSummary {
+	return &ThreadSummary{
+		ID:        t.ID,
+		Title:     t.Title,
+		Status:    t.Status,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+		HasReport: t.Report != "",
+	}
+}
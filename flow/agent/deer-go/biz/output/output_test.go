@@ -0,0 +1,62 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseScript(t *testing.T) {
+	s, err := ParseScript("```json\n{\"locale\": \"zh\", \"lines\": [{\"speaker\": \"male\", \"text\": \"大家好<>\"}, {\"speaker\": \"female\", \"text\": \"你好\"}]}\n```")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "{\"speaker\":\"male\",\"text\":\"大家好<>\"}\n{\"speaker\":\"female\",\"text\":\"你好\"}\n"
+	if s.Locale != "zh" || string(s.JSONL()) != want {
+		t.Fatalf("script = %+v, jsonl = %s", s, s.JSONL())
+	}
+
+	for _, bad := range []string{
+		"not json",
+		`{"locale": "en", "lines": []}`,
+		`{"locale": "en", "lines": [{"speaker": "host", "text": "hi"}]}`,
+		`{"locale": "en", "lines": [{"speaker": "male", "text": " "}]}`,
+	} {
+		if _, err = ParseScript(bad); err == nil {
+			t.Errorf("%s: no error", bad)
+		}
+	}
+}
+
+func TestParseDeck(t *testing.T) {
+	d := ParseDeck("```markdown\n# 标题\n\n---\n\n## 议程\n\n- a\n\n---\n\n```yaml\n---\nk: v\n```\n\n---\n\n```")
+	if d.Title != "标题" || len(d.Slides) != 3 || !strings.Contains(d.Slides[2], "k: v") {
+		t.Fatalf("deck = %+v", d)
+	}
+	if md := d.Markdown(); !strings.HasPrefix(md, "# 标题\n\n---\n\n## 议程") {
+		t.Fatalf("markdown = %q", md)
+	}
+
+	html, err := ParseDeck("# A</textarea><script>\n---\n## B").RevealHTML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(html), "<section data-markdown>") != 2 || strings.Contains(string(html), "</textarea><script>") {
+		t.Fatalf("html = %s", html)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package output 把研究报告转成其他形式的产出物：双人播客脚本和幻灯片。
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ScriptLine 播客脚本中的一句台词
+type ScriptLine struct {
+	Speaker string `json:"speaker"` // male | female
+	Text    string `json:"text"`
+}
+
+// Script 与 podcast_script_writer.md 中约定的输出格式一致
+type Script struct {
+	Locale string       `json:"locale"`
+	Lines  []ScriptLine `json:"lines"`
+}
+
+// ParseScript 解析模型输出的播客脚本，允许外层包裹 ```json 代码块
+func ParseScript(content string) (*Script, error) {
+	s := &Script{}
+	if err := json.Unmarshal([]byte(TrimFence(content)), s); err != nil {
+		return nil, fmt.Errorf("解析播客脚本失败: %w", err)
+	}
+	if len(s.Lines) == 0 {
+		return nil, fmt.Errorf("播客脚本为空")
+	}
+	for i, l := range s.Lines {
+		if l.Speaker != "male" && l.Speaker != "female" {
+			return nil, fmt.Errorf("第 %d 句的 speaker %q 不是 male 或 female", i+1, l.Speaker)
+		}
+		if strings.TrimSpace(l.Text) == "" {
+			return nil, fmt.Errorf("第 %d 句台词为空", i+1)
+		}
+	}
+	return s, nil
+}
+
+// JSONL 每句台词一行 JSON，便于交给 TTS 逐句合成
+func (s *Script) JSONL() []byte {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	for _, l := range s.Lines {
+		_ = enc.Encode(l)
+	}
+	return buf.Bytes()
+}
+
+// TrimFence 去掉模型输出外层的 ``` 代码块
+func TrimFence(content string) string {
+	content = strings.TrimSpace(content)
+	if !strings.HasPrefix(content, "```") {
+		return content
+	}
+	if i := strings.IndexByte(content, '\n'); i >= 0 {
+		content = content[i+1:]
+	} else {
+		return ""
+	}
+	content = strings.TrimSpace(content)
+	return strings.TrimSpace(strings.TrimSuffix(content, "```"))
+}
+
+// Save 把产出物写到 dir/name，返回文件路径
+func Save(dir, name string, data []byte) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, name)
+	return path, os.WriteFile(path, data, 0o644)
+}
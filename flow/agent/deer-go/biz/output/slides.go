@@ -0,0 +1,97 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+)
+
+// Deck 由 ppt_composer.md 生成的 Markdown 幻灯片
+type Deck struct {
+	Title  string
+	Slides []string
+}
+
+// ParseDeck 按单独一行的 --- 切分幻灯片，代码块中的 --- 不切分
+func ParseDeck(markdown string) *Deck {
+	d := &Deck{}
+	var (
+		cur    []string
+		inCode bool
+	)
+	flush := func() {
+		if s := strings.TrimSpace(strings.Join(cur, "\n")); s != "" {
+			d.Slides = append(d.Slides, s)
+		}
+		cur = nil
+	}
+	for _, line := range strings.Split(TrimFence(markdown), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inCode = !inCode
+		}
+		if !inCode && trimmed == "---" {
+			flush()
+			continue
+		}
+		if d.Title == "" && !inCode && strings.HasPrefix(trimmed, "# ") {
+			d.Title = strings.TrimSpace(trimmed[2:])
+		}
+		cur = append(cur, line)
+	}
+	flush()
+	return d
+}
+
+// Markdown 重新拼接成 Markdown 幻灯片
+func (d *Deck) Markdown() string {
+	return strings.Join(d.Slides, "\n\n---\n\n") + "\n"
+}
+
+// RevealHTML 生成可直接用浏览器打开的 reveal.js 幻灯片，由 reveal.js 的 markdown 插件渲染每一页
+func (d *Deck) RevealHTML() ([]byte, error) {
+	var buf bytes.Buffer
+	err := revealTemplate.Execute(&buf, d)
+	return buf.Bytes(), err
+}
+
+var revealTemplate = template.Must(template.New("reveal").Parse(`<!doctype html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/reveal.js@5/dist/reveal.css">
+<link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/reveal.js@5/dist/theme/white.css">
+</head>
+<body>
+<div class="reveal">
+<div class="slides">
+{{- range .Slides}}
+<section data-markdown><textarea data-template>
+{{.}}
+</textarea></section>
+{{- end}}
+</div>
+</div>
+<script src="https://cdn.jsdelivr.net/npm/reveal.js@5/dist/reveal.js"></script>
+<script src="https://cdn.jsdelivr.net/npm/reveal.js@5/plugin/markdown/markdown.js"></script>
+<script>Reveal.initialize({hash: true, plugins: [RevealMarkdown]});</script>
+</body>
+</html>
+`))
@@ -0,0 +1,272 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package store 持久化 DeerGo 的 Graph CheckPoint 和研究会话记录，
+// 二者都以 ChatRequest.ThreadID 为索引，服务重启后会话可以继续。
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/compose"
+
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/model"
+)
+
+const (
+	kindCheckPoint = "checkpoints"
+	kindThread     = "threads"
+)
+
+// ErrInvalidID thread id 只能包含字母、数字、- 和 _，以免被拼成任意文件路径
+var ErrInvalidID = errors.New("invalid thread id")
+
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,128}$`)
+
+// Store 并发安全的 CheckPointStore 和会话存储。
+// dir 非空时每条记录是 dir/<kind>/<id> 下的一个文件，先写临时文件再 rename，不会读到写了一半的记录；
+// dir 为空时只保存在内存中。
+type Store struct {
+	dir string
+
+	mu  sync.RWMutex
+	mem map[string][]byte // dir 为空时使用，key 为 kind/id
+}
+
+// New 创建一个 Store，dir 为空时只保存在内存中
+func New(dir string) (*Store, error) {
+	s := &Store{dir: dir, mem: map[string][]byte{}}
+	if dir == "" {
+		return s, nil
+	}
+	for _, kind := range []string{kindCheckPoint, kindThread} {
+		if err := os.MkdirAll(filepath.Join(dir, kind), 0o755); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+var defaultStore, _ = New("")
+
+// Init 把默认 Store 设为保存在 dir 下，服务启动时调用
+func Init(dir string) error {
+	s, err := New(dir)
+	if err != nil {
+		return err
+	}
+	defaultStore = s
+	return nil
+}
+
+// Default 返回默认 Store，未 Init 时只保存在内存中
+func Default() *Store {
+	return defaultStore
+}
+
+// Get 实现 compose.CheckPointStore。请求没有 thread id 时不保存 CheckPoint
+func (s *Store) Get(_ context.Context, checkPointID string) ([]byte, bool, error) {
+	if checkPointID == "" {
+		return nil, false, nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.read(kindCheckPoint, checkPointID)
+}
+
+// Set 实现 compose.CheckPointStore
+func (s *Store) Set(_ context.Context, checkPointID string, checkPoint []byte) error {
+	if checkPointID == "" {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(kindCheckPoint, checkPointID, checkPoint)
+}
+
+// GetThread 返回一个会话记录
+func (s *Store) GetThread(id string) (*model.Thread, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.thread(id)
+}
+
+// UpdateThread 读出会话记录（不存在时新建）交给 update 修改后写回
+func (s *Store) UpdateThread(id string, update func(t *model.Thread)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok, err := s.thread(id)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if !ok {
+		t = &model.Thread{ID: id, CreatedAt: now}
+	}
+	update(t)
+	t.ID, t.UpdatedAt = id, now
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	return s.write(kindThread, id, data)
+}
+
+// ListThreads 返回全部会话，最近更新的在前
+func (s *Store) ListThreads() ([]*model.Thread, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids, err := s.ids(kindThread)
+	if err != nil {
+		return nil, err
+	}
+	threads := make([]*model.Thread, 0, len(ids))
+	for _, id := range ids {
+		t, ok, err := s.thread(id)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			threads = append(threads, t)
+		}
+	}
+	sort.Slice(threads, func(i, j int) bool {
+		return threads[i].UpdatedAt.After(threads[j].UpdatedAt)
+	})
+	return threads, nil
+}
+
+// DeleteThread 删除会话记录和它的 CheckPoint，返回会话是否存在
+func (s *Store) DeleteThread(id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok, err := s.read(kindThread, id)
+	if err != nil {
+		return false, err
+	}
+	if err = s.remove(kindCheckPoint, id); err != nil {
+		return false, err
+	}
+	return ok, s.remove(kindThread, id)
+}
+
+func (s *Store) thread(id string) (*model.Thread, bool, error) {
+	data, ok, err := s.read(kindThread, id)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	t := &model.Thread{}
+	if err = json.Unmarshal(data, t); err != nil {
+		return nil, false, fmt.Errorf("decode thread %s: %w", id, err)
+	}
+	return t, true, nil
+}
+
+// CheckID 校验 thread id，不合法时返回 ErrInvalidID
+func CheckID(id string) error {
+	if !idPattern.MatchString(id) {
+		return fmt.Errorf("%w: %q", ErrInvalidID, id)
+	}
+	return nil
+}
+
+func (s *Store) path(kind, id string) (string, error) {
+	if err := CheckID(id); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, kind, id), nil
+}
+
+func (s *Store) read(kind, id string) ([]byte, bool, error) {
+	path, err := s.path(kind, id)
+	if err != nil {
+		return nil, false, err
+	}
+	if s.dir == "" {
+		data, ok := s.mem[kind+"/"+id]
+		return data, ok, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	return data, err == nil, err
+}
+
+func (s *Store) write(kind, id string, data []byte) error {
+	path, err := s.path(kind, id)
+	if err != nil {
+		return err
+	}
+	if s.dir == "" {
+		s.mem[kind+"/"+id] = data
+		return nil
+	}
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (s *Store) remove(kind, id string) error {
+	path, err := s.path(kind, id)
+	if err != nil {
+		return err
+	}
+	if s.dir == "" {
+		delete(s.mem, kind+"/"+id)
+		return nil
+	}
+	if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *Store) ids(kind string) ([]string, error) {
+	var ids []string
+	if s.dir == "" {
+		for key := range s.mem {
+			if id, ok := strings.CutPrefix(key, kind+"/"); ok {
+				ids = append(ids, id)
+			}
+		}
+		return ids, nil
+	}
+	entries, err := os.ReadDir(filepath.Join(s.dir, kind))
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if !e.IsDir() && idPattern.MatchString(e.Name()) {
+			ids = append(ids, e.Name())
+		}
+	}
+	return ids, nil
+}
+
+var _ compose.CheckPointStore = (*Store)(nil)
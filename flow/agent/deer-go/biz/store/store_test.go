@@ -0,0 +1,100 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/model"
+)
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	for _, tc := range []struct {
+		name string
+		dir  string
+	}{{"memory", ""}, {"file", dir}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			s, err := New(tc.dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// 并发写不同的会话
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					id := fmt.Sprintf("t%d", i)
+					if err := s.Set(ctx, id, []byte(id)); err != nil {
+						t.Error(err)
+					}
+					if err := s.UpdateThread(id, func(th *model.Thread) { th.Title = id }); err != nil {
+						t.Error(err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if err = s.UpdateThread("t3", func(th *model.Thread) { th.Report = "report" }); err != nil {
+				t.Fatal(err)
+			}
+			threads, err := s.ListThreads()
+			if err != nil || len(threads) != 8 || threads[0].ID != "t3" {
+				t.Fatalf("threads = %v, %v", threads, err)
+			}
+			if th, ok, _ := s.GetThread("t3"); !ok || th.Title != "t3" || th.Report != "report" || !th.UpdatedAt.After(th.CreatedAt) {
+				t.Fatalf("thread = %+v", th)
+			}
+
+			if ok, err := s.DeleteThread("t3"); !ok || err != nil {
+				t.Fatalf("delete = %v, %v", ok, err)
+			}
+			if _, ok, _ := s.Get(ctx, "t3"); ok {
+				t.Fatal("checkpoint not deleted")
+			}
+			if ok, _ := s.DeleteThread("t3"); ok {
+				t.Fatal("deleted twice")
+			}
+
+			if _, _, err = s.GetThread("../x"); !errors.Is(err, ErrInvalidID) {
+				t.Fatalf("err = %v", err)
+			}
+			if _, ok, err := s.Get(ctx, ""); ok || err != nil {
+				t.Fatalf("empty id = %v, %v", ok, err)
+			}
+		})
+	}
+
+	// 重新打开后数据仍在
+	s, err := New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, ok, _ := s.Get(context.Background(), "t5"); !ok || string(data) != "t5" {
+		t.Fatalf("checkpoint = %q, %v", data, ok)
+	}
+	if threads, _ := s.ListThreads(); len(threads) != 7 {
+		t.Fatalf("%d threads", len(threads))
+	}
+}
@@ -41,8 +41,12 @@ type DeerConfig struct {
 		BaseURL      string `yaml:"base_url"`
 	} `yaml:"model"`
 	Setting struct {
-		MaxPlanIterations int `yaml:"max_plan_iterations"`
-		MaxStepNum        int `yaml:"max_step_num"`
+		MaxPlanIterations int      `yaml:"max_plan_iterations"`
+		MaxStepNum        int      `yaml:"max_step_num"`
+		MaxParallelSteps  int      `yaml:"max_parallel_steps"` // 同时执行的计划步骤数上限，不大于 1 时逐个执行
+		Outputs           []string `yaml:"outputs"`            // 控制台模式下报告之后的产出物: podcast, ppt
+		OutputDir         string   `yaml:"output_dir"`         // 产出物的写入目录，默认 output
+		DataDir           string   `yaml:"data_dir"`           // 会话记录和 CheckPoint 的保存目录，默认 output/data
 	} `yaml:"setting"`
 }
 
@@ -84,5 +88,11 @@ func LoadDeerConfig(ctx context.Context) {
 	//		Config: stdioConfig,
 	//	}
 	//}
+	if deerConfig.Setting.OutputDir == "" {
+		deerConfig.Setting.OutputDir = "output"
+	}
+	if deerConfig.Setting.DataDir == "" {
+		deerConfig.Setting.DataDir = filepath.Join("output", "data")
+	}
 	Config = &deerConfig
 }
@@ -23,6 +23,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -37,6 +38,7 @@ import (
 	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/eino"
 	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/infra"
 	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/model"
+	"github.com/cloudwego/eino-examples/flow/agent/deer-go/biz/store"
 	"github.com/cloudwego/eino-examples/flow/agent/deer-go/conf"
 	hertztracing "github.com/hertz-contrib/obs-opentelemetry/tracing"
 )
@@ -61,6 +63,9 @@ func runServer() {
 	ilog.SetGlobalLogLevel(ilog.LevelInfo)
 	ctx := context.Background()
 	conf.LoadDeerConfig(ctx)
+	if err := store.Init(conf.Config.Setting.DataDir); err != nil {
+		panic(err)
+	}
 	infra.InitModel()
 	infra.InitMCP()
 	tracer, cfg, shutdown := infra.InitAPMPlusTracing(ctx, true)
@@ -116,8 +121,11 @@ func runConsole() {
 			MaxPlanIterations: conf.Config.Setting.MaxPlanIterations,
 			AutoAcceptedPlan:  true,
 			MaxStepNum:        conf.Config.Setting.MaxStepNum,
+			MaxParallelSteps:  conf.Config.Setting.MaxParallelSteps,
 			Messages:          userMessage,
 			Goto:              consts.Coordinator,
+			Outputs:           conf.Config.Setting.Outputs,
+			OutputDir:         filepath.Join(conf.Config.Setting.OutputDir, time.Now().Format("20060102-150405")),
 		}
 	}
 	r := eino.Builder[string, string, *model.State](ctx, genFunc)
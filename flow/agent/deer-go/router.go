@@ -27,4 +27,9 @@ import (
 // customizeRegister registers customize routers.
 func customizedRegister(r *server.Hertz) {
 	r.POST("/api/chat/stream", handler.ChatStreamEino)
+
+	r.GET("/api/threads", handler.ListThreads)
+	r.GET("/api/threads/:thread_id", handler.GetThread)
+	r.GET("/api/threads/:thread_id/report", handler.GetThreadReport)
+	r.DELETE("/api/threads/:thread_id", handler.DeleteThread)
 }
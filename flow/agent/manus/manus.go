@@ -30,7 +30,6 @@ import (
 	"github.com/cloudwego/eino-ext/components/model/openai"
 	"github.com/cloudwego/eino-ext/components/tool/browseruse"
 	"github.com/cloudwego/eino-ext/components/tool/commandline"
-	"github.com/cloudwego/eino-ext/components/tool/commandline/sandbox"
 	"github.com/cloudwego/eino-ext/components/tool/duckduckgo/v2"
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components/model"
@@ -53,6 +52,10 @@ var (
 	cozeloopApiToken    string
 	cozeloopWorkspaceID string
 
+	sandboxBackend string
+	sandboxDir     string
+	browserMode    string
+
 	input string
 )
 
@@ -68,6 +71,10 @@ func init() {
 	cozeloopApiToken = os.Getenv("COZELOOP_API_TOKEN")
 	cozeloopWorkspaceID = os.Getenv("COZELOOP_WORKSPACE_ID") // use cozeloop trace, from https://loop.coze.cn/open/docs/cozeloop/go-sdk#4a8c980e
 
+	sandboxBackend = os.Getenv("MANUS_SANDBOX") // docker (default), namespace or dir
+	sandboxDir = os.Getenv("MANUS_SANDBOX_DIR")
+	browserMode = os.Getenv("MANUS_BROWSER") // headed (default), headless or off
+
 	input = "what is eino?"
 }
 
@@ -78,12 +85,16 @@ func main() {
 	sb := newSandbox(ctx)
 	defer sb.Cleanup(ctx)
 	commandlineTools := newCommandLineTools(ctx, sb)
+	tools := commandlineTools
 	browserTool := newBrowserTool(ctx)
-	defer browserTool.Cleanup()
+	if browserTool != nil {
+		defer browserTool.Cleanup()
+		tools = append(tools, browserTool)
+	}
 
 	// init chat model
 	cm := newChatModel(ctx)
-	cm = bindTools(ctx, cm, append(commandlineTools, browserTool))
+	cm = bindTools(ctx, cm, tools)
 
 	// init and register callback handlers for logging and tracing
 	var handlers []callbacks.Handler
@@ -107,7 +118,7 @@ func main() {
 	callbacks.AppendGlobalHandlers(handlers...)
 
 	// compose graph
-	agent := composeAgent(ctx, cm, browserTool, commandlineTools)
+	agent := composeAgent(ctx, cm, browserTool, tools, sb.WorkDir())
 
 	// init langfuse trace
 	ctx = langfuse.SetTrace(ctx, langfuse.WithID(uuid.New().String()))
@@ -166,6 +177,7 @@ func composeAgent(ctx context.Context,
 	cm model.BaseChatModel,
 	browserTool *browseruse.Tool,
 	tools []tool.BaseTool,
+	workDir string,
 ) compose.Runnable[string, string] {
 	err := compose.RegisterSerializableType[state]("my state")
 	if err != nil {
@@ -179,7 +191,7 @@ func composeAgent(ctx context.Context,
 	// register nodes
 	err = g.AddLambdaNode(NodeKeyInputConvert, compose.InvokableLambda(func(ctx context.Context, input string) (output []*schema.Message, err error) {
 		return []*schema.Message{
-			schema.SystemMessage(systemPrompt),
+			schema.SystemMessage(systemPrompt + fmt.Sprintf(workDirPrompt, workDir)),
 			schema.UserMessage(input),
 		}, nil
 	}), compose.WithNodeName(NodeKeyInputConvert))
@@ -205,7 +217,7 @@ func composeAgent(ctx context.Context,
 		log.Fatal(err)
 	}
 
-	toolsNode, err := compose.NewToolNode(ctx, &compose.ToolsNodeConfig{Tools: append(tools, browserTool)})
+	toolsNode, err := compose.NewToolNode(ctx, &compose.ToolsNodeConfig{Tools: tools})
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -304,6 +316,11 @@ func bindTools(ctx context.Context, cm model.ToolCallingChatModel, tools []tool.
 }
 
 func appendNextPrompt(ctx context.Context, browserTool *browseruse.Tool) func(ctx context.Context, toolsNodeOutput []*schema.Message, state *state) ([]*schema.Message, error) {
+	if browserTool == nil {
+		return func(ctx context.Context, toolsNodeOutput []*schema.Message, state *state) ([]*schema.Message, error) {
+			return append(toolsNodeOutput, schema.UserMessage(nextStepPrompt)), nil
+		}
+	}
 	info, err := browserTool.Info(ctx)
 	if err != nil {
 		log.Fatal("get browser tool info fail: ", err)
@@ -395,23 +412,15 @@ func newChatModel(ctx context.Context) model.ToolCallingChatModel {
 	return cm
 }
 
-func newSandbox(ctx context.Context) *sandbox.DockerSandbox {
-	sb, err := sandbox.NewDockerSandbox(ctx, &sandbox.Config{
-		Image:          "python:3.9-slim",
-		HostName:       "sandbox",
-		WorkDir:        "/workspace",
-		MemoryLimit:    512 * 1024 * 1024,
-		CPULimit:       1.0,
-		NetworkEnabled: false,
-		Timeout:        time.Second * 30,
+func newSandbox(ctx context.Context) Sandbox {
+	sb, err := NewSandbox(ctx, &SandboxConfig{
+		Backend: sandboxBackend,
+		Dir:     sandboxDir,
+		Timeout: time.Second * 30,
 	})
 	if err != nil {
 		log.Fatal(err)
 	}
-	err = sb.Create(ctx)
-	if err != nil {
-		log.Fatal(err)
-	}
 	return sb
 }
 
@@ -427,14 +436,27 @@ func newCommandLineTools(ctx context.Context, sb commandline.Operator) []tool.Ba
 	return []tool.BaseTool{et, pt}
 }
 
+// newBrowserTool returns nil when MANUS_BROWSER is off, for machines without
+// Chrome. headless runs Chrome without a display.
 func newBrowserTool(ctx context.Context) *browseruse.Tool {
+	var headless bool
+	switch browserMode {
+	case "", "headed":
+	case "headless":
+		headless = true
+	case "off":
+		return nil
+	default:
+		log.Fatalf("unknown MANUS_BROWSER %q, want headed, headless or off", browserMode)
+	}
+
 	ddgs, err := duckduckgo.NewSearch(ctx, &duckduckgo.Config{Timeout: time.Second * 30})
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	t, err := browseruse.NewBrowserUseTool(ctx, &browseruse.Config{
-		Headless:           false,
+		Headless:           headless,
 		DisableSecurity:    false,
 		ExtraChromiumArgs:  nil,
 		ChromeInstancePath: "",
@@ -0,0 +1,299 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino-ext/components/tool/commandline"
+	"github.com/cloudwego/eino-ext/components/tool/commandline/sandbox"
+)
+
+// Sandbox backends, chosen with MANUS_SANDBOX.
+const (
+	// SandboxDocker runs commands in a python:3.9-slim container. It is the default.
+	SandboxDocker = "docker"
+	// SandboxNamespace runs commands in Linux user, mount, pid and network
+	// namespaces with the host's system dirs mounted read-only. Linux only.
+	SandboxNamespace = "namespace"
+	// SandboxDir runs commands on the host in a plain work dir. File access
+	// is confined to the dir, commands are not; meant for tests.
+	SandboxDir = "dir"
+)
+
+const (
+	sandboxWorkDir = "/workspace"
+	sandboxTimeout = 30 * time.Second
+)
+
+// Sandbox is where the agent's files live and its commands run.
+type Sandbox interface {
+	commandline.Operator
+	// WorkDir is the dir commands start in, as the agent sees it.
+	WorkDir() string
+	Cleanup(ctx context.Context)
+}
+
+type SandboxConfig struct {
+	// Backend is SandboxDocker, SandboxNamespace or SandboxDir, default SandboxDocker.
+	Backend string
+	// Dir is the host dir holding the work dir of the namespace and dir
+	// backends. When empty, a temp dir is created and removed on Cleanup.
+	Dir string
+	// Timeout bounds a single command, default 30s.
+	Timeout time.Duration
+}
+
+func NewSandbox(ctx context.Context, conf *SandboxConfig) (Sandbox, error) {
+	c := *conf
+	if c.Timeout <= 0 {
+		c.Timeout = sandboxTimeout
+	}
+	switch c.Backend {
+	case "", SandboxDocker:
+		return newDockerSandbox(ctx, &c)
+	case SandboxNamespace:
+		return newNamespaceSandbox(ctx, &c)
+	case SandboxDir:
+		return newDirSandbox(&c, "")
+	default:
+		return nil, fmt.Errorf("unknown sandbox backend %q, want %s, %s or %s", c.Backend, SandboxDocker, SandboxNamespace, SandboxDir)
+	}
+}
+
+type dockerSandbox struct {
+	*sandbox.DockerSandbox
+}
+
+func newDockerSandbox(ctx context.Context, conf *SandboxConfig) (*dockerSandbox, error) {
+	sb, err := sandbox.NewDockerSandbox(ctx, &sandbox.Config{
+		Image:          "python:3.9-slim",
+		HostName:       "sandbox",
+		WorkDir:        sandboxWorkDir,
+		MemoryLimit:    512 * 1024 * 1024,
+		CPULimit:       1.0,
+		NetworkEnabled: false,
+		Timeout:        conf.Timeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = sb.Create(ctx); err != nil {
+		return nil, err
+	}
+	return &dockerSandbox{DockerSandbox: sb}, nil
+}
+
+func (d *dockerSandbox) WorkDir() string {
+	return sandboxWorkDir
+}
+
+// dirSandbox keeps files in a host dir. Paths are resolved against workDir,
+// the name the agent uses for the dir, and may not leave it, including
+// through symlinks. newCmd decides how a command is run.
+type dirSandbox struct {
+	dir       string
+	workDir   string
+	timeout   time.Duration
+	removeDir bool
+	newCmd    func(ctx context.Context, command string) *exec.Cmd
+}
+
+// newDirSandbox uses the host dir as work dir when workDir is empty. Commands
+// run with the host shell in the dir.
+func newDirSandbox(conf *SandboxConfig, workDir string) (*dirSandbox, error) {
+	s := &dirSandbox{dir: conf.Dir, timeout: conf.Timeout}
+	if s.dir == "" {
+		dir, err := os.MkdirTemp("", "manus-workspace-")
+		if err != nil {
+			return nil, err
+		}
+		s.dir, s.removeDir = dir, true
+	} else if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return nil, err
+	}
+	dir, err := filepath.Abs(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	if s.dir, err = filepath.EvalSymlinks(dir); err != nil {
+		return nil, err
+	}
+	s.workDir = workDir
+	if s.workDir == "" {
+		s.workDir = s.dir
+	}
+	s.newCmd = s.hostCmd
+	return s, nil
+}
+
+func (s *dirSandbox) WorkDir() string {
+	return s.workDir
+}
+
+func (s *dirSandbox) Cleanup(ctx context.Context) {
+	if s.removeDir {
+		if err := os.RemoveAll(s.dir); err != nil {
+			fmt.Printf("sandbox cleanup: %v\n", err)
+		}
+	}
+}
+
+func (s *dirSandbox) ReadFile(ctx context.Context, path string) (string, error) {
+	root, rel, err := s.open(path)
+	if err != nil {
+		return "", err
+	}
+	defer root.Close()
+	f, err := root.Open(rel)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	b, err := io.ReadAll(f)
+	return string(b), err
+}
+
+// WriteFile creates missing parent dirs.
+func (s *dirSandbox) WriteFile(ctx context.Context, path string, content string) error {
+	root, rel, err := s.open(path)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+	parent := ""
+	for _, name := range strings.Split(filepath.Dir(rel), string(filepath.Separator)) {
+		if name == "." {
+			continue
+		}
+		parent = filepath.Join(parent, name)
+		if err = root.Mkdir(parent, 0o755); err != nil && !errors.Is(err, fs.ErrExist) {
+			return err
+		}
+	}
+	f, err := root.OpenFile(rel, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err = f.WriteString(content); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (s *dirSandbox) IsDirectory(ctx context.Context, path string) (bool, error) {
+	info, err := s.stat(path)
+	if err != nil || info == nil {
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+func (s *dirSandbox) Exists(ctx context.Context, path string) (bool, error) {
+	info, err := s.stat(path)
+	return info != nil, err
+}
+
+// RunCommand returns stdout. Like the docker backend, a non-zero exit status
+// is an error carrying stderr.
+func (s *dirSandbox) RunCommand(ctx context.Context, command string) (string, error) {
+	runCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	cmd := s.newCmd(runCtx, command)
+	cmd.WaitDelay = time.Second
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		return "", fmt.Errorf("command execution timed out after %v", s.timeout)
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return "", fmt.Errorf("command execution failed with exit code %d: %s", exitErr.ExitCode(), stderr.String())
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to run command: %w", err)
+	}
+	return stdout.String(), nil
+}
+
+func (s *dirSandbox) hostCmd(ctx context.Context, command string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	cmd.Dir = s.dir
+	cmd.Env = sandboxEnv(s.dir)
+	setProcessGroup(cmd)
+	return cmd
+}
+
+// sandboxEnv keeps API keys and other secrets of the agent's environment
+// away from commands.
+func sandboxEnv(home string) []string {
+	env := []string{"HOME=" + home, "LANG=C.UTF-8"}
+	if path := os.Getenv("PATH"); path != "" {
+		env = append(env, "PATH="+path)
+	}
+	return env
+}
+
+// open returns the host dir as an os.Root and path relative to it. os.Root
+// refuses to follow symlinks or ".." out of the dir.
+func (s *dirSandbox) open(path string) (*os.Root, string, error) {
+	rel := filepath.Clean(path)
+	if filepath.IsAbs(rel) {
+		var err error
+		if rel, err = filepath.Rel(s.workDir, rel); err != nil {
+			return nil, "", err
+		}
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, "", fmt.Errorf("path %s is outside the sandbox work dir %s", path, s.workDir)
+	}
+	root, err := os.OpenRoot(s.dir)
+	if err != nil {
+		return nil, "", err
+	}
+	return root, rel, nil
+}
+
+// stat returns nil info for missing paths.
+func (s *dirSandbox) stat(path string) (fs.FileInfo, error) {
+	root, rel, err := s.open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer root.Close()
+	info, err := root.Stat(rel)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return info, err
+}
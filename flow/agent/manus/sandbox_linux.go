@@ -0,0 +1,215 @@
+//go:build linux
+
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+)
+
+// sandboxHelperArg makes the manus binary act as the namespace sandbox
+// helper: it builds the sandbox root inside the fresh namespaces, pivots into
+// it and execs the command.
+const sandboxHelperArg = "__manus_sandbox_helper"
+
+// system dirs mounted read-only into the sandbox root.
+var sandboxSystemDirs = []string{"/bin", "/sbin", "/usr", "/lib", "/lib32", "/lib64", "/etc"}
+
+// device nodes bound into the sandbox /dev.
+var sandboxDevices = []string{"null", "zero", "random", "urandom"}
+
+func init() {
+	if len(os.Args) == 5 && os.Args[1] == sandboxHelperArg {
+		err := sandboxHelper(os.Args[2], os.Args[3], os.Args[4])
+		fmt.Fprintf(os.Stderr, "sandbox helper: %v\n", err)
+		os.Exit(125)
+	}
+}
+
+type namespaceSandbox struct {
+	*dirSandbox
+	rootfs string
+}
+
+// newNamespaceSandbox checks that namespaces can be created by running a
+// trivial command, so an unsupported host fails here and not on the agent's
+// first tool call.
+func newNamespaceSandbox(ctx context.Context, conf *SandboxConfig) (*namespaceSandbox, error) {
+	ds, err := newDirSandbox(conf, sandboxWorkDir)
+	if err != nil {
+		return nil, err
+	}
+	rootfs, err := os.MkdirTemp("", "manus-rootfs-")
+	if err != nil {
+		ds.Cleanup(ctx)
+		return nil, err
+	}
+	s := &namespaceSandbox{dirSandbox: ds, rootfs: rootfs}
+	ds.newCmd = s.command
+	if _, err = s.RunCommand(ctx, "true"); err != nil {
+		s.Cleanup(ctx)
+		return nil, fmt.Errorf("namespace sandbox is not available (are unprivileged user namespaces enabled?): %w", err)
+	}
+	return s, nil
+}
+
+func (s *namespaceSandbox) Cleanup(ctx context.Context) {
+	s.dirSandbox.Cleanup(ctx)
+	_ = os.Remove(s.rootfs)
+}
+
+// command re-executes the running binary as the helper in new user, mount,
+// pid, network, uts and ipc namespaces. The helper is pid 1 of its pid
+// namespace, so killing it on timeout kills everything the command started.
+func (s *namespaceSandbox) command(ctx context.Context, command string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "/proc/self/exe", sandboxHelperArg, s.dir, s.rootfs, command)
+	cmd.Env = sandboxEnv(sandboxWorkDir)
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags: syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS | syscall.CLONE_NEWPID |
+			syscall.CLONE_NEWNET | syscall.CLONE_NEWUTS | syscall.CLONE_NEWIPC,
+		UidMappings: []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getuid(), Size: 1}},
+		GidMappings: []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getgid(), Size: 1}},
+		Pdeathsig:   syscall.SIGKILL,
+	}
+	return cmd
+}
+
+// sandboxHelper only returns on error. The new root is a tmpfs holding the
+// read-only system dirs, dir as /workspace, and a private /tmp, /proc and
+// minimal /dev; the network namespace has no interfaces but loopback.
+func sandboxHelper(dir, rootfs, command string) error {
+	if err := syscall.Mount("", "/", "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("make mounts private: %w", err)
+	}
+	if err := syscall.Mount("tmpfs", rootfs, "tmpfs", syscall.MS_NOSUID|syscall.MS_NODEV, "mode=0755"); err != nil {
+		return fmt.Errorf("mount root: %w", err)
+	}
+	for _, p := range sandboxSystemDirs {
+		info, err := os.Lstat(p)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(rootfs, p)
+		if info.Mode()&fs.ModeSymlink != 0 {
+			// merged /usr layouts link /bin and /lib into /usr
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			if err = os.Symlink(link, target); err != nil {
+				return err
+			}
+			continue
+		}
+		if err = bindMount(p, target, true); err != nil {
+			return err
+		}
+	}
+	if err := bindMount(dir, filepath.Join(rootfs, sandboxWorkDir), false); err != nil {
+		return err
+	}
+
+	tmp := filepath.Join(rootfs, "tmp")
+	if err := os.Mkdir(tmp, 0o1777); err != nil {
+		return err
+	}
+	if err := syscall.Mount("tmpfs", tmp, "tmpfs", syscall.MS_NOSUID|syscall.MS_NODEV, "mode=1777"); err != nil {
+		return fmt.Errorf("mount /tmp: %w", err)
+	}
+	proc := filepath.Join(rootfs, "proc")
+	if err := os.Mkdir(proc, 0o555); err != nil {
+		return err
+	}
+	if err := syscall.Mount("proc", proc, "proc", syscall.MS_NOSUID|syscall.MS_NODEV|syscall.MS_NOEXEC, ""); err != nil {
+		return fmt.Errorf("mount /proc: %w", err)
+	}
+	if err := os.Mkdir(filepath.Join(rootfs, "dev"), 0o755); err != nil {
+		return err
+	}
+	for _, d := range sandboxDevices {
+		target := filepath.Join(rootfs, "dev", d)
+		if err := os.WriteFile(target, nil, 0o666); err != nil {
+			return err
+		}
+		if err := syscall.Mount("/dev/"+d, target, "", syscall.MS_BIND, ""); err != nil {
+			return fmt.Errorf("bind /dev/%s: %w", d, err)
+		}
+	}
+
+	old := filepath.Join(rootfs, ".old")
+	if err := os.Mkdir(old, 0o700); err != nil {
+		return err
+	}
+	if err := syscall.PivotRoot(rootfs, old); err != nil {
+		return fmt.Errorf("pivot root: %w", err)
+	}
+	if err := syscall.Chdir("/"); err != nil {
+		return err
+	}
+	if err := syscall.Unmount("/.old", syscall.MNT_DETACH); err != nil {
+		return fmt.Errorf("unmount old root: %w", err)
+	}
+	if err := os.Remove("/.old"); err != nil {
+		return err
+	}
+	if err := syscall.Sethostname([]byte("sandbox")); err != nil {
+		return err
+	}
+	if err := syscall.Chdir(sandboxWorkDir); err != nil {
+		return err
+	}
+	return syscall.Exec("/bin/sh", []string{"sh", "-c", command}, os.Environ())
+}
+
+// bindMount mounts src on target, creating target. A read-only bind must be
+// remounted, keeping the flags the kernel locks for mounts inherited by a
+// user namespace.
+func bindMount(src, target string, readOnly bool) error {
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		return err
+	}
+	if err := syscall.Mount(src, target, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+		return fmt.Errorf("bind %s: %w", src, err)
+	}
+	if !readOnly {
+		return nil
+	}
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(target, &st); err != nil {
+		return err
+	}
+	const locked = syscall.MS_NOSUID | syscall.MS_NODEV | syscall.MS_NOEXEC | syscall.MS_NOATIME | syscall.MS_NODIRATIME
+	flags := uintptr(syscall.MS_REMOUNT|syscall.MS_BIND|syscall.MS_RDONLY) | uintptr(st.Flags)&locked
+	if st.Flags&0x1000 != 0 { // ST_RELATIME
+		flags |= syscall.MS_RELATIME
+	}
+	if err := syscall.Mount("", target, "", flags, ""); err != nil {
+		return fmt.Errorf("remount %s read-only: %w", src, err)
+	}
+	return nil
+}
@@ -1,5 +1,7 @@
+//go:build !linux
+
 /*
- * Copyright 2025 CloudWeGo Authors
+ * Copyright 2026 CloudWeGo Authors
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -14,11 +16,14 @@
  * limitations under the License.
  */
 
-package params
+package main
 
-const (
-	FilePathSessionKey            = "file_path_session_key"
-	UserAllPreviewFilesSessionKey = "user_all_preview_files_session_key"
-	WorkDirSessionKey             = "work_dir_session_key"
-	TaskIDKey                     = "task_id"
+import (
+	"context"
+	"fmt"
+	"runtime"
 )
+
+func newNamespaceSandbox(ctx context.Context, conf *SandboxConfig) (Sandbox, error) {
+	return nil, fmt.Errorf("namespace sandbox is not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testSandboxFiles(t *testing.T, sb Sandbox) {
+	t.Helper()
+	ctx := context.Background()
+	wd := sb.WorkDir()
+
+	if err := sb.WriteFile(ctx, "data/in.txt", "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := sb.ReadFile(ctx, filepath.Join(wd, "data", "in.txt")); err != nil || got != "hello" {
+		t.Fatalf("ReadFile = %q, %v", got, err)
+	}
+	if ok, err := sb.IsDirectory(ctx, filepath.Join(wd, "data")); err != nil || !ok {
+		t.Fatalf("IsDirectory = %v, %v", ok, err)
+	}
+	if ok, err := sb.Exists(ctx, filepath.Join(wd, "missing")); err != nil || ok {
+		t.Fatalf("Exists(missing) = %v, %v", ok, err)
+	}
+	for _, p := range []string{"/etc/passwd", "../outside", filepath.Join(wd, "..", "x")} {
+		if _, err := sb.ReadFile(ctx, p); err == nil {
+			t.Errorf("ReadFile(%s) succeeded", p)
+		}
+		if err := sb.WriteFile(ctx, p, "x"); err == nil {
+			t.Errorf("WriteFile(%s) succeeded", p)
+		}
+	}
+
+	out, err := sb.RunCommand(ctx, "cat data/in.txt; echo ' world' >> data/in.txt; echo warn >&2")
+	if err != nil || out != "hello" {
+		t.Fatalf("RunCommand = %q, %v", out, err)
+	}
+	if got, _ := sb.ReadFile(ctx, "data/in.txt"); got != "hello world\n" {
+		t.Fatalf("file written by command = %q", got)
+	}
+	if _, err = sb.RunCommand(ctx, "echo boom >&2; exit 3"); err == nil || !strings.Contains(err.Error(), "exit code 3: boom") {
+		t.Fatalf("failed command error = %v", err)
+	}
+	if out, _ = sb.RunCommand(ctx, "echo $OPENAI_API_KEY"); strings.TrimSpace(out) != "" {
+		t.Fatalf("secret leaked to command: %q", out)
+	}
+}
+
+func TestDirSandbox(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "secret")
+	dir := t.TempDir()
+	sb, err := NewSandbox(context.Background(), &SandboxConfig{Backend: SandboxDir, Dir: dir, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sb.Cleanup(context.Background())
+	testSandboxFiles(t, sb)
+
+	// symlinks may not lead out of the work dir
+	if err = os.Symlink("/etc", filepath.Join(dir, "etc")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = sb.ReadFile(context.Background(), "etc/hostname"); err == nil {
+		t.Error("read through symlink succeeded")
+	}
+
+	start := time.Now()
+	if _, err = sb.RunCommand(context.Background(), "sleep 10"); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("timeout error = %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatal("command was not killed on timeout")
+	}
+
+	// background children are killed with the shell
+	if _, err = sb.RunCommand(context.Background(), "sleep 10 & echo $! > sleep.pid; wait"); err == nil {
+		t.Fatal("background command did not time out")
+	}
+	if runtime.GOOS == "linux" {
+		pid, _ := sb.ReadFile(context.Background(), "sleep.pid")
+		alive := func() bool {
+			stat, err := os.ReadFile("/proc/" + strings.TrimSpace(pid) + "/stat")
+			return err == nil && !strings.Contains(string(stat), ") Z ")
+		}
+		for deadline := time.Now().Add(2 * time.Second); alive() && time.Now().Before(deadline); {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if alive() {
+			t.Fatal("background child outlived the timeout")
+		}
+	}
+
+	if _, err = NewSandbox(context.Background(), &SandboxConfig{Backend: "vm"}); err == nil {
+		t.Fatal("unknown backend accepted")
+	}
+}
+
+func TestNamespaceSandbox(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("namespaces are Linux only")
+	}
+	t.Setenv("OPENAI_API_KEY", "secret")
+	ctx := context.Background()
+	sb, err := NewSandbox(ctx, &SandboxConfig{Backend: SandboxNamespace, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Skipf("namespace sandbox unavailable: %v", err)
+	}
+	defer sb.Cleanup(ctx)
+	if sb.WorkDir() != "/workspace" {
+		t.Fatalf("WorkDir = %s", sb.WorkDir())
+	}
+	testSandboxFiles(t, sb)
+
+	out, err := sb.RunCommand(ctx, "pwd; hostname; echo $$; ls /")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Fields(out)
+	if len(lines) < 3 || lines[0] != "/workspace" || lines[1] != "sandbox" || lines[2] != "1" {
+		t.Fatalf("sandbox environment = %q", out)
+	}
+	for _, name := range lines[3:] {
+		if name == "root" || name == "home" {
+			t.Errorf("host dir /%s visible in sandbox", name)
+		}
+	}
+	if _, err = sb.RunCommand(ctx, "touch /usr/x"); err == nil {
+		t.Error("system dirs are writable")
+	}
+	if out, _ = sb.RunCommand(ctx, "cat /proc/net/dev | grep -v lo: | tail -n +3"); strings.TrimSpace(out) != "" {
+		t.Errorf("network interfaces visible: %q", out)
+	}
+}
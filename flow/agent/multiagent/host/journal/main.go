@@ -19,6 +19,7 @@ package main
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"io"
 	"os"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/cloudwego/eino/schema"
 
 	"github.com/cloudwego/eino-examples/devops/visualize"
+	"github.com/cloudwego/eino-examples/flow/agent/multiagent/host/supervisor"
 )
 
 func main() {
@@ -58,20 +60,35 @@ func main() {
 		panic(err)
 	}
 
-	hostMA, err := host.NewMultiAgent(ctx, &host.MultiAgentConfig{
+	// the supervisor lets the host hand off more than once per request, and falls back to
+	// answer_with_journal when the host loops between specialists or hands off too often
+	sup, err := supervisor.New(ctx, &supervisor.Config{
 		Host: *h,
 		Specialists: []*host.Specialist{
 			writer,
 			reader,
 			answerer,
 		},
+		DefaultSpecialist: answerer.Name,
 	})
 	if err != nil {
 		panic(err)
 	}
 
-	// export the graph via API and render mermaid (non-critical path)
+	// export the graph via API and render mermaid (non-critical path); the supervisor
+	// has no graph of its own, so render the host multi-agent over the same specialists
 	{
+		hostMA, err := host.NewMultiAgent(ctx, &host.MultiAgentConfig{
+			Host: *h,
+			Specialists: []*host.Specialist{
+				writer,
+				reader,
+				answerer,
+			},
+		})
+		if err != nil {
+			panic(err)
+		}
 		anyG, opts := hostMA.ExportGraph()
 		gen := visualize.NewMermaidGenerator("flow/agent/multiagent/host/journal")
 		g := compose.NewGraph[[]*schema.Message, *schema.Message]()
@@ -98,6 +115,8 @@ func main() {
 		}
 
 		if message == "exit" {
+			metrics, _ := json.MarshalIndent(sup.Metrics(), "", "  ")
+			println(string(metrics))
 			return
 		}
 
@@ -106,7 +125,7 @@ func main() {
 			Content: message,
 		}
 
-		out, err := hostMA.Stream(ctx, []*schema.Message{msg}, host.WithAgentCallbacks(cb))
+		out, err := sup.Stream(ctx, []*schema.Message{msg}, supervisor.WithAgentCallbacks(cb), supervisor.WithDecisionHandler(logDecision))
 		if err != nil {
 			panic(err)
 		}
@@ -134,3 +153,10 @@ func (l *logCallback) OnHandOff(ctx context.Context, info *host.HandOffInfo) con
 	println("\nHandOff to", info.ToAgentName, "with argument", info.Argument)
 	return ctx
 }
+
+// logDecision prints hand-offs the supervisor stopped.
+func logDecision(_ context.Context, d supervisor.Decision) {
+	if d.Guard != "" {
+		println("\nHandOff stopped by", d.Guard, "fallback to", d.Specialist)
+	}
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package supervisor
+
+import (
+	"sync"
+	"time"
+)
+
+// Metrics are the hand-off statistics of a Supervisor.
+type Metrics struct {
+	Requests    int                 `json:"requests"`
+	Guards      map[string]int      `json:"guards"` // how often each guard tripped
+	Specialists []SpecialistMetrics `json:"specialists"`
+}
+
+// SpecialistMetrics are the statistics of one specialist.
+type SpecialistMetrics struct {
+	Name string `json:"name"`
+
+	// Selections counts the hand-offs the host chose, SelectionRate is
+	// Selections per request.
+	Selections    int     `json:"selections"`
+	SelectionRate float64 `json:"selection_rate"`
+	// Fallbacks counts the runs as the default specialist.
+	Fallbacks int `json:"fallbacks"`
+
+	Successes   int     `json:"successes"`
+	Failures    int     `json:"failures"`
+	SuccessRate float64 `json:"success_rate"`
+
+	AvgLatency time.Duration `json:"avg_latency"`
+	MaxLatency time.Duration `json:"max_latency"`
+}
+
+type metrics struct {
+	mu       sync.Mutex
+	requests int
+	guards   map[string]int
+	names    []string
+	byName   map[string]*specialistCounters
+}
+
+type specialistCounters struct {
+	selections, fallbacks, successes, failures int
+	totalLatency, maxLatency                   time.Duration
+}
+
+func newMetrics() *metrics {
+	return &metrics{guards: map[string]int{}, byName: map[string]*specialistCounters{}}
+}
+
+func (m *metrics) add(name string) {
+	m.names = append(m.names, name)
+	m.byName[name] = &specialistCounters{}
+}
+
+func (m *metrics) request() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests++
+}
+
+func (m *metrics) guard(g string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.guards[g]++
+}
+
+func (m *metrics) handOff(name string, fallback bool, latency time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := m.byName[name]
+	if fallback {
+		c.fallbacks++
+	} else {
+		c.selections++
+	}
+	if err != nil {
+		c.failures++
+	} else {
+		c.successes++
+	}
+	c.totalLatency += latency
+	c.maxLatency = max(c.maxLatency, latency)
+}
+
+func (m *metrics) snapshot() Metrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := Metrics{Requests: m.requests, Guards: make(map[string]int, len(m.guards))}
+	for g, n := range m.guards {
+		out.Guards[g] = n
+	}
+	for _, name := range m.names {
+		c := m.byName[name]
+		sm := SpecialistMetrics{
+			Name:       name,
+			Selections: c.selections,
+			Fallbacks:  c.fallbacks,
+			Successes:  c.successes,
+			Failures:   c.failures,
+			MaxLatency: c.maxLatency,
+		}
+		if m.requests > 0 {
+			sm.SelectionRate = float64(c.selections) / float64(m.requests)
+		}
+		if runs := c.successes + c.failures; runs > 0 {
+			sm.SuccessRate = float64(c.successes) / float64(runs)
+			sm.AvgLatency = c.totalLatency / time.Duration(runs)
+		}
+		out.Specialists = append(out.Specialists, sm)
+	}
+	return out
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package supervisor
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudwego/eino/flow/agent"
+	"github.com/cloudwego/eino/flow/agent/multiagent/host"
+)
+
+// Guards that stop the hand-off loop of a request.
+const (
+	GuardMaxHandOffs  = "max_hand_offs"
+	GuardMaxDepth     = "max_depth"
+	GuardPingPong     = "ping_pong"
+	GuardRepeatedCall = "repeated_call"
+)
+
+// Decision is one hand-off decision of the host.
+type Decision struct {
+	Round      int    // host round of the request, starting at 1
+	Specialist string // the specialist that ran; the default specialist if Fallback
+	Argument   string // raw tool call arguments
+	Reason     string // the reason the host gave for the hand-off
+	Thought    string // text the host wrote along with the hand-off
+	Time       time.Time
+
+	// Guard is the guard that stopped the hand-off the host asked for.
+	Guard string
+	// Fallback reports that the default specialist ran because of Guard.
+	Fallback bool
+
+	Latency time.Duration
+	Error   string
+}
+
+// DecisionHandler receives every hand-off decision of a request.
+type DecisionHandler func(ctx context.Context, d Decision)
+
+type options struct {
+	decisionHandlers []DecisionHandler
+	callbacks        []host.MultiAgentCallback
+}
+
+// WithDecisionHandler registers a handler for the hand-off decisions.
+func WithDecisionHandler(h DecisionHandler) agent.AgentOption {
+	return agent.WrapImplSpecificOptFn(func(o *options) {
+		o.decisionHandlers = append(o.decisionHandlers, h)
+	})
+}
+
+// WithAgentCallbacks registers host multi-agent callbacks, which are called
+// before each specialist runs.
+func WithAgentCallbacks(cbs ...host.MultiAgentCallback) agent.AgentOption {
+	return agent.WrapImplSpecificOptFn(func(o *options) {
+		o.callbacks = append(o.callbacks, cbs...)
+	})
+}
@@ -0,0 +1,332 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package supervisor runs host multi-agent specialists under a supervising
+// loop.
+//
+// The host multi-agent in eino routes a request to specialists once. The
+// supervisor instead gives the specialists' answers back to the host, which
+// may hand off again or answer the user. Every hand-off decision is recorded
+// together with the host's reasoning, and guards stop the loop when the host
+// ping-pongs between two specialists, repeats an identical hand-off, or
+// exceeds the hand-off depth or count of a request. When a guard trips, the
+// designated default specialist answers instead. Per-specialist metrics are
+// available from Supervisor.Metrics.
+package supervisor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/flow/agent"
+	"github.com/cloudwego/eino/flow/agent/multiagent/host"
+	"github.com/cloudwego/eino/schema"
+)
+
+const defaultHostPrompt = "decide which tool is best for the task and call only the best tool. " +
+	"When the tools have answered, reply to the user with their answers, or call another tool if the task is not done yet."
+
+// Config configures a Supervisor.
+type Config struct {
+	Host        host.Host
+	Specialists []*host.Specialist
+
+	// DefaultSpecialist names the specialist that answers when a guard trips.
+	// Without it, a tripped guard fails the request with ErrLimitExceeded.
+	DefaultSpecialist string
+
+	// MaxHandOffs is the maximum number of hand-offs per request. Default 6.
+	MaxHandOffs int
+	// MaxDepth is the maximum number of host rounds that hand off per
+	// request. Default 3.
+	MaxDepth int
+	// MaxPingPong is the longest run of hand-offs alternating between the
+	// same two specialists, e.g. A B A B is a run of 4. Default 4.
+	MaxPingPong int
+	// MaxRepeats is how many times the same specialist may be called with
+	// the same argument. Default 1.
+	MaxRepeats int
+}
+
+// ErrLimitExceeded is returned when a guard trips and no default specialist
+// is configured.
+var ErrLimitExceeded = errors.New("hand-off limit exceeded")
+
+// Supervisor is a host multi-agent with hand-off analytics and loop
+// protection.
+type Supervisor struct {
+	conf        Config
+	host        model.BaseChatModel
+	hostPrompt  string
+	specialists map[string]*host.Specialist
+	metrics     *metrics
+}
+
+// New creates a Supervisor.
+func New(_ context.Context, conf *Config) (*Supervisor, error) {
+	if conf == nil || len(conf.Specialists) == 0 {
+		return nil, errors.New("supervisor needs at least one specialist")
+	}
+	c := *conf
+	if c.MaxHandOffs <= 0 {
+		c.MaxHandOffs = 6
+	}
+	if c.MaxDepth <= 0 {
+		c.MaxDepth = 3
+	}
+	if c.MaxPingPong <= 0 {
+		c.MaxPingPong = 4
+	}
+	if c.MaxRepeats <= 0 {
+		c.MaxRepeats = 1
+	}
+
+	s := &Supervisor{
+		conf:        c,
+		hostPrompt:  c.Host.SystemPrompt,
+		specialists: make(map[string]*host.Specialist, len(c.Specialists)),
+		metrics:     newMetrics(),
+	}
+	if s.hostPrompt == "" {
+		s.hostPrompt = defaultHostPrompt
+	}
+
+	tools := make([]*schema.ToolInfo, 0, len(c.Specialists))
+	for _, sp := range c.Specialists {
+		if sp.Name == "" || sp.IntendedUse == "" {
+			return nil, errors.New("specialist needs a name and an intended use")
+		}
+		if sp.ChatModel == nil && sp.Invokable == nil && sp.Streamable == nil {
+			return nil, fmt.Errorf("specialist %s has no chat model or Invokable or Streamable", sp.Name)
+		}
+		if _, ok := s.specialists[sp.Name]; ok {
+			return nil, fmt.Errorf("duplicate specialist %s", sp.Name)
+		}
+		s.specialists[sp.Name] = sp
+		s.metrics.add(sp.Name)
+		tools = append(tools, &schema.ToolInfo{
+			Name: sp.Name,
+			Desc: sp.IntendedUse,
+			ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+				"reason": {Type: schema.String, Desc: "the reason to call this tool"},
+			}),
+		})
+	}
+	if c.DefaultSpecialist != "" && s.specialists[c.DefaultSpecialist] == nil {
+		return nil, fmt.Errorf("default specialist %s is not a specialist", c.DefaultSpecialist)
+	}
+
+	hostModel, err := agent.ChatModelWithTools(c.Host.ChatModel, c.Host.ToolCallingModel, tools)
+	if err != nil {
+		return nil, err
+	}
+	s.host = hostModel
+	return s, nil
+}
+
+// Generate answers input, handing off to specialists as the host decides.
+func (s *Supervisor) Generate(ctx context.Context, input []*schema.Message, opts ...agent.AgentOption) (*schema.Message, error) {
+	o := agent.GetImplSpecificOptions(&options{}, opts...)
+	r := &request{s: s, opts: o, input: input, seen: map[string]int{}}
+	s.metrics.request()
+	return r.run(ctx)
+}
+
+// Stream is Generate with the answer delivered as a stream. The hand-off loop
+// needs every specialist's complete answer, so only the final answer streams.
+func (s *Supervisor) Stream(ctx context.Context, input []*schema.Message, opts ...agent.AgentOption) (*schema.StreamReader[*schema.Message], error) {
+	msg, err := s.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{msg}), nil
+}
+
+// Metrics returns the per-specialist metrics collected so far.
+func (s *Supervisor) Metrics() Metrics {
+	return s.metrics.snapshot()
+}
+
+// request is the state of one Generate call.
+type request struct {
+	s        *Supervisor
+	opts     *options
+	input    []*schema.Message
+	history  []*schema.Message // host decisions and specialist answers
+	handOffs []string          // specialists handed off to, in order
+	seen     map[string]int    // identical hand-offs, keyed by name and argument
+}
+
+func (r *request) run(ctx context.Context) (*schema.Message, error) {
+	for round := 1; ; round++ {
+		msgs := append([]*schema.Message{schema.SystemMessage(r.s.hostPrompt)}, r.input...)
+		out, err := r.s.host.Generate(ctx, append(msgs, r.history...))
+		if err != nil {
+			return nil, err
+		}
+		if len(out.ToolCalls) == 0 {
+			return out, nil
+		}
+
+		r.history = append(r.history, out)
+		for _, tc := range out.ToolCalls {
+			d := Decision{
+				Round:      round,
+				Specialist: tc.Function.Name,
+				Argument:   tc.Function.Arguments,
+				Reason:     reasonOf(tc.Function.Arguments),
+				Thought:    out.Content,
+				Time:       time.Now(),
+			}
+			if d.Guard = r.check(round, tc); d.Guard != "" {
+				r.s.metrics.guard(d.Guard)
+				return r.fallback(ctx, d)
+			}
+
+			answer, err := r.handOff(ctx, &d)
+			r.record(ctx, d)
+			content := answer
+			if err != nil {
+				content = "error: " + err.Error()
+			}
+			r.history = append(r.history, schema.ToolMessage(content, tc.ID, schema.WithToolName(tc.Function.Name)))
+		}
+	}
+}
+
+// check returns the guard that forbids the hand-off, if any, and otherwise
+// counts it.
+func (r *request) check(round int, tc schema.ToolCall) string {
+	name := tc.Function.Name
+	key := name + "\x00" + canonicalJSON(tc.Function.Arguments)
+	switch {
+	case round > r.s.conf.MaxDepth:
+		return GuardMaxDepth
+	case len(r.handOffs) >= r.s.conf.MaxHandOffs:
+		return GuardMaxHandOffs
+	case r.s.specialists[name] == nil:
+		return "" // answered with an error so the host can correct itself
+	case r.seen[key] >= r.s.conf.MaxRepeats:
+		return GuardRepeatedCall
+	case alternatingRun(append(r.handOffs, name)) > r.s.conf.MaxPingPong:
+		return GuardPingPong
+	}
+	r.seen[key]++
+	r.handOffs = append(r.handOffs, name)
+	return ""
+}
+
+// handOff runs the specialist of d and fills in the outcome.
+func (r *request) handOff(ctx context.Context, d *Decision) (string, error) {
+	sp := r.s.specialists[d.Specialist]
+	if sp == nil {
+		d.Error = fmt.Sprintf("unknown specialist %s", d.Specialist)
+		return "", errors.New(d.Error)
+	}
+
+	for _, cb := range r.opts.callbacks {
+		ctx = cb.OnHandOff(ctx, &host.HandOffInfo{ToAgentName: d.Specialist, Argument: d.Argument})
+	}
+
+	start := time.Now()
+	msg, err := invoke(ctx, sp, r.input)
+	d.Latency = time.Since(start)
+	r.s.metrics.handOff(d.Specialist, d.Fallback, d.Latency, err)
+	if err != nil {
+		d.Error = err.Error()
+		return "", err
+	}
+	return msg.Content, nil
+}
+
+// fallback lets the default specialist answer after a guard tripped.
+func (r *request) fallback(ctx context.Context, d Decision) (*schema.Message, error) {
+	if r.s.conf.DefaultSpecialist == "" {
+		r.record(ctx, d)
+		return nil, fmt.Errorf("%w: %s", ErrLimitExceeded, d.Guard)
+	}
+
+	d.Specialist, d.Fallback = r.s.conf.DefaultSpecialist, true
+	answer, err := r.handOff(ctx, &d)
+	r.record(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	return schema.AssistantMessage(answer, nil), nil
+}
+
+func (r *request) record(ctx context.Context, d Decision) {
+	for _, h := range r.opts.decisionHandlers {
+		h(ctx, d)
+	}
+}
+
+// invoke runs a specialist on the request's input.
+func invoke(ctx context.Context, sp *host.Specialist, input []*schema.Message) (*schema.Message, error) {
+	switch {
+	case sp.Invokable != nil:
+		return sp.Invokable(ctx, input)
+	case sp.Streamable != nil:
+		sr, err := sp.Streamable(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		return schema.ConcatMessageStream(sr)
+	}
+	if sp.SystemPrompt != "" {
+		input = append([]*schema.Message{schema.SystemMessage(sp.SystemPrompt)}, input...)
+	}
+	return sp.ChatModel.Generate(ctx, input)
+}
+
+// alternatingRun returns the length of the run of hand-offs at the end of
+// handOffs that alternates between two specialists.
+func alternatingRun(handOffs []string) int {
+	n := len(handOffs)
+	if n < 2 || handOffs[n-1] == handOffs[n-2] {
+		return min(n, 1)
+	}
+	run := 2
+	for i := n - 3; i >= 0 && handOffs[i] == handOffs[i+2]; i-- {
+		run++
+	}
+	return run
+}
+
+func reasonOf(arguments string) string {
+	var args struct {
+		Reason string `json:"reason"`
+	}
+	if json.Unmarshal([]byte(arguments), &args) == nil && args.Reason != "" {
+		return args.Reason
+	}
+	return arguments
+}
+
+// canonicalJSON normalizes arguments so that formatting differences do not
+// hide a repeated call.
+func canonicalJSON(s string) string {
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return s
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
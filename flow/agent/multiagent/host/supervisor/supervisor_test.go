@@ -0,0 +1,154 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/flow/agent"
+	"github.com/cloudwego/eino/flow/agent/multiagent/host"
+	"github.com/cloudwego/eino/schema"
+)
+
+// scriptedHost hands off to the specialists in script, one per round, then
+// answers.
+type scriptedHost struct {
+	script []string
+	calls  int
+}
+
+func (h *scriptedHost) Generate(_ context.Context, in []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	h.calls++
+	if h.calls > len(h.script) {
+		return schema.AssistantMessage("done: "+in[len(in)-1].Content, nil), nil
+	}
+	name := h.script[h.calls-1]
+	return schema.AssistantMessage("thinking", []schema.ToolCall{{
+		ID:       fmt.Sprint(h.calls),
+		Function: schema.FunctionCall{Name: name, Arguments: `{"reason": "need ` + name + `"}`},
+	}}), nil
+}
+
+func (h *scriptedHost) Stream(context.Context, []*schema.Message, ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, errors.New("not implemented")
+}
+
+func (h *scriptedHost) WithTools([]*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	return h, nil
+}
+
+func specialist(name string, err error) *host.Specialist {
+	return &host.Specialist{
+		AgentMeta: host.AgentMeta{Name: name, IntendedUse: "does " + name},
+		Invokable: func(context.Context, []*schema.Message, ...agent.AgentOption) (*schema.Message, error) {
+			if err != nil {
+				return nil, err
+			}
+			return schema.AssistantMessage(name+" answer", nil), nil
+		},
+	}
+}
+
+func newSupervisor(t *testing.T, script []string, conf Config) *Supervisor {
+	t.Helper()
+	conf.Host = host.Host{ToolCallingModel: &scriptedHost{script: script}}
+	conf.Specialists = []*host.Specialist{specialist("a", nil), specialist("b", nil), specialist("c", errors.New("boom"))}
+	s, err := New(context.Background(), &conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func run(t *testing.T, s *Supervisor) (*schema.Message, []Decision, error) {
+	t.Helper()
+	var decisions []Decision
+	out, err := s.Generate(context.Background(), []*schema.Message{schema.UserMessage("hi")},
+		WithDecisionHandler(func(_ context.Context, d Decision) { decisions = append(decisions, d) }))
+	return out, decisions, err
+}
+
+func TestHandOffLoop(t *testing.T) {
+	s := newSupervisor(t, []string{"a", "c", "b"}, Config{})
+	out, decisions, err := run(t, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The host sees every answer, including the failure, and answers itself.
+	if out.Content != "done: b answer" || len(decisions) != 3 {
+		t.Fatalf("out = %q, %d decisions", out.Content, len(decisions))
+	}
+	if d := decisions[1]; d.Specialist != "c" || d.Reason != "need c" || d.Thought != "thinking" || d.Error != "boom" || d.Round != 2 {
+		t.Fatalf("decision = %+v", d)
+	}
+
+	m := s.Metrics()
+	if m.Requests != 1 || len(m.Specialists) != 3 {
+		t.Fatalf("metrics = %+v", m)
+	}
+	if c := m.Specialists[2]; c.Selections != 1 || c.Failures != 1 || c.SuccessRate != 0 || c.SelectionRate != 1 {
+		t.Fatalf("metrics of c = %+v", c)
+	}
+}
+
+func TestGuards(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		script []string
+		conf   Config
+		guard  string
+		ran    []string
+	}{
+		{"ping-pong", []string{"a", "b", "a", "b", "a"}, Config{MaxDepth: 10, MaxPingPong: 4, MaxRepeats: 5}, GuardPingPong, []string{"a", "b", "a", "b"}},
+		{"repeated call", []string{"a", "b", "b"}, Config{}, GuardRepeatedCall, []string{"a", "b"}},
+		{"depth", []string{"a", "b", "c", "a"}, Config{MaxDepth: 2, MaxRepeats: 5}, GuardMaxDepth, []string{"a", "b"}},
+		{"count", []string{"a", "b", "c", "a"}, Config{MaxDepth: 10, MaxHandOffs: 3, MaxRepeats: 5}, GuardMaxHandOffs, []string{"a", "b", "c"}},
+		{"unknown specialist", []string{"x", "x", "x", "x"}, Config{MaxDepth: 3}, GuardMaxDepth, []string{"x", "x", "x"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// With a default specialist, it answers instead.
+			tc.conf.DefaultSpecialist = "a"
+			s := newSupervisor(t, tc.script, tc.conf)
+			out, decisions, err := run(t, s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ran []string
+			for _, d := range decisions[:len(decisions)-1] {
+				ran = append(ran, d.Specialist)
+			}
+			last := decisions[len(decisions)-1]
+			if !reflect.DeepEqual(ran, tc.ran) || last.Guard != tc.guard || !last.Fallback || out.Content != "a answer" {
+				t.Fatalf("ran %q, last decision %+v, out %q", ran, last, out.Content)
+			}
+			if m := s.Metrics(); m.Guards[tc.guard] != 1 || m.Specialists[0].Fallbacks != 1 {
+				t.Fatalf("metrics = %+v", m)
+			}
+
+			// Without one, the request fails.
+			tc.conf.DefaultSpecialist = ""
+			if _, _, err = run(t, newSupervisor(t, tc.script, tc.conf)); !errors.Is(err, ErrLimitExceeded) {
+				t.Fatalf("err = %v", err)
+			}
+		})
+	}
+}
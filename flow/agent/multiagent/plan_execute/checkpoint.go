@@ -0,0 +1,204 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/flow/agent"
+	"github.com/cloudwego/eino/schema"
+)
+
+// ProgressHandler 在计划或步骤状态变化时被调用，plan 是当时计划的拷贝.
+type ProgressHandler func(ctx context.Context, plan *Plan)
+
+type options struct {
+	runID    string
+	progress ProgressHandler
+}
+
+// WithRunID 指定本次运行的 ID. 配置了 CheckPointStore 时，计划和上下文在每次状态变化后以该 ID 保存，
+// 以相同 ID 再次调用会从未完成的步骤继续执行.
+func WithRunID(id string) agent.AgentOption {
+	return agent.WrapImplSpecificOptFn(func(o *options) {
+		o.runID = id
+	})
+}
+
+// WithProgress 注册进度回调.
+func WithProgress(h ProgressHandler) agent.AgentOption {
+	return agent.WrapImplSpecificOptFn(func(o *options) {
+		o.progress = h
+	})
+}
+
+// record 一次运行保存到 CheckPointStore 中的快照.
+type record struct {
+	Plan     *Plan             `json:"plan,omitempty"`
+	Messages []*schema.Message `json:"messages"`
+	Finished bool              `json:"finished"`
+}
+
+// run 一次运行的持久化与进度上报，通过 ctx 传给各节点.
+type run struct {
+	id       string
+	store    compose.CheckPointStore
+	progress ProgressHandler
+	resumed  *record // 从 store 中恢复的快照，没有则为 nil
+	last     *record // 最近一次保存的快照
+}
+
+type runKey struct{}
+
+// newRun 读取 opts，并在 runID 对应的快照未完成时恢复它. 快照的输入与 input 不同时返回错误，
+// 避免以新的问题继续旧的运行.
+func newRun(ctx context.Context, store compose.CheckPointStore, input []*schema.Message, opts ...agent.AgentOption) (*run, error) {
+	o := agent.GetImplSpecificOptions(&options{}, opts...)
+	r := &run{id: o.runID, progress: o.progress}
+	if store == nil || o.runID == "" {
+		return r, nil
+	}
+	r.store = store
+
+	data, ok, err := store.Get(ctx, checkPointKey(o.runID))
+	if err != nil {
+		return nil, fmt.Errorf("load plan checkpoint %q: %w", o.runID, err)
+	}
+	if !ok {
+		return r, nil
+	}
+	rec := &record{}
+	if err = json.Unmarshal(data, rec); err != nil {
+		return nil, fmt.Errorf("decode plan checkpoint %q: %w", o.runID, err)
+	}
+	if rec.Finished {
+		return r, nil
+	}
+	if !sameInput(rec.Messages, input) {
+		return nil, fmt.Errorf("plan checkpoint %q is an unfinished run with a different input, use another run ID", o.runID)
+	}
+	// 崩溃时执行到一半的步骤需要重新执行
+	if rec.Plan != nil {
+		for _, s := range rec.Plan.Steps {
+			if s.Status == StepRunning {
+				s.Status = StepPending
+			}
+		}
+	}
+	r.resumed = rec
+	return r, nil
+}
+
+// sameInput 判断保存的上下文是否以 input 开头. start 节点最先把输入写入上下文.
+func sameInput(saved, input []*schema.Message) bool {
+	if len(saved) < len(input) {
+		return false
+	}
+	a, err := json.Marshal(saved[:len(input)])
+	if err != nil {
+		return false
+	}
+	b, err := json.Marshal(input)
+	return err == nil && string(a) == string(b)
+}
+
+func checkPointKey(runID string) string {
+	return "plan_execute:" + runID
+}
+
+func withRun(ctx context.Context, r *run) context.Context {
+	return context.WithValue(ctx, runKey{}, r)
+}
+
+func getRun(ctx context.Context) *run {
+	if r, ok := ctx.Value(runKey{}).(*run); ok {
+		return r
+	}
+	return &run{}
+}
+
+// save 保存快照. plan 变化时同时上报进度.
+func (r *run) save(ctx context.Context, s *state, planChanged bool) error {
+	if planChanged && r.progress != nil && s.plan != nil {
+		r.progress(ctx, s.plan.Clone())
+	}
+	return r.write(ctx, &record{Plan: s.plan, Messages: s.messages})
+}
+
+// finish 把最近的快照标记为已完成，再次以相同 ID 调用会重新开始.
+func (r *run) finish(ctx context.Context) error {
+	if r.last == nil {
+		return nil
+	}
+	r.last.Finished = true
+	return r.write(ctx, r.last)
+}
+
+func (r *run) write(ctx context.Context, rec *record) error {
+	if r.store == nil {
+		return nil
+	}
+	r.last = rec
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if err = r.store.Set(ctx, checkPointKey(r.id), data); err != nil {
+		return fmt.Errorf("save plan checkpoint %q: %w", r.id, err)
+	}
+	return nil
+}
+
+// fileCheckPointStore 把快照保存为目录下的文件，进程崩溃后仍可恢复.
+type fileCheckPointStore struct {
+	dir string
+}
+
+func newFileCheckPointStore(dir string) (compose.CheckPointStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &fileCheckPointStore{dir: dir}, nil
+}
+
+func (f *fileCheckPointStore) path(id string) string {
+	return filepath.Join(f.dir, url.PathEscape(id)+".json")
+}
+
+func (f *fileCheckPointStore) Get(_ context.Context, id string) ([]byte, bool, error) {
+	data, err := os.ReadFile(f.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	return data, err == nil, err
+}
+
+// Set 先写临时文件再重命名，避免崩溃时留下写了一半的快照.
+func (f *fileCheckPointStore) Set(_ context.Context, id string, data []byte) error {
+	tmp := f.path(id) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.path(id))
+}
@@ -31,7 +31,18 @@ import (
 	"github.com/cloudwego/eino-examples/flow/agent/multiagent/plan_execute/prompt"
 )
 
+// ReplanMode 步骤执行失败后的重新规划方式.
+type ReplanMode int
+
+const (
+	// ReplanAll 由 planner 重新生成整个计划，已完成的步骤也会重新执行.
+	ReplanAll ReplanMode = iota
+	// ReplanRemaining 由 replanner 只修订失败和待执行的步骤，已完成的步骤及结果保留.
+	ReplanRemaining
+)
+
 // Config “计划——执行”多智能体的配置.
+// 模型如需适配服务商的消息格式（如不支持 ToolMessage），请先用 msgcompat.Wrap 包装.
 type Config struct {
 	PlannerModel        model.BaseChatModel // planner 智能体使用的大模型
 	PlannerSystemPrompt string              // planner 智能体的 system prompt
@@ -43,6 +54,12 @@ type Config struct {
 	ReviserModel        model.BaseChatModel // reviser 智能体使用的大模型
 	ReviserSystemPrompt string              // reviser 智能体的 system prompt
 
+	ReplanMode            ReplanMode // 步骤执行失败后的重新规划方式，默认 ReplanAll
+	ReplannerSystemPrompt string     // ReplanRemaining 模式下 replanner 智能体的 system prompt，replanner 使用 PlannerModel
+
+	// CheckPointStore 用于保存计划和上下文，配合 WithRunID 使用，使中断的运行可以从未完成的步骤继续
+	CheckPointStore compose.CheckPointStore
+
 	MaxStep int // 多智能体的最大执行步骤数，避免无限循环
 }
 
@@ -50,34 +67,42 @@ type Config struct {
 type PlanExecuteMultiAgent struct {
 	// 图编排后的可执行体，输入是 Message 数组，输出是单条 Message
 	runnable compose.Runnable[[]*schema.Message, *schema.Message]
+	store    compose.CheckPointStore
 }
 
-// state 以多智能体一次运行为 scope 的全局状态，用于记录上下文
+// state 以多智能体一次运行为 scope 的全局状态，用于记录上下文和计划
 type state struct {
 	messages []*schema.Message
+	plan     *Plan
 }
 
 const (
-	nodeKeyPlanner        = "planner"          // planner 智能体的节点 key
-	nodeKeyExecutor       = "executor"         // executor 智能体的节点 key
-	nodeKeyReviser        = "reviser"          // reviser 智能体的节点 key
-	nodeKeyTools          = "tools"            // tools 执行器的节点 key
-	nodeKeyPlannerToList  = "planner_to_list"  // planner->executor 之间的 converter 节点 key
-	nodeKeyExecutorToList = "executor_to_list" // executor->reviser 之间的 converter 节点 key
-	nodeKeyReviserToList  = "reviser_to_list"  // reviser->executor 之间的 converter 节点 key
-	defaultMaxStep        = 100                // 默认的最大执行步骤数量
+	nodeKeyInit            = "init"              // 入口节点 key，负责保存输入或恢复上下文
+	nodeKeyPlanner         = "planner"           // planner 智能体的节点 key
+	nodeKeyExecutor        = "executor"          // executor 智能体的节点 key
+	nodeKeyReviser         = "reviser"           // reviser 智能体的节点 key
+	nodeKeyReplanner       = "replanner"         // replanner 智能体的节点 key
+	nodeKeyTools           = "tools"             // tools 执行器的节点 key
+	nodeKeyPlannerToPlan   = "planner_to_plan"   // 解析 planner 输出为计划的节点 key
+	nodeKeyStepEnd         = "step_end"          // 记录 executor 步骤结果的节点 key
+	nodeKeyReviserToPlan   = "reviser_to_plan"   // 把 reviser 的待校验内容加入计划的节点 key
+	nodeKeyReplannerToPlan = "replanner_to_plan" // 解析 replanner 输出为剩余计划的节点 key
+	defaultMaxStep         = 200                 // 默认的最大执行步骤数量
+
+	stepFailedMarker = "步骤失败" // executor 判断步骤无法执行时输出的标记
 )
 
 // NewMultiAgent 根据配置编排一个“计划——执行”多智能体.
 func NewMultiAgent(ctx context.Context, config *Config) (*PlanExecuteMultiAgent, error) {
 	var (
-		toolInfos      []*schema.ToolInfo
-		toolsNode      *compose.ToolsNode
-		err            error
-		plannerPrompt  = config.PlannerSystemPrompt
-		executorPrompt = config.ExecutorSystemPrompt
-		reviserPrompt  = config.ReviserSystemPrompt
-		maxStep        = config.MaxStep
+		toolInfos       []*schema.ToolInfo
+		toolsNode       *compose.ToolsNode
+		err             error
+		plannerPrompt   = config.PlannerSystemPrompt
+		executorPrompt  = config.ExecutorSystemPrompt
+		reviserPrompt   = config.ReviserSystemPrompt
+		replannerPrompt = config.ReplannerSystemPrompt
+		maxStep         = config.MaxStep
 	)
 
 	if len(plannerPrompt) == 0 {
@@ -92,6 +117,10 @@ func NewMultiAgent(ctx context.Context, config *Config) (*PlanExecuteMultiAgent,
 		reviserPrompt = prompt.DefaultReviserPrompt
 	}
 
+	if len(replannerPrompt) == 0 {
+		replannerPrompt = prompt.DefaultReplannerPrompt
+	}
+
 	if maxStep == 0 {
 		maxStep = defaultMaxStep
 	}
@@ -110,35 +139,147 @@ func NewMultiAgent(ctx context.Context, config *Config) (*PlanExecuteMultiAgent,
 		return nil, err
 	}
 
-	// 创建一个待编排的 graph，规定整体的输入输出类型，配置全局状态的初始化方法
+	// 创建一个待编排的 graph，规定整体的输入输出类型，配置全局状态的初始化方法。
+	// 以相同的 RunID 恢复运行时，用保存的上下文和计划初始化状态
 	graph := compose.NewGraph[[]*schema.Message, *schema.Message](compose.WithGenLocalState(func(ctx context.Context) *state {
+		if rec := getRun(ctx).resumed; rec != nil {
+			return &state{messages: rec.Messages, plan: rec.Plan}
+		}
 		return &state{}
 	}))
 
-	// 在大模型执行之前，向全局状态中保存上下文，并组装本次的上下文
-	modelPreHandle := func(systemPrompt string, isDeepSeek bool) compose.StatePreHandler[[]*schema.Message, *state] {
-		return func(ctx context.Context, input []*schema.Message, state *state) ([]*schema.Message, error) {
-			state.messages = append(state.messages, input...)
+	// 步骤失败后由谁重新规划
+	replanNode := nodeKeyPlanner
+	if config.ReplanMode == ReplanRemaining {
+		replanNode = nodeKeyReplanner
+	}
 
-			if isDeepSeek {
-				return append([]*schema.Message{schema.SystemMessage(systemPrompt)}, convertMessagesForDeepSeek(state.messages)...), nil
+	// 根据计划的状态决定下一个节点：没有计划时规划，有失败的步骤时重新规划，有未完成的步骤时执行，否则汇总
+	route := func(ctx context.Context) (next string, err error) {
+		err = compose.ProcessState(ctx, func(_ context.Context, state *state) error {
+			switch {
+			case state.plan == nil:
+				next = nodeKeyPlanner
+			case state.plan.Count(StepFailed) > 0:
+				next = replanNode
+			case state.plan.Next() >= 0 || state.plan.Current() >= 0:
+				next = nodeKeyExecutor
+			default:
+				next = nodeKeyReviser
 			}
+			return nil
+		})
+		return next, err
+	}
+	routeBranch := func(endNodes ...string) *compose.GraphBranch {
+		ends := make(map[string]bool, len(endNodes))
+		for _, n := range endNodes {
+			ends[n] = true
+		}
+		return compose.NewGraphBranch(func(ctx context.Context, _ []*schema.Message) (string, error) {
+			return route(ctx)
+		}, ends)
+	}
 
-			return append([]*schema.Message{schema.SystemMessage(systemPrompt)}, convertMessagesForArk(state.messages)...), nil
+	// 上下文都由产生消息的节点写入全局状态，大模型执行之前只需要组装本次的上下文。
+	// 各服务商对消息格式的特殊要求由 msgcompat 包装的模型处理，这里只传原始上下文
+	modelPreHandle := func(systemPrompt string) compose.StatePreHandler[[]*schema.Message, *state] {
+		return func(ctx context.Context, _ []*schema.Message, state *state) ([]*schema.Message, error) {
+			return append([]*schema.Message{schema.SystemMessage(systemPrompt)}, state.messages...), nil
 		}
 	}
 
+	// executor 每次只执行一个步骤：没有执行中的步骤时，把下一个待执行的步骤标记为执行中，并交代给 executor
+	executorPreHandle := func(ctx context.Context, input []*schema.Message, state *state) ([]*schema.Message, error) {
+		if state.plan.Current() < 0 {
+			i := state.plan.Next()
+			if i < 0 {
+				return nil, fmt.Errorf("no pending step in plan")
+			}
+			state.plan.Steps[i].Status = StepRunning
+			state.messages = append(state.messages, schema.UserMessage(fmt.Sprintf("当前计划：\n%s\n请执行第 %d 步：%s", state.plan, i+1, state.plan.Steps[i].Text)))
+			if err := getRun(ctx).save(ctx, state, true); err != nil {
+				return nil, err
+			}
+		}
+		return modelPreHandle(executorPrompt)(ctx, input, state)
+	}
+
+	// replanner 额外看到带状态和结果的计划
+	replannerPreHandle := func(ctx context.Context, input []*schema.Message, state *state) ([]*schema.Message, error) {
+		msgs, _ := modelPreHandle(replannerPrompt)(ctx, input, state)
+		return append(msgs, schema.UserMessage("当前计划：\n"+state.plan.String())), nil
+	}
+
+	// start 保存输入。恢复运行时，输入已在保存的上下文中
+	start := func(ctx context.Context, input []*schema.Message) ([]*schema.Message, error) {
+		r := getRun(ctx)
+		if r.resumed != nil {
+			return input, nil
+		}
+		return input, compose.ProcessState(ctx, func(ctx context.Context, state *state) error {
+			state.messages = append(state.messages, input...)
+			return r.save(ctx, state, false)
+		})
+	}
+
+	// updatePlan 把大模型的输出写入上下文，用 update 更新计划后保存
+	updatePlan := func(update func(msg *schema.Message, plan *Plan)) func(context.Context, *schema.Message) ([]*schema.Message, error) {
+		return func(ctx context.Context, msg *schema.Message) ([]*schema.Message, error) {
+			return []*schema.Message{msg}, compose.ProcessState(ctx, func(ctx context.Context, state *state) error {
+				state.messages = append(state.messages, msg)
+				if state.plan == nil {
+					state.plan = &Plan{}
+				}
+				update(msg, state.plan)
+				return getRun(ctx).save(ctx, state, true)
+			})
+		}
+	}
+
+	plannerToPlan := updatePlan(func(msg *schema.Message, plan *Plan) {
+		steps := parseSteps(msg.Content, "初始计划")
+		if len(steps) == 0 {
+			steps = []string{strings.TrimSpace(msg.Content)}
+		}
+		plan.Replace(steps)
+	})
+
+	stepEnd := updatePlan(func(msg *schema.Message, plan *Plan) {
+		if i := plan.Current(); i >= 0 {
+			step := plan.Steps[i]
+			step.Result = msg.Content
+			step.Status = StepDone
+			if strings.Contains(msg.Content, stepFailedMarker) {
+				step.Status = StepFailed
+			}
+		}
+	})
+
+	// reviser 列出的待校验内容作为新的步骤交给 executor
+	reviserToPlan := updatePlan(func(msg *schema.Message, plan *Plan) {
+		steps := parseSteps(msg.Content, "存在的问题或需要进一步校验的内容")
+		if len(steps) == 0 {
+			steps = []string{"校验待讨论的方案"}
+		}
+		plan.ReplaceRemaining(steps)
+	})
+
+	replannerToPlan := updatePlan(func(msg *schema.Message, plan *Plan) {
+		plan.ReplaceRemaining(parseSteps(msg.Content, "剩余计划"))
+	})
+
 	// 定义 Executor 后的分支判断用的条件函数。该函数的输出是运行时选中的 NodeKey
 	executorPostBranchCondition := func(_ context.Context, msg *schema.Message) (endNode string, err error) {
 		if len(msg.ToolCalls) == 0 {
-			return nodeKeyExecutorToList, nil
+			return nodeKeyStepEnd, nil
 		}
 
 		return nodeKeyTools, nil
 	}
 
-	// 定义 Reviser 后的分支判断用的条件函数。
-	reviserPostBranchCondition := func(_ context.Context, sr *schema.StreamReader[*schema.Message]) (endNode string, err error) {
+	// 定义 Reviser 后的分支判断用的条件函数。得到最终答案时把保存的运行标记为已完成
+	reviserPostBranchCondition := func(ctx context.Context, sr *schema.StreamReader[*schema.Message]) (endNode string, err error) {
 		defer sr.Close()
 
 		var content string
@@ -146,7 +287,7 @@ func NewMultiAgent(ctx context.Context, config *Config) (*PlanExecuteMultiAgent,
 			msg, err := sr.Recv()
 			if err != nil {
 				if err == io.EOF {
-					return nodeKeyReviserToList, nil
+					return nodeKeyReviserToPlan, nil
 				}
 				return "", err
 			}
@@ -154,46 +295,64 @@ func NewMultiAgent(ctx context.Context, config *Config) (*PlanExecuteMultiAgent,
 			content += msg.Content
 
 			if strings.Contains(content, "最终答案") {
-				return compose.END, nil
+				return compose.END, getRun(ctx).finish(ctx)
 			}
 		}
 	}
 
-	// 添加 Planner 节点，同时添加 StatePreHandler 读写上下文
-	_ = graph.AddChatModelNode(nodeKeyPlanner, config.PlannerModel, compose.WithStatePreHandler(modelPreHandle(plannerPrompt, true)), compose.WithNodeName(nodeKeyPlanner))
+	_ = graph.AddLambdaNode(nodeKeyInit, compose.InvokableLambda(start))
+
+	// 添加 Planner 节点，同时添加 StatePreHandler 读取上下文
+	_ = graph.AddChatModelNode(nodeKeyPlanner, config.PlannerModel, compose.WithStatePreHandler(modelPreHandle(plannerPrompt)), compose.WithNodeName(nodeKeyPlanner))
 
-	// 添加 Executor 节点，同时添加 StatePreHandler 读写上下文
-	_ = graph.AddChatModelNode(nodeKeyExecutor, config.ExecutorModel, compose.WithStatePreHandler(modelPreHandle(executorPrompt, false)), compose.WithNodeName(nodeKeyExecutor))
+	// 添加 Executor 节点，同时添加 StatePreHandler 开始步骤并读取上下文
+	_ = graph.AddChatModelNode(nodeKeyExecutor, config.ExecutorModel, compose.WithStatePreHandler(executorPreHandle), compose.WithNodeName(nodeKeyExecutor))
 
-	// 添加 Reviser 节点，同时添加 StatePreHandler 读写上下文
-	_ = graph.AddChatModelNode(nodeKeyReviser, config.ReviserModel, compose.WithStatePreHandler(modelPreHandle(reviserPrompt, true)), compose.WithNodeName(nodeKeyReviser))
+	// 添加 Reviser 节点，同时添加 StatePreHandler 读取上下文
+	_ = graph.AddChatModelNode(nodeKeyReviser, config.ReviserModel, compose.WithStatePreHandler(modelPreHandle(reviserPrompt)), compose.WithNodeName(nodeKeyReviser))
 
-	// 添加 Tool 执行器节点，同时添加 StatePreHandler 读写上下文
+	// ReplanRemaining 模式下添加 Replanner 节点，使用 planner 的大模型
+	if config.ReplanMode == ReplanRemaining {
+		_ = graph.AddChatModelNode(nodeKeyReplanner, config.PlannerModel, compose.WithStatePreHandler(replannerPreHandle), compose.WithNodeName(nodeKeyReplanner))
+		_ = graph.AddLambdaNode(nodeKeyReplannerToPlan, compose.InvokableLambda(replannerToPlan))
+	}
+
+	// 添加 Tool 执行器节点，同时添加 StatePreHandler 和 StatePostHandler 写入上下文
 	_ = graph.AddToolsNode(nodeKeyTools, toolsNode, compose.WithStatePreHandler(func(ctx context.Context, in *schema.Message, state *state) (*schema.Message, error) {
 		state.messages = append(state.messages, in)
 		return in, nil
+	}), compose.WithStatePostHandler(func(ctx context.Context, out []*schema.Message, state *state) ([]*schema.Message, error) {
+		state.messages = append(state.messages, out...)
+		return out, nil
 	}))
 
-	// 添加三个 ToList 转换节点
-	_ = graph.AddLambdaNode(nodeKeyPlannerToList, compose.ToList[*schema.Message]())
-	_ = graph.AddLambdaNode(nodeKeyExecutorToList, compose.ToList[*schema.Message]())
-	_ = graph.AddLambdaNode(nodeKeyReviserToList, compose.ToList[*schema.Message]())
+	// 添加更新计划的节点
+	_ = graph.AddLambdaNode(nodeKeyPlannerToPlan, compose.InvokableLambda(plannerToPlan))
+	_ = graph.AddLambdaNode(nodeKeyStepEnd, compose.InvokableLambda(stepEnd))
+	_ = graph.AddLambdaNode(nodeKeyReviserToPlan, compose.InvokableLambda(reviserToPlan))
 
 	// 添加节点之间的边和分支
-	_ = graph.AddEdge(compose.START, nodeKeyPlanner)
-	_ = graph.AddEdge(nodeKeyPlanner, nodeKeyPlannerToList)
-	_ = graph.AddEdge(nodeKeyPlannerToList, nodeKeyExecutor)
+	_ = graph.AddEdge(compose.START, nodeKeyInit)
+	_ = graph.AddBranch(nodeKeyInit, routeBranch(nodeKeyPlanner, replanNode, nodeKeyExecutor, nodeKeyReviser))
+	_ = graph.AddEdge(nodeKeyPlanner, nodeKeyPlannerToPlan)
+	_ = graph.AddEdge(nodeKeyPlannerToPlan, nodeKeyExecutor)
 	_ = graph.AddBranch(nodeKeyExecutor, compose.NewGraphBranch(executorPostBranchCondition, map[string]bool{
-		nodeKeyTools:          true,
-		nodeKeyExecutorToList: true,
+		nodeKeyTools:   true,
+		nodeKeyStepEnd: true,
 	}))
 	_ = graph.AddEdge(nodeKeyTools, nodeKeyExecutor)
-	_ = graph.AddEdge(nodeKeyExecutorToList, nodeKeyReviser)
+	_ = graph.AddBranch(nodeKeyStepEnd, routeBranch(replanNode, nodeKeyExecutor, nodeKeyReviser))
 	_ = graph.AddBranch(nodeKeyReviser, compose.NewStreamGraphBranch(reviserPostBranchCondition, map[string]bool{
-		nodeKeyReviserToList: true,
+		nodeKeyReviserToPlan: true,
 		compose.END:          true,
 	}))
-	_ = graph.AddEdge(nodeKeyReviserToList, nodeKeyExecutor)
+	_ = graph.AddEdge(nodeKeyReviserToPlan, nodeKeyExecutor)
+
+	// 修订后的剩余计划可能为空，因此同样根据计划状态选择下一个节点
+	if config.ReplanMode == ReplanRemaining {
+		_ = graph.AddEdge(nodeKeyReplanner, nodeKeyReplannerToPlan)
+		_ = graph.AddBranch(nodeKeyReplannerToPlan, routeBranch(nodeKeyReplanner, nodeKeyExecutor, nodeKeyReviser))
+	}
 
 	// 编译 graph，并生成 Mermaid 拓扑图。由于 graph 中存在环，使用 AnyPredecessor 模式，同时设置运行时最大步数。
 	gen := visualize.NewMermaidGenerator("flow/agent/multiagent/plan_execute")
@@ -211,12 +370,19 @@ func NewMultiAgent(ctx context.Context, config *Config) (*PlanExecuteMultiAgent,
 
 	return &PlanExecuteMultiAgent{
 		runnable: runnable,
+		store:    config.CheckPointStore,
 	}, nil
 }
 
 // Generate 以非流式的方式调用多智能体.
+// 可用 WithRunID 恢复中断的运行，用 WithProgress 获取计划的执行进度.
 func (r *PlanExecuteMultiAgent) Generate(ctx context.Context, input []*schema.Message, opts ...agent.AgentOption) (output *schema.Message, err error) {
-	output, err = r.runnable.Invoke(ctx, input, agent.GetComposeOptions(opts...)...)
+	run, err := newRun(ctx, r.store, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err = r.runnable.Invoke(withRun(ctx, run), input, agent.GetComposeOptions(opts...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -225,10 +391,16 @@ func (r *PlanExecuteMultiAgent) Generate(ctx context.Context, input []*schema.Me
 }
 
 // Stream 以流式的方式调用多智能体.
+// 可用 WithRunID 恢复中断的运行，用 WithProgress 获取计划的执行进度.
 func (r *PlanExecuteMultiAgent) Stream(ctx context.Context, input []*schema.Message, opts ...agent.AgentOption) (
 	output *schema.StreamReader[*schema.Message], err error,
 ) {
-	res, err := r.runnable.Stream(ctx, input, agent.GetComposeOptions(opts...)...)
+	run, err := newRun(ctx, r.store, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.runnable.Stream(withRun(ctx, run), input, agent.GetComposeOptions(opts...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -250,49 +422,3 @@ func genToolInfos(ctx context.Context, config compose.ToolsNodeConfig) ([]*schem
 
 	return toolInfos, nil
 }
-
-func convertMessagesForDeepSeek(messages []*schema.Message) (converted []*schema.Message) {
-	converted = make([]*schema.Message, 0, len(messages)*2)
-	for _, message := range messages {
-		switch message.Role {
-		case schema.Tool: // 有 DeepSeek 服务商如火山引擎，目前不支持传入 ToolMessage
-			converted = append(converted, schema.AssistantMessage(message.Content, nil))
-		case schema.Assistant:
-			if len(message.ToolCalls) == 0 {
-				if len(message.Content) > 0 {
-					converted = append(converted, message)
-				}
-			} else {
-				if len(message.Content) > 0 {
-					converted = append(converted, schema.AssistantMessage(message.Content, nil))
-				}
-				for _, toolCall := range message.ToolCalls {
-					converted = append(converted, schema.AssistantMessage(fmt.Sprintf("call %s with %s, got response:", toolCall.Function.Name, toolCall.Function.Arguments), nil))
-				}
-			}
-		default:
-			converted = append(converted, message)
-		}
-	}
-
-	return converted
-}
-
-func convertMessagesForArk(messages []*schema.Message) (converted []*schema.Message) {
-	converted = make([]*schema.Message, 0, len(messages)*2)
-	for _, message := range messages {
-		if message.Role == schema.Assistant {
-			if len(message.ToolCalls) == 0 {
-				if len(message.Content) > 0 {
-					converted = append(converted, message)
-				}
-			} else {
-				converted = append(converted, message)
-			}
-		} else {
-			converted = append(converted, message)
-		}
-	}
-
-	return converted
-}
@@ -23,6 +23,7 @@ import (
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -39,6 +40,8 @@ import (
 	"github.com/cloudwego/eino/utils/callbacks"
 	"github.com/coze-dev/cozeloop-go"
 
+	"github.com/cloudwego/eino-examples/components/model/msgcompat"
+	"github.com/cloudwego/eino-examples/devops/callbacklog"
 	"github.com/cloudwego/eino-examples/flow/agent/multiagent/plan_execute/debug"
 	"github.com/cloudwego/eino-examples/flow/agent/multiagent/plan_execute/tools"
 )
@@ -84,17 +87,36 @@ func main() {
 		log.Fatalf("get tools config failed: %v", err)
 	}
 
+	// planner 和 reviser 没有绑定工具，且部分 DeepSeek 服务商（如火山引擎）不支持传入 ToolMessage，
+	// 无论模型名是什么，都把执行历史中的工具调用和结果展开为 assistant 消息。
+	// executor 按模型名选择消息格式适配规则（如豆包不接受空的 assistant 消息），无法识别时使用 Ark 的规则
+	deepSeekCompat, ok := msgcompat.Default.Profile(msgcompat.ProfileDeepSeekReasoner)
+	if !ok {
+		log.Fatalf("message compat profile %s not registered", msgcompat.ProfileDeepSeekReasoner)
+	}
+	arkCompat := compatProfile(os.Getenv("ARK_MODEL_NAME"), msgcompat.ProfileArk)
+
+	store, err := newFileCheckPointStore(filepath.Join(os.TempDir(), "eino_plan_execute"))
+	if err != nil {
+		log.Fatalf("new checkpoint store failed: %v", err)
+	}
+	// 只有设置了 RUN_ID 才保存和恢复运行，未设置时每次都重新开始
+	runID := os.Getenv("RUN_ID")
+
 	// 创建多智能体的配置，system prompt 都用默认值
 	config := &Config{
 		// planner 在调试时大部分场景不需要真的去生成，可以用 mock 输出替代
 		PlannerModel: &debug.ChatModelDebugDecorator{
-			Model: deepSeekModel,
+			Model: msgcompat.Wrap(deepSeekModel, deepSeekCompat),
 		},
-		ExecutorModel: arkModel,
+		ExecutorModel: msgcompat.Wrap(arkModel, arkCompat),
 		ToolsConfig:   compose.ToolsNodeConfig{Tools: toolsConfig},
 		ReviserModel: &debug.ChatModelDebugDecorator{
-			Model: deepSeekModel,
+			Model: msgcompat.Wrap(deepSeekModel, deepSeekCompat),
 		},
+		// 步骤失败时只修订剩余的步骤。设置了 RUN_ID 时计划和上下文保存在本地目录，中断后以相同的 RUN_ID 重新运行即可继续
+		ReplanMode:      ReplanRemaining,
+		CheckPointStore: store,
 	}
 
 	planExecuteAgent, err := NewMultiAgent(ctx, config)
@@ -106,9 +128,23 @@ func main() {
 	printer.printStream()                     // 开始异步输出到 console
 	handler := printer.toCallbackHandler()    // 转化为 Eino 框架的 callback handler
 
+	// 设置 PLAN_EXECUTE_LOG_FILE 时，另外把模型和工具的输入输出以 JSON lines 格式写入滚动日志，便于事后分析
+	callbackOpts := []compose.Option{compose.WithCallbacks(handler)}
+	if logFile := os.Getenv("PLAN_EXECUTE_LOG_FILE"); logFile != "" {
+		sink, err := callbacklog.NewRotatingSink(logFile, 10<<20, 3)
+		if err != nil {
+			log.Fatalf("open log file failed: %v", err)
+		}
+		logHandler := callbacklog.NewHandler([]callbacklog.Sink{sink})
+		defer logHandler.Close()
+		callbackOpts = append(callbackOpts, compose.WithCallbacks(logHandler))
+	}
+
 	// 以流式方式调用多智能体，实际的 OutputStream 不再需要关注，因为所有输出都由 intermediateOutputPrinter 处理了
 	_, err = planExecuteAgent.Stream(ctx, []*schema.Message{schema.UserMessage("我们一家三口去乐园玩，孩子身高 120 cm，预算 2000 元，希望能尽可能多的看表演，游乐设施则比较偏爱刺激项目，希望能在一天内尽可能多体验不同的活动，请帮忙规划一个可操作的一日行程。我们会在乐园开门的时候入场，玩到晚上闭园的时候。")},
-		agent.WithComposeOptions(compose.WithCallbacks(handler)), // 将中间结果打印的 callback handler 注入进来
+		agent.WithComposeOptions(callbackOpts...), // 将中间结果打印的 callback handler 注入进来
+		WithRunID(runID),
+		WithProgress(printer.printPlan), // 计划或步骤状态变化时输出进度
 		// 给 planner 指定 mock 输出
 		// agent.WithComposeOptions(compose.WithChatModelOption(debug.WithDebugOutput(schema.AssistantMessage(debug.PlannerOutput, nil))).DesignateNode(nodeKeyPlanner)),
 		// 给 reviser 指定 mock 输出
@@ -122,6 +158,18 @@ func main() {
 	time.Sleep(3 * time.Second) // 确保trace上报后再结束
 }
 
+// compatProfile 返回模型名对应的消息适配规则，无法识别时使用 fallback.
+func compatProfile(modelName, fallback string) msgcompat.Profile {
+	if p := msgcompat.Default.Match(modelName); p.Name != msgcompat.Passthrough.Name {
+		return p
+	}
+	p, ok := msgcompat.Default.Profile(fallback)
+	if !ok {
+		log.Fatalf("message compat profile %s not registered", fallback)
+	}
+	return p
+}
+
 type coloredString struct {
 	str  string
 	code string
@@ -140,9 +188,10 @@ func newIntermediateOutputPrinter() *intermediateOutputPrinter {
 	return &intermediateOutputPrinter{
 		ch: make(chan coloredString),
 		agentReasoning: map[string]bool{
-			nodeKeyPlanner:  false,
-			nodeKeyExecutor: false,
-			nodeKeyReviser:  false,
+			nodeKeyPlanner:   false,
+			nodeKeyExecutor:  false,
+			nodeKeyReviser:   false,
+			nodeKeyReplanner: false,
 		},
 	}
 }
@@ -155,6 +204,11 @@ func (s *intermediateOutputPrinter) printStream() {
 	}()
 }
 
+// printPlan 输出计划的执行进度.
+func (s *intermediateOutputPrinter) printPlan(_ context.Context, plan *Plan) {
+	s.ch <- coloredString{fmt.Sprintf("\n\n[plan #%d: %d/%d done]\n", plan.Revision, plan.Count(StepDone), len(plan.Steps)), Green}
+}
+
 func (s *intermediateOutputPrinter) toCallbackHandler() callbacks2.Handler {
 	return callbacks.NewHandlerHelper().ChatModel(&callbacks.ModelCallbackHandler{
 		OnEndWithStreamOutput: s.onChatModelEndWithStreamOutput,
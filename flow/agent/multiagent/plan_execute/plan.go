@@ -0,0 +1,154 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// StepStatus 计划步骤的执行状态.
+type StepStatus string
+
+const (
+	StepPending StepStatus = "pending" // 待执行
+	StepRunning StepStatus = "running" // 执行中
+	StepDone    StepStatus = "done"    // 已完成
+	StepFailed  StepStatus = "failed"  // 执行失败
+)
+
+// Step 计划中的一个步骤.
+type Step struct {
+	Text   string     `json:"text"`
+	Status StepStatus `json:"status"`
+	Result string     `json:"result,omitempty"` // executor 对该步骤的总结
+}
+
+// Plan 结构化的计划. 每次重新规划 Revision 加一.
+type Plan struct {
+	Revision int     `json:"revision"`
+	Steps    []*Step `json:"steps"`
+}
+
+// Current 返回执行中的步骤，没有则返回 -1.
+func (p *Plan) Current() int {
+	for i, s := range p.Steps {
+		if s.Status == StepRunning {
+			return i
+		}
+	}
+	return -1
+}
+
+// Next 返回第一个待执行的步骤，没有则返回 -1.
+func (p *Plan) Next() int {
+	for i, s := range p.Steps {
+		if s.Status == StepPending {
+			return i
+		}
+	}
+	return -1
+}
+
+// Count 返回处于给定状态的步骤数.
+func (p *Plan) Count(status StepStatus) int {
+	n := 0
+	for _, s := range p.Steps {
+		if s.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Replace 用新步骤替换整个计划.
+func (p *Plan) Replace(texts []string) {
+	p.Steps = newSteps(texts)
+	p.Revision++
+}
+
+// ReplaceRemaining 保留已完成的步骤，用新步骤替换其余的步骤.
+func (p *Plan) ReplaceRemaining(texts []string) {
+	kept := make([]*Step, 0, len(p.Steps)+len(texts))
+	for _, s := range p.Steps {
+		if s.Status == StepDone {
+			kept = append(kept, s)
+		}
+	}
+	p.Steps = append(kept, newSteps(texts)...)
+	p.Revision++
+}
+
+// Clone 返回计划的深拷贝.
+func (p *Plan) Clone() *Plan {
+	cp := &Plan{Revision: p.Revision, Steps: make([]*Step, len(p.Steps))}
+	for i, s := range p.Steps {
+		step := *s
+		cp.Steps[i] = &step
+	}
+	return cp
+}
+
+// String 以带状态的编号列表展示计划，用于拼入 prompt.
+func (p *Plan) String() string {
+	var b strings.Builder
+	for i, s := range p.Steps {
+		fmt.Fprintf(&b, "%d. [%s] %s\n", i+1, s.Status, s.Text)
+		if s.Result != "" {
+			fmt.Fprintf(&b, "   结果：%s\n", strings.ReplaceAll(strings.TrimSpace(s.Result), "\n", "\n   "))
+		}
+	}
+	return b.String()
+}
+
+func newSteps(texts []string) []*Step {
+	steps := make([]*Step, len(texts))
+	for i, t := range texts {
+		steps[i] = &Step{Text: t, Status: StepPending}
+	}
+	return steps
+}
+
+var stepLine = regexp.MustCompile(`^\s*(?:[-*]\s*)?\d+\s*[.、)）]\s*(.+?)\s*$`)
+
+// parseSteps 从大模型输出中解析编号列表. header 不为空且出现在输出中时，只解析它之后的部分；
+// 编号列表之后的非空、非缩进行视为列表结束，缩进行并入上一个步骤.
+func parseSteps(content, header string) []string {
+	if header != "" {
+		if i := strings.Index(content, header); i >= 0 {
+			content = content[i+len(header):]
+		}
+	}
+
+	var steps []string
+	for _, line := range strings.Split(content, "\n") {
+		if m := stepLine.FindStringSubmatch(line); m != nil {
+			steps = append(steps, strings.Trim(m[1], "* "))
+			continue
+		}
+		if len(steps) == 0 || strings.TrimSpace(line) == "" {
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			steps[len(steps)-1] += " " + strings.TrimSpace(line)
+			continue
+		}
+		break
+	}
+	return steps
+}
@@ -0,0 +1,235 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+)
+
+func TestParseSteps(t *testing.T) {
+	content := "我来分析一下。\n\n初始计划：\n1. 查询营业时间\n2、列出所有区域\n   以及入口区域\n3) **查询演出**\n\n以上。\n4. 不是步骤"
+	want := []string{"查询营业时间", "列出所有区域 以及入口区域", "查询演出"}
+	if got := parseSteps(content, "初始计划"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseSteps = %q, want %q", got, want)
+	}
+
+	reviser := "待讨论的方案：\n| 开始时间 |\n|---|\n\n存在的问题或需要进一步校验的内容：\n1. 预算是否满足？\n2. 演出场次是否正确？"
+	if got := parseSteps(reviser, "存在的问题或需要进一步校验的内容"); len(got) != 2 || got[1] != "演出场次是否正确？" {
+		t.Fatalf("parseSteps(reviser) = %q", got)
+	}
+}
+
+func TestReplaceRemaining(t *testing.T) {
+	p := &Plan{}
+	p.Replace([]string{"a", "b", "c"})
+	p.Steps[0].Status, p.Steps[1].Status = StepDone, StepFailed
+	p.ReplaceRemaining([]string{"b2", "c2"})
+
+	var got []string
+	for _, s := range p.Steps {
+		got = append(got, s.Text+":"+string(s.Status))
+	}
+	if want := []string{"a:done", "b2:pending", "c2:pending"}; !reflect.DeepEqual(got, want) || p.Revision != 2 {
+		t.Fatalf("steps = %q, revision %d", got, p.Revision)
+	}
+}
+
+// scriptedModel answers with reply, which sees the request and the model's own call count.
+type scriptedModel struct {
+	calls int
+	reply func(calls int, in []*schema.Message) (*schema.Message, error)
+}
+
+func (m *scriptedModel) Generate(_ context.Context, in []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	m.calls++
+	return m.reply(m.calls, in)
+}
+
+func (m *scriptedModel) Stream(ctx context.Context, in []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	msg, err := m.Generate(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{msg}), nil
+}
+
+func (m *scriptedModel) WithTools([]*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	return m, nil
+}
+
+type memStore map[string][]byte
+
+func (s memStore) Get(_ context.Context, id string) ([]byte, bool, error) {
+	v, ok := s[id]
+	return v, ok, nil
+}
+
+func (s memStore) Set(_ context.Context, id string, v []byte) error {
+	s[id] = v
+	return nil
+}
+
+func reply(content string) (*schema.Message, error) {
+	return schema.AssistantMessage(content, nil), nil
+}
+
+func TestResumeAndReplanRemaining(t *testing.T) {
+	ctx := context.Background()
+	store := memStore{}
+	crash := true
+
+	planner := &scriptedModel{reply: func(calls int, in []*schema.Message) (*schema.Message, error) {
+		if strings.Contains(in[len(in)-1].Content, "当前计划") {
+			return reply("剩余计划：\n1. 改查备选演出\n2. 汇总信息")
+		}
+		return reply("初始计划：\n1. 查询营业时间\n2. 查询演出\n3. 汇总信息")
+	}}
+	executor := &scriptedModel{reply: func(calls int, in []*schema.Message) (*schema.Message, error) {
+		last := in[len(in)-1].Content
+		switch {
+		case strings.Contains(last, "请执行第 2 步：查询演出"):
+			if crash {
+				crash = false
+				return nil, errors.New("connection reset")
+			}
+			return reply("步骤失败：演出已取消")
+		case strings.Contains(last, "请执行第 1 步"):
+			return reply("步骤完成：9:00-22:00")
+		}
+		return reply("步骤完成")
+	}}
+	reviser := &scriptedModel{reply: func(int, []*schema.Message) (*schema.Message, error) {
+		return reply("最终答案：……")
+	}}
+
+	a, err := NewMultiAgent(ctx, &Config{
+		PlannerModel:    planner,
+		ExecutorModel:   executor,
+		ReviserModel:    reviser,
+		ReplanMode:      ReplanRemaining,
+		CheckPointStore: store,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var progress []string
+	onProgress := WithProgress(func(_ context.Context, p *Plan) {
+		progress = append(progress, fmt.Sprintf("r%d %d/%d", p.Revision, p.Count(StepDone), len(p.Steps)))
+	})
+	input := []*schema.Message{schema.UserMessage("规划一日行程")}
+
+	// 第二步执行时崩溃，第一步的结果已保存
+	if _, err = a.Generate(ctx, input, WithRunID("run-1"), onProgress); err == nil {
+		t.Fatal("first run succeeded")
+	}
+	rec := loadRecord(t, store, "run-1")
+	if rec.Finished || rec.Plan.Steps[0].Status != StepDone || rec.Plan.Steps[1].Status != StepRunning {
+		t.Fatalf("saved plan after crash:\n%s", rec.Plan)
+	}
+
+	// 输入不同时不恢复
+	if _, err = a.Generate(ctx, []*schema.Message{schema.UserMessage("另一个问题")}, WithRunID("run-1")); err == nil || !strings.Contains(err.Error(), "different input") {
+		t.Fatalf("resume with another input: %v", err)
+	}
+
+	// 恢复后不再规划，从第二步继续；第二步失败后只修订剩余的步骤
+	out, err := a.Generate(ctx, input, WithRunID("run-1"), onProgress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.Content, "最终答案") || planner.calls != 2 {
+		t.Fatalf("output %q, planner called %d times", out.Content, planner.calls)
+	}
+
+	rec = loadRecord(t, store, "run-1")
+	var got []string
+	for _, s := range rec.Plan.Steps {
+		got = append(got, s.Text+":"+string(s.Status))
+	}
+	if want := []string{"查询营业时间:done", "改查备选演出:done", "汇总信息:done"}; !rec.Finished || !reflect.DeepEqual(got, want) {
+		t.Fatalf("finished %v, steps %q", rec.Finished, got)
+	}
+	if rec.Plan.Steps[0].Result != "步骤完成：9:00-22:00" {
+		t.Fatalf("result of step 1 = %q", rec.Plan.Steps[0].Result)
+	}
+	if n := strings.Count(fmt.Sprint(rec.Messages), "规划一日行程"); n != 1 {
+		t.Fatalf("input appears %d times in the saved context", n)
+	}
+
+	want := []string{
+		"r1 0/3", "r1 0/3", "r1 1/3", "r1 1/3", // 首次运行：规划、第一步开始和完成、第二步开始
+		"r1 1/3", "r1 1/3", "r2 1/3", "r2 1/3", "r2 2/3", "r2 2/3", "r2 3/3", // 恢复：第二步失败、修订、执行剩余步骤
+	}
+	if !reflect.DeepEqual(progress, want) {
+		t.Fatalf("progress = %q\nwant %q", progress, want)
+	}
+}
+
+func TestReplanAll(t *testing.T) {
+	ctx := context.Background()
+	planner := &scriptedModel{reply: func(calls int, _ []*schema.Message) (*schema.Message, error) {
+		return reply(fmt.Sprintf("初始计划：\n1. 第 %d 版的第一步\n2. 第 %d 版的第二步", calls, calls))
+	}}
+	executor := &scriptedModel{reply: func(_ int, in []*schema.Message) (*schema.Message, error) {
+		if strings.Contains(in[len(in)-1].Content, "请执行第 2 步：第 1 版的第二步") {
+			return reply("步骤失败")
+		}
+		return reply("步骤完成")
+	}}
+	reviser := &scriptedModel{reply: func(int, []*schema.Message) (*schema.Message, error) {
+		return reply("最终答案")
+	}}
+	a, err := NewMultiAgent(ctx, &Config{PlannerModel: planner, ExecutorModel: executor, ReviserModel: reviser})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var last *Plan
+	if _, err = a.Generate(ctx, []*schema.Message{schema.UserMessage("hi")}, WithProgress(func(_ context.Context, p *Plan) {
+		last = p
+	})); err != nil {
+		t.Fatal(err)
+	}
+	// 整个计划重新生成，第一步也重新执行
+	if planner.calls != 2 || executor.calls != 4 || last.Revision != 2 || last.Count(StepDone) != 2 {
+		t.Fatalf("planner %d, executor %d calls; plan:\n%s", planner.calls, executor.calls, last)
+	}
+}
+
+func loadRecord(t *testing.T, store compose.CheckPointStore, runID string) *record {
+	t.Helper()
+	data, ok, err := store.Get(context.Background(), checkPointKey(runID))
+	if err != nil || !ok {
+		t.Fatalf("no checkpoint for %s: %v", runID, err)
+	}
+	rec := &record{}
+	if err = json.Unmarshal(data, rec); err != nil {
+		t.Fatal(err)
+	}
+	return rec
+}
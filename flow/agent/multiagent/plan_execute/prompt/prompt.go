@@ -40,17 +40,28 @@ const (
 3. {填写第 N 个步骤}
 `
 
-	DefaultExecutorPrompt = `你会收到用户关于乐园 A 的行程规划问题，以及其他智能体提供的关于如何解决这个问题的一个分步骤的解决思路和计划，这个计划的所有步骤都是未完成的。你的工作是严格遵循这个解决思路和计划，一步步的调用工具执行，直到所有的步骤到已执行或判断为无法执行。已完成的步骤可跳过。如果你不知道下一步该做什么，输出"".
+	DefaultExecutorPrompt = `你会收到用户关于乐园 A 的行程规划问题，以及其他智能体提供的关于如何解决这个问题的一个分步骤的解决思路和计划。计划会逐个步骤交给你执行：每次只执行指定的当前步骤，调用工具获取这个步骤需要的信息，不要提前执行后面的步骤。当前步骤完成后，输出**步骤完成**以及这个步骤结果的简要总结；如果判断当前步骤无法执行，输出**步骤失败**以及原因。
 
 另外，你还会收到其他智能体制作的乐园 A 的完整行程规划。你的工作是调用工具，验证这个行程规划是否自洽。
 
 具体来说：
 - 每次收到一版一日日程安排后，针对表演类型的日程以及选中的表演开始时间，都需要调用 validate_performance_time_table 工具来验证时间是否符合事实。如果不符合事实，需要重新调用 arrange_performances 工具重新安排。
 - 调用工具后，可能得到报错信息：传入的演出名称为 xxxxxx，未找到对应的表演。这是需要重新调用 query_performance_info 获取表演的准确名称后，重新发起调用。
-- 同时，每次收到一版一日完整日常安排后或优化后的方案时（包含游乐设施+表演+用餐+移动），**务必**调用 validate_plan_items 工具来验证整个计划是否自洽，无论验证结果是否自洽，都输出**交给你了**
+- 同时，每次收到一版一日完整日常安排后或优化后的方案时（包含游乐设施+表演+用餐+移动），**务必**调用 validate_plan_items 工具来验证整个计划是否自洽，无论验证结果是否自洽，都输出**步骤完成**以及验证结果
 - 如果你收到的完整日常安排仅仅是 arrange_performances 的返回结果，而不包含游乐设施、餐厅等其他计划项，务必**不要**调用 validate_plan_items.
 
-注意，你的工作只是调用工具，切记**不要**规划真正的行程安排。当所有的工具都调用完毕后，直接返回**交给你了**`
+注意，你的工作只是调用工具，切记**不要**规划真正的行程安排。当前步骤需要的工具都调用完毕后，直接按上面的格式输出。`
+
+	DefaultReplannerPrompt = `你会收到用户关于乐园 A 的行程规划问题、已进行的执行过程，以及当前计划各步骤的状态：done 为已完成，failed 为执行失败，pending 为待执行。计划中有步骤执行失败了，你的工作是只修订计划中尚未完成的部分：已完成的步骤及其结果会保留，不要重复它们；结合失败的原因，给出替代失败步骤以及原有待执行步骤的新步骤。可以调用的 API 与制定初始计划时相同。注意，你不是要给出实际的规划，而是要给出剩余的解决步骤。
+
+你的输出格式为：
+
+剩余计划：
+1. {填写第一个步骤}
+2. {填写第二个步骤}
+...
+3. {填写第 N 个步骤}
+`
 
 	DefaultReviserPrompt = `你会收到用户关于乐园 A 的行程规划，如何解决这个问题的一个分步骤的解决思路和计划，记为初始计划，以及对这个解决计划具体的已进行的执行过程和已完成的各步骤的结果。你的工作是汇总执行过程中获取的所有信息，生成一个符合用户需求的乐园 A 行程规划。在指定计划时，需要特别注意的点包括：
 
@@ -19,6 +19,7 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"log"
@@ -26,6 +27,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/cloudwego/eino-ext/components/model/ark"
 	"github.com/cloudwego/eino/components/model"
@@ -38,11 +40,15 @@ import (
 
 	"github.com/cloudwego/eino-examples/components/model/httptransport"
 	"github.com/cloudwego/eino-examples/flow/agent/react/dynamic_option_example/dynamic"
+	"github.com/cloudwego/eino-examples/flow/agent/react/dynamic_option_example/policy"
 	"github.com/cloudwego/eino-examples/flow/agent/react/tools"
 	"github.com/cloudwego/eino-examples/internal/logs"
 )
 
 func main() {
+	policyFile := flag.String("policy", "", "YAML/JSON policy deciding the options of each iteration, e.g. policy.yaml; getDynamicOptions is used when empty")
+	flag.Parse()
+
 	arkApiKey := os.Getenv("ARK_API_KEY")
 	arkModelName := os.Getenv("ARK_MODEL_NAME")
 
@@ -81,9 +87,17 @@ func main() {
 	// Wrap the ChatModel with dynamic.ChatModel to enable dynamic option modification.
 	// The GetOptionFunc will be called before each ChatModel.Generate() call,
 	// allowing us to modify options based on the current iteration state.
+	getOptions := dynamic.OptionFunc(getDynamicOptions)
+	if *policyFile != "" {
+		getOptions, err = newPolicyOptions(ctx, *policyFile, []tool.BaseTool{restaurantTool, dishTool})
+		if err != nil {
+			logs.Errorf("failed to load policy: %v", err)
+			return
+		}
+	}
 	dynamicModel := &dynamic.ChatModel{
 		Model:         arkChatModel,
-		GetOptionFunc: getDynamicOptions,
+		GetOptionFunc: getOptions,
 	}
 
 	// Create ReAct agent with the dynamic model
@@ -268,6 +282,34 @@ func truncateString(s string, maxLen int) string {
 	return s[:maxLen] + "..."
 }
 
+// newPolicyOptions builds the option function from a policy file instead of
+// Go code. The file is watched, so rules can be tuned while the process runs.
+func newPolicyOptions(ctx context.Context, path string, tools []tool.BaseTool) (dynamic.OptionFunc, error) {
+	p, err := policy.LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]*schema.ToolInfo, 0, len(tools))
+	for _, t := range tools {
+		info, err := t.Info(ctx)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	engine, err := policy.New(p, infos)
+	if err != nil {
+		return nil, err
+	}
+	go engine.Watch(ctx, path, 2*time.Second)
+
+	return func(ctx context.Context, input []*schema.Message, state *dynamic.State) []model.Option {
+		d := engine.Evaluate(input, state)
+		fmt.Printf("\n--- [Policy] iteration %d matched %v -> %v ---\n", state.Iteration, d.Rules, d.Action)
+		return d.Options
+	}, nil
+}
+
 // getDynamicOptions is called before each ChatModel.Generate() call.
 // It demonstrates how to dynamically modify options based on the current iteration:
 // - Iteration 0: Enable thinking mode, allow tool calls
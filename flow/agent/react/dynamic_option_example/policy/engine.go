@@ -0,0 +1,316 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/flow/agent/react/dynamic_option_example/dynamic"
+)
+
+var toolChoices = map[string]schema.ToolChoice{
+	"allowed":   schema.ToolChoiceAllowed,
+	"auto":      schema.ToolChoiceAllowed,
+	"forced":    schema.ToolChoiceForced,
+	"required":  schema.ToolChoiceForced,
+	"forbidden": schema.ToolChoiceForbidden,
+	"none":      schema.ToolChoiceForbidden,
+}
+
+var roles = map[string]schema.RoleType{
+	"user":      schema.User,
+	"assistant": schema.Assistant,
+	"tool":      schema.Tool,
+	"system":    schema.System,
+}
+
+// Engine evaluates a compiled policy. Its Options method is a
+// dynamic.OptionFunc. The policy can be replaced while the agent runs.
+type Engine struct {
+	tools    []*schema.ToolInfo
+	compiled atomic.Pointer[compiled]
+}
+
+type compiled struct {
+	rules []rule
+}
+
+type rule struct {
+	Rule
+	min, max *int
+	role     schema.RoleType
+	anyMsg   bool
+	re       *regexp.Regexp
+	choice   *schema.ToolChoice
+	tools    []*schema.ToolInfo
+}
+
+// Decision is the outcome of evaluating a policy for one model call.
+type Decision struct {
+	// Rules lists the names of the matching rules, in order.
+	Rules []string
+	// Action is the merged action of the matching rules.
+	Action Action
+	// Options are the model options the action translates to.
+	Options []model.Option
+}
+
+// New compiles p. tools are the tools bound to the agent; allowed_tools
+// selects from them. A policy that uses allowed_tools needs them.
+func New(p *Policy, tools []*schema.ToolInfo) (*Engine, error) {
+	e := &Engine{tools: tools}
+	if err := e.Reload(p); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Reload validates p and, if it is valid, replaces the current policy. Model
+// calls already being prepared finish with the old one.
+func (e *Engine) Reload(p *Policy) error {
+	if p == nil {
+		return fmt.Errorf("policy is nil")
+	}
+	c := &compiled{rules: make([]rule, len(p.Rules))}
+	for i, r := range p.Rules {
+		if r.Name == "" {
+			r.Name = fmt.Sprintf("rules[%d]", i)
+		}
+		cr, err := e.compile(r)
+		if err != nil {
+			return fmt.Errorf("rule %q: %w", r.Name, err)
+		}
+		c.rules[i] = cr
+	}
+	e.compiled.Store(c)
+	return nil
+}
+
+func (e *Engine) compile(r Rule) (rule, error) {
+	cr := rule{Rule: r}
+	if it := r.When.Iteration; it != nil {
+		cr.min, cr.max = it.Min, it.Max
+		if it.Eq != nil {
+			if it.Min != nil || it.Max != nil {
+				return cr, fmt.Errorf("iteration: eq cannot be combined with min or max")
+			}
+			cr.min, cr.max = it.Eq, it.Eq
+		}
+		if cr.min != nil && cr.max != nil && *cr.min > *cr.max {
+			return cr, fmt.Errorf("iteration: min %d is greater than max %d", *cr.min, *cr.max)
+		}
+	}
+	if m := r.When.Message; m != nil {
+		if m.Role != "" {
+			role, ok := roles[m.Role]
+			if !ok {
+				return cr, fmt.Errorf("message: unknown role %q", m.Role)
+			}
+			cr.role = role
+		}
+		switch m.Scope {
+		case "", "last":
+		case "any":
+			cr.anyMsg = true
+		default:
+			return cr, fmt.Errorf("message: scope must be last or any, got %q", m.Scope)
+		}
+		re, err := regexp.Compile(m.Pattern)
+		if err != nil {
+			return cr, fmt.Errorf("message: %w", err)
+		}
+		cr.re = re
+	}
+	if r.Then.ToolChoice != "" {
+		choice, ok := toolChoices[r.Then.ToolChoice]
+		if !ok {
+			return cr, fmt.Errorf("tool_choice: unknown value %q", r.Then.ToolChoice)
+		}
+		cr.choice = &choice
+	}
+	if r.Then.AllowedTools != nil {
+		cr.tools = []*schema.ToolInfo{}
+		for _, name := range r.Then.AllowedTools {
+			i := slices.IndexFunc(e.tools, func(t *schema.ToolInfo) bool { return t.Name == name })
+			if i < 0 {
+				return cr, fmt.Errorf("allowed_tools: unknown tool %q", name)
+			}
+			cr.tools = append(cr.tools, e.tools[i])
+		}
+	}
+	return cr, nil
+}
+
+// Options implements dynamic.OptionFunc.
+func (e *Engine) Options(_ context.Context, input []*schema.Message, state *dynamic.State) []model.Option {
+	return e.Evaluate(input, state).Options
+}
+
+// Evaluate matches the rules against state and input and merges the actions
+// of the matching ones. Set actions are written to state.CustomData.
+func (e *Engine) Evaluate(input []*schema.Message, state *dynamic.State) Decision {
+	var (
+		d      Decision
+		choice *schema.ToolChoice
+		tools  []*schema.ToolInfo
+	)
+	for _, r := range e.compiled.Load().rules {
+		if !r.matches(input, state) {
+			continue
+		}
+		d.Rules = append(d.Rules, r.Name)
+		a := r.Then
+		if r.choice != nil {
+			d.Action.ToolChoice, choice = a.ToolChoice, r.choice
+		}
+		if r.tools != nil {
+			d.Action.AllowedTools, tools = a.AllowedTools, r.tools
+		}
+		if a.Temperature != nil {
+			d.Action.Temperature = a.Temperature
+		}
+		if a.MaxTokens != nil {
+			d.Action.MaxTokens = a.MaxTokens
+		}
+		if a.Model != "" {
+			d.Action.Model = a.Model
+		}
+		for k, v := range a.Set {
+			if d.Action.Set == nil {
+				d.Action.Set = make(map[string]any)
+			}
+			d.Action.Set[k] = v
+		}
+		if r.Stop {
+			break
+		}
+	}
+
+	if len(d.Action.Set) > 0 {
+		if state.CustomData == nil {
+			state.CustomData = make(map[string]any)
+		}
+		for k, v := range d.Action.Set {
+			state.CustomData[k] = v
+		}
+	}
+	if tools != nil {
+		d.Options = append(d.Options, model.WithTools(tools))
+	}
+	if choice != nil {
+		d.Options = append(d.Options, model.WithToolChoice(*choice, d.Action.AllowedTools...))
+	}
+	if d.Action.Temperature != nil {
+		d.Options = append(d.Options, model.WithTemperature(*d.Action.Temperature))
+	}
+	if d.Action.MaxTokens != nil {
+		d.Options = append(d.Options, model.WithMaxTokens(*d.Action.MaxTokens))
+	}
+	if d.Action.Model != "" {
+		d.Options = append(d.Options, model.WithModel(d.Action.Model))
+	}
+	return d
+}
+
+func (r *rule) matches(input []*schema.Message, state *dynamic.State) bool {
+	if r.min != nil && state.Iteration < *r.min {
+		return false
+	}
+	if r.max != nil && state.Iteration > *r.max {
+		return false
+	}
+	if r.When.NoToolCalls && len(state.LastToolCalls) > 0 {
+		return false
+	}
+	if len(r.When.ToolCalled) > 0 {
+		called := false
+		for _, tc := range state.LastToolCalls {
+			called = called || slices.Contains(r.When.ToolCalled, tc.Function.Name) || slices.Contains(r.When.ToolCalled, "*")
+		}
+		if !called {
+			return false
+		}
+	}
+	for k, want := range r.When.Custom {
+		got, ok := state.CustomData[k]
+		if !ok || fmt.Sprint(got) != fmt.Sprint(want) {
+			return false
+		}
+	}
+	if r.re != nil {
+		return r.matchesMessages(input)
+	}
+	return true
+}
+
+func (r *rule) matchesMessages(input []*schema.Message) bool {
+	for i := len(input) - 1; i >= 0; i-- {
+		m := input[i]
+		if r.role != "" && m.Role != r.role {
+			continue
+		}
+		if r.re.MatchString(m.Content) {
+			return true
+		}
+		if !r.anyMsg {
+			return false
+		}
+	}
+	return false
+}
+
+// Watch reloads the policy file whenever its modification time changes, until
+// ctx is done. An invalid file is logged and the current policy stays in use.
+func (e *Engine) Watch(ctx context.Context, path string, interval time.Duration) {
+	var last time.Time
+	if fi, err := os.Stat(path); err == nil {
+		last = fi.ModTime()
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		fi, err := os.Stat(path)
+		if err != nil || !fi.ModTime().After(last) {
+			continue
+		}
+		last = fi.ModTime()
+		p, err := LoadFile(path)
+		if err == nil {
+			err = e.Reload(p)
+		}
+		if err != nil {
+			log.Printf("warn: keeping the current policy: %v", err)
+			continue
+		}
+		log.Printf("policy reloaded from %s", path)
+	}
+}
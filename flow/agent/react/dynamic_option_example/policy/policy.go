@@ -0,0 +1,172 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package policy is a declarative rule engine over dynamic.ChatModel.
+//
+// A Policy is a list of rules written in YAML or JSON. Before every model call
+// of the ReAct loop, each rule is matched against the dynamic.State and the
+// input messages; the actions of the matching rules, in order, decide the
+// model options of that call. Later rules override the fields set by earlier
+// ones, and a rule with stop: true ends the evaluation.
+//
+//	rules:
+//	  - name: look things up first
+//	    when: {iteration: {eq: 0}}
+//	    then: {tool_choice: forced}
+//	  - name: answer after two rounds
+//	    when: {iteration: {min: 2}}
+//	    then: {tool_choice: forbidden, temperature: 0.3}
+//	    stop: true
+//
+// Usage:
+//
+//	p, _ := policy.LoadFile("policy.yaml")
+//	engine, _ := policy.New(p, toolInfos)
+//	cm := &dynamic.ChatModel{Model: inner, GetOptionFunc: engine.Options}
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Policy is an ordered list of rules.
+type Policy struct {
+	Rules []Rule `yaml:"rules" json:"rules"`
+}
+
+// Rule applies its actions when all of its conditions match.
+type Rule struct {
+	// Name identifies the rule in logs. Defaults to "rules[i]".
+	Name string `yaml:"name" json:"name"`
+	When Match  `yaml:"when" json:"when"`
+	Then Action `yaml:"then" json:"then"`
+	// Stop skips the rules after this one when it matches.
+	Stop bool `yaml:"stop" json:"stop"`
+}
+
+// Match holds the conditions of a rule. Unset conditions match everything, so
+// a rule without conditions always applies.
+type Match struct {
+	// Iteration bounds dynamic.State.Iteration, counted from 0.
+	Iteration *Range `yaml:"iteration" json:"iteration"`
+	// ToolCalled matches when the previous model call requested one of these
+	// tools; "*" matches any tool.
+	ToolCalled []string `yaml:"tool_called" json:"tool_called"`
+	// NoToolCalls matches when the previous model call requested no tool.
+	NoToolCalls bool `yaml:"no_tool_calls" json:"no_tool_calls"`
+	// Message matches the content of the input messages.
+	Message *MessageMatch `yaml:"message" json:"message"`
+	// Custom matches entries of dynamic.State.CustomData. Values are compared
+	// by their printed form, so 3 matches both an int and a float64 3.
+	Custom map[string]any `yaml:"custom" json:"custom"`
+}
+
+// Range is an inclusive integer range. Eq is shorthand for Min = Max = Eq.
+type Range struct {
+	Eq  *int `yaml:"eq" json:"eq"`
+	Min *int `yaml:"min" json:"min"`
+	Max *int `yaml:"max" json:"max"`
+}
+
+// MessageMatch matches a regular expression against input messages.
+type MessageMatch struct {
+	// Role only considers messages with this role: user, assistant, tool or
+	// system. Empty considers all of them.
+	Role string `yaml:"role" json:"role"`
+	// Scope is "last" (default) to match only the last considered message, or
+	// "any" to match any of them.
+	Scope string `yaml:"scope" json:"scope"`
+	// Pattern is an RE2 regular expression, e.g. "(?i)spicy|辣".
+	Pattern string `yaml:"pattern" json:"pattern"`
+}
+
+// Action holds the model options a rule sets. Unset fields leave the option
+// as decided by earlier rules, or as passed to the agent.
+type Action struct {
+	// ToolChoice is "allowed" (alias "auto"), "forced" (alias "required") or
+	// "forbidden" (alias "none").
+	ToolChoice string `yaml:"tool_choice" json:"tool_choice"`
+	// AllowedTools rebinds the model to this subset of the agent's tools. An
+	// empty list unbinds all tools.
+	AllowedTools []string `yaml:"allowed_tools" json:"allowed_tools"`
+	Temperature  *float32 `yaml:"temperature" json:"temperature"`
+	MaxTokens    *int     `yaml:"max_tokens" json:"max_tokens"`
+	// Model switches the model name sent to the provider, e.g. to a cheaper
+	// model for the final answer.
+	Model string `yaml:"model" json:"model"`
+	// Set writes entries to dynamic.State.CustomData, for rules of later
+	// iterations to match on.
+	Set map[string]any `yaml:"set" json:"set"`
+}
+
+// String lists the fields the action sets, with pointer fields dereferenced.
+func (a Action) String() string {
+	var parts []string
+	if a.ToolChoice != "" {
+		parts = append(parts, "tool_choice:"+a.ToolChoice)
+	}
+	if a.AllowedTools != nil {
+		parts = append(parts, fmt.Sprintf("allowed_tools:%v", a.AllowedTools))
+	}
+	if a.Temperature != nil {
+		parts = append(parts, fmt.Sprintf("temperature:%v", *a.Temperature))
+	}
+	if a.MaxTokens != nil {
+		parts = append(parts, fmt.Sprintf("max_tokens:%d", *a.MaxTokens))
+	}
+	if a.Model != "" {
+		parts = append(parts, "model:"+a.Model)
+	}
+	if len(a.Set) > 0 {
+		parts = append(parts, fmt.Sprintf("set:%v", a.Set))
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
+
+// Parse reads a policy from YAML or JSON. Unknown fields are errors, so a
+// misspelled condition does not silently match everything.
+func Parse(data []byte) (*Policy, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	p := &Policy{}
+	if err := dec.Decode(p); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("policy is empty")
+		}
+		return nil, fmt.Errorf("parse policy: %w", err)
+	}
+	return p, nil
+}
+
+// LoadFile reads a policy file.
+func LoadFile(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
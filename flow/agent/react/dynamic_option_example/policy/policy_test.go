@@ -0,0 +1,153 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/flow/agent/react/dynamic_option_example/dynamic"
+)
+
+const testPolicy = `
+rules:
+  - name: first
+    when: {iteration: {eq: 0}}
+    then: {tool_choice: forced, allowed_tools: [query_restaurants]}
+  - name: spicy
+    when:
+      message: {role: user, pattern: "(?i)spicy|辣"}
+    then: {temperature: 0.9, set: {spicy: true}}
+  - name: after-restaurants
+    when: {tool_called: [query_restaurants], custom: {spicy: true}}
+    then: {allowed_tools: [query_dishes], max_tokens: 512}
+  - name: finish
+    when: {iteration: {min: 2}}
+    then: {tool_choice: none, allowed_tools: [], model: small-model}
+    stop: true
+  - name: never
+    then: {temperature: 0}
+`
+
+var testTools = []*schema.ToolInfo{{Name: "query_restaurants"}, {Name: "query_dishes"}}
+
+func newTestEngine(t *testing.T, src string) *Engine {
+	t.Helper()
+	p, err := Parse([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := New(p, testTools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestEvaluate(t *testing.T) {
+	e := newTestEngine(t, testPolicy)
+	input := []*schema.Message{schema.SystemMessage("persona"), schema.UserMessage("Something spicy in Beijing")}
+	state := dynamic.NewState()
+
+	d := e.Evaluate(input, state)
+	if want := []string{"first", "spicy", "never"}; !reflect.DeepEqual(d.Rules, want) {
+		t.Fatalf("iteration 0 rules = %q, want %q", d.Rules, want)
+	}
+	opts := model.GetCommonOptions(nil, d.Options...)
+	if *opts.ToolChoice != schema.ToolChoiceForced || len(opts.Tools) != 1 || opts.Tools[0].Name != "query_restaurants" || *opts.Temperature != 0 {
+		t.Fatalf("iteration 0 options = %+v", opts)
+	}
+	if state.CustomData["spicy"] != true {
+		t.Fatalf("custom data = %v", state.CustomData)
+	}
+
+	state.Iteration = 1
+	state.LastToolCalls = []*schema.ToolCall{{Function: schema.FunctionCall{Name: "query_restaurants"}}}
+	input = append(input, &schema.Message{Role: schema.Tool, Content: "[...]"})
+	d = e.Evaluate(input, state)
+	if want := []string{"spicy", "after-restaurants", "never"}; !reflect.DeepEqual(d.Rules, want) {
+		t.Fatalf("iteration 1 rules = %q, want %q", d.Rules, want)
+	}
+	opts = model.GetCommonOptions(nil, d.Options...)
+	if opts.ToolChoice != nil || len(opts.Tools) != 1 || opts.Tools[0].Name != "query_dishes" || *opts.MaxTokens != 512 {
+		t.Fatalf("iteration 1 options = %+v", opts)
+	}
+
+	// stop: true skips the rules after "finish"; an empty list unbinds all tools.
+	state.Iteration = 2
+	d = e.Evaluate(input, state)
+	if want := []string{"spicy", "after-restaurants", "finish"}; !reflect.DeepEqual(d.Rules, want) {
+		t.Fatalf("iteration 2 rules = %q, want %q", d.Rules, want)
+	}
+	opts = model.GetCommonOptions(nil, d.Options...)
+	if *opts.ToolChoice != schema.ToolChoiceForbidden || opts.Tools == nil || len(opts.Tools) != 0 || *opts.Model != "small-model" || *opts.Temperature != 0.9 {
+		t.Fatalf("iteration 2 options = %+v", opts)
+	}
+}
+
+func TestMessageScope(t *testing.T) {
+	e := newTestEngine(t, `{"rules": [
+		{"name": "last", "when": {"message": {"pattern": "refund"}}},
+		{"name": "any-user", "when": {"message": {"role": "user", "scope": "any", "pattern": "refund"}}}
+	]}`)
+	input := []*schema.Message{schema.UserMessage("I want a refund"), schema.UserMessage("thanks"), schema.AssistantMessage("ok", nil)}
+	if d := e.Evaluate(input, dynamic.NewState()); !reflect.DeepEqual(d.Rules, []string{"any-user"}) {
+		t.Fatalf("rules = %q", d.Rules)
+	}
+}
+
+func TestInvalidPolicies(t *testing.T) {
+	for src, want := range map[string]string{
+		`rules: [{when: {iteraton: {eq: 0}}}]`:                 "field iteraton not found",
+		`rules: [{then: {tool_choice: maybe}}]`:                "unknown value",
+		`rules: [{then: {allowed_tools: [web_search]}}]`:       `unknown tool "web_search"`,
+		`rules: [{when: {iteration: {eq: 1, min: 0}}}]`:        "eq cannot be combined",
+		`rules: [{when: {message: {pattern: "("}}}]`:           "missing closing",
+		`rules: [{name: x, when: {message: {role: robot}}}]`:   `rule "x": message: unknown role`,
+		`rules: [{when: {message: {scope: all, pattern: a}}}]`: "scope must be",
+		``: "empty",
+	} {
+		p, err := Parse([]byte(src))
+		if err == nil {
+			_, err = New(p, testTools)
+		}
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: err = %v, want %q", src, err, want)
+		}
+	}
+
+	// A failed reload keeps the current policy.
+	e := newTestEngine(t, testPolicy)
+	if err := e.Reload(&Policy{Rules: []Rule{{Then: Action{ToolChoice: "maybe"}}}}); err == nil {
+		t.Fatal("invalid reload succeeded")
+	}
+	if d := e.Evaluate(nil, dynamic.NewState()); len(d.Rules) == 0 || d.Rules[0] != "first" {
+		t.Fatalf("rules after failed reload = %q", d.Rules)
+	}
+}
+
+func TestActionString(t *testing.T) {
+	temp, maxTokens := float32(0.3), 512
+	a := Action{ToolChoice: "forbidden", Temperature: &temp, MaxTokens: &maxTokens}
+	if got, want := a.String(), "{tool_choice:forbidden temperature:0.3 max_tokens:512}"; got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+}
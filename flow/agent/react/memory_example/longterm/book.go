@@ -0,0 +1,230 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package longterm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/flow/agent/react/memory_example/memory"
+)
+
+// extraEntry holds the JSON-encoded entry of a stored memory record. It is a
+// string so it survives the JSON round trip of the Redis store unchanged.
+const extraEntry = "long_term_memory"
+
+// Kind classifies a memory.
+type Kind string
+
+const (
+	KindFact       Kind = "fact"
+	KindPreference Kind = "preference"
+)
+
+// Memory is one consolidated memory.
+type Memory struct {
+	ID      string
+	Kind    Kind
+	Content string
+	Source  Source
+	// Merged lists the memories this one replaced in a merge.
+	Merged []string
+	// Time is when this version of the memory was written.
+	Time time.Time
+}
+
+// Source is the provenance of a memory: the message it was extracted from.
+type Source struct {
+	SessionID string `json:"session_id,omitempty"`
+	// Seq is the position of the message in the conversation, counting from 0
+	// and skipping system messages. It equals the memory.Record.Seq of the
+	// message when the session history is kept in a MemoryStore and replayed
+	// in full, as in the memory example.
+	Seq     int64  `json:"seq"`
+	Role    string `json:"role"`
+	Excerpt string `json:"excerpt,omitempty"`
+}
+
+// op is the kind of change a record makes.
+type op string
+
+const (
+	opPut    op = "put"
+	opDelete op = "delete"
+)
+
+// entry is the metadata of one record of the memory log; the memory content
+// is the message content, so the store's keyword and semantic search see it.
+type entry struct {
+	ID     string   `json:"id"`
+	Op     op       `json:"op"`
+	Kind   Kind     `json:"kind,omitempty"`
+	Source *Source  `json:"source,omitempty"`
+	Merged []string `json:"merged,omitempty"`
+}
+
+func storeKey(userID string) string {
+	return "ltm:" + userID
+}
+
+// book is one user's memories, kept as an append-only log in a MemoryStore:
+// every change is a put or delete record, and the live memories are the last
+// put of every ID that has not been deleted since.
+type book struct {
+	store memory.MemoryStore
+	key   string
+}
+
+// state replays the log. It returns the live memories by ID, the seq of the
+// record holding each one, and the length of the log.
+func (b *book) state(ctx context.Context) (map[string]Memory, map[string]int64, int64, error) {
+	// Unlike Read, Tail returns the records with their sequence numbers.
+	records, err := b.store.Tail(ctx, b.key, math.MaxInt)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	live := make(map[string]Memory)
+	seqs := make(map[string]int64)
+	for _, r := range records {
+		e, ok := decodeEntry(r.Message)
+		if !ok {
+			continue
+		}
+		if e.Op == opDelete {
+			delete(live, e.ID)
+			delete(seqs, e.ID)
+			continue
+		}
+		live[e.ID] = toMemory(e, r)
+		seqs[e.ID] = r.Seq
+	}
+	var n int64
+	if len(records) > 0 {
+		n = records[len(records)-1].Seq + 1
+	}
+	return live, seqs, n, nil
+}
+
+// append writes entries to the log. Puts carry the memory content.
+func (b *book) append(ctx context.Context, changes []change) error {
+	msgs := make([]*schema.Message, 0, len(changes))
+	for _, c := range changes {
+		raw, err := json.Marshal(c.entry)
+		if err != nil {
+			return err
+		}
+		msg := schema.SystemMessage(c.content)
+		msg.Extra = map[string]any{extraEntry: string(raw)}
+		msgs = append(msgs, msg)
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return b.store.Append(ctx, b.key, msgs...)
+}
+
+type change struct {
+	entry   entry
+	content string
+}
+
+func decodeEntry(msg *schema.Message) (entry, bool) {
+	var e entry
+	raw, ok := msg.Extra[extraEntry].(string)
+	if !ok || json.Unmarshal([]byte(raw), &e) != nil || e.ID == "" {
+		return e, false
+	}
+	return e, true
+}
+
+func toMemory(e entry, r memory.Record) Memory {
+	mem := Memory{ID: e.ID, Kind: e.Kind, Content: r.Message.Content, Merged: e.Merged, Time: r.Time}
+	if e.Source != nil {
+		mem.Source = *e.Source
+	}
+	return mem
+}
+
+// List returns every live memory of the user, newest first.
+func List(ctx context.Context, store memory.MemoryStore, userID string) ([]Memory, error) {
+	b := &book{store: store, key: storeKey(userID)}
+	live, seqs, _, err := b.state(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newestFirst(live, seqs), nil
+}
+
+// Recall returns up to k live memories of the user ranked against text, as the
+// middleware does before a turn.
+func Recall(ctx context.Context, store memory.MemoryStore, userID, text string, ranking memory.Ranking, k int) ([]Memory, error) {
+	return recall(ctx, &book{store: store, key: storeKey(userID)}, text, ranking, k)
+}
+
+// recall ranks the live memories against text with the store's Query and
+// fills the remaining slots with the newest memories, so preferences that
+// share no words with the message are still seen.
+func recall(ctx context.Context, b *book, text string, ranking memory.Ranking, k int) ([]Memory, error) {
+	live, seqs, _, err := b.state(ctx)
+	if err != nil || len(live) == 0 {
+		return nil, err
+	}
+	var out []Memory
+	seen := make(map[string]bool)
+	if strings.TrimSpace(text) != "" {
+		hits, err := b.store.Query(ctx, b.key, memory.Query{Text: text, Ranking: ranking})
+		if err != nil {
+			return nil, fmt.Errorf("query memories: %w", err)
+		}
+		for _, h := range hits {
+			e, ok := decodeEntry(h.Message)
+			// Older versions and deleted memories are still in the log.
+			if !ok || seqs[e.ID] != h.Seq || seen[e.ID] {
+				continue
+			}
+			if mem, ok := live[e.ID]; ok && len(out) < k {
+				seen[e.ID] = true
+				out = append(out, mem)
+			}
+		}
+	}
+	for _, mem := range newestFirst(live, seqs) {
+		if len(out) >= k {
+			break
+		}
+		if !seen[mem.ID] {
+			out = append(out, mem)
+		}
+	}
+	return out, nil
+}
+
+func newestFirst(live map[string]Memory, seqs map[string]int64) []Memory {
+	out := make([]Memory, 0, len(live))
+	for _, mem := range live {
+		out = append(out, mem)
+	}
+	sort.Slice(out, func(i, j int) bool { return seqs[out[i].ID] > seqs[out[j].ID] })
+	return out
+}
@@ -0,0 +1,260 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package longterm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+const consolidatePrompt = `You maintain the long-term memory of an assistant about its user.
+
+Read the latest conversation turn and decide how the memory should change. Only
+remember durable information that will still be useful in future conversations:
+facts about the user (name, location, job, family, ongoing projects) and their
+preferences (likes, dislikes, dietary needs, how they want answers). Do not
+remember one-off requests, small talk, or information from tool results that is
+not about the user.
+
+Existing memories:
+%s
+
+Latest turn (each message is prefixed with its number):
+%s
+
+Reply with JSON only — no explanation, no markdown fences:
+{"operations": [
+  {"op": "add", "kind": "fact|preference", "content": "<memory>", "source": <message number>},
+  {"op": "update", "id": "<id>", "content": "<corrected memory>", "source": <message number>},
+  {"op": "merge", "ids": ["<id>", "<id>"], "kind": "fact|preference", "content": "<combined memory>", "source": <message number>},
+  {"op": "delete", "id": "<id>"}
+]}
+
+Rules:
+- Write each memory as one short third-person sentence, e.g. "The user is vegetarian."
+- Do not add a memory that an existing one already states; update it if the turn changes it.
+- Merge existing memories that say overlapping things into one.
+- Delete memories the user contradicts or asks you to forget.
+- Reply {"operations": []} if nothing should change.`
+
+// maxMessageRunes caps each message shown to the model; tool results can be long.
+const maxMessageRunes = 1000
+
+// operation is one memory change proposed by the model.
+type operation struct {
+	Op      string   `json:"op"`
+	ID      string   `json:"id"`
+	IDs     []string `json:"ids"`
+	Kind    Kind     `json:"kind"`
+	Content string   `json:"content"`
+	Source  *int64   `json:"source"`
+}
+
+// consolidate extracts memories from the last turn of msgs and appends the
+// resulting changes to the user's memory log.
+func (m *middleware) consolidate(ctx context.Context, msgs []*schema.Message) error {
+	start, startSeq := lastUserMessage(msgs)
+	if start < 0 {
+		return nil
+	}
+	turn := make(map[int64]*schema.Message)
+	var transcript strings.Builder
+	seq := startSeq
+	for _, msg := range msgs[start:] {
+		if msg.Role == schema.System {
+			continue
+		}
+		turn[seq] = msg
+		fmt.Fprintf(&transcript, "[%d] %s: %s\n", seq, msg.Role, truncate(messageText(msg), maxMessageRunes))
+		seq++
+	}
+
+	b := m.book(ctx)
+	candidates, err := recall(ctx, b, msgs[start].Content, m.ranking, m.maxCandidates)
+	if err != nil {
+		return err
+	}
+	var existing strings.Builder
+	for _, mem := range candidates {
+		fmt.Fprintf(&existing, "- id=%s [%s] %s\n", mem.ID, mem.Kind, mem.Content)
+	}
+	if existing.Len() == 0 {
+		existing.WriteString("(none)\n")
+	}
+
+	prompt := fmt.Sprintf(consolidatePrompt, existing.String(), transcript.String())
+	resp, err := m.model.Generate(ctx, []*schema.Message{schema.UserMessage(prompt)})
+	if err != nil {
+		return fmt.Errorf("extract memories: %w", err)
+	}
+	content := strings.TrimSpace(resp.Content)
+	// strip optional markdown code block wrapper
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.TrimPrefix(content, "```")
+	content = strings.TrimSuffix(content, "```")
+	var out struct {
+		Operations []operation `json:"operations"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(content)), &out); err != nil {
+		return fmt.Errorf("parse memory operations %q: %w", truncate(content, 200), err)
+	}
+
+	live, _, next, err := b.state(ctx)
+	if err != nil {
+		return err
+	}
+	p := &planner{live: live, next: next, turn: turn, startSeq: startSeq, sessionID: sessionFrom(ctx).sessionID}
+	for _, o := range out.Operations {
+		p.apply(o)
+	}
+	return b.append(ctx, p.changes)
+}
+
+// planner turns the model's operations into log changes. It validates them
+// against the live memories as they change, so an operation on a memory that
+// does not exist, or no longer does, is dropped.
+type planner struct {
+	live      map[string]Memory
+	next      int64 // length of the log, used to mint IDs
+	turn      map[int64]*schema.Message
+	startSeq  int64
+	sessionID string
+	changes   []change
+}
+
+func (p *planner) apply(o operation) {
+	content := strings.TrimSpace(o.Content)
+	switch o.Op {
+	case "add":
+		if content == "" || p.duplicate(content, "") {
+			return
+		}
+		p.put(p.newID(), kindOr(o.Kind, KindFact), content, o.Source, nil)
+	case "update":
+		old, ok := p.live[o.ID]
+		if !ok || content == "" || p.duplicate(content, o.ID) {
+			return
+		}
+		p.put(o.ID, kindOr(o.Kind, old.Kind), content, o.Source, nil)
+	case "merge":
+		var ids []string
+		kind := o.Kind
+		for _, id := range o.IDs {
+			if old, ok := p.live[id]; ok && !slices.Contains(ids, id) {
+				ids = append(ids, id)
+				kind = kindOr(kind, old.Kind)
+			}
+		}
+		if len(ids) == 0 || content == "" {
+			return
+		}
+		for _, id := range ids {
+			p.remove(id)
+		}
+		if !p.duplicate(content, "") {
+			p.put(p.newID(), kindOr(kind, KindFact), content, o.Source, ids)
+		}
+	case "delete":
+		if _, ok := p.live[o.ID]; ok {
+			p.remove(o.ID)
+		}
+	}
+}
+
+func (p *planner) put(id string, kind Kind, content string, source *int64, merged []string) {
+	src := p.source(source)
+	p.live[id] = Memory{ID: id, Kind: kind, Content: content, Source: src, Merged: merged}
+	p.changes = append(p.changes, change{
+		entry:   entry{ID: id, Op: opPut, Kind: kind, Source: &src, Merged: merged},
+		content: content,
+	})
+	p.next++
+}
+
+// remove deletes a memory. The delete record repeats the old content, which
+// keeps the log readable and gives the store's embedder no empty text.
+func (p *planner) remove(id string) {
+	old := p.live[id]
+	delete(p.live, id)
+	p.changes = append(p.changes, change{entry: entry{ID: id, Op: opDelete, Kind: old.Kind}, content: old.Content})
+	p.next++
+}
+
+// newID names a new memory after the log position of its first record, which
+// is unique because the log only grows.
+func (p *planner) newID() string {
+	return fmt.Sprintf("m%d", p.next)
+}
+
+// source resolves the message number given by the model, falling back to the
+// user message that started the turn.
+func (p *planner) source(n *int64) Source {
+	seq := p.startSeq
+	if n != nil {
+		if _, ok := p.turn[*n]; ok {
+			seq = *n
+		}
+	}
+	msg := p.turn[seq]
+	return Source{SessionID: p.sessionID, Seq: seq, Role: string(msg.Role), Excerpt: truncate(msg.Content, 120)}
+}
+
+// duplicate reports whether a live memory other than except already says
+// content, ignoring case, spacing and a trailing period.
+func (p *planner) duplicate(content, except string) bool {
+	key := normalize(content)
+	for id, mem := range p.live {
+		if id != except && normalize(mem.Content) == key {
+			return true
+		}
+	}
+	return false
+}
+
+func normalize(s string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.Join(strings.Fields(s), " ")), ".")
+}
+
+func kindOr(k, def Kind) Kind {
+	if k == KindFact || k == KindPreference {
+		return k
+	}
+	return def
+}
+
+// messageText is what the model sees of a message: its content, plus the
+// tools it calls.
+func messageText(m *schema.Message) string {
+	text := m.Content
+	for _, tc := range m.ToolCalls {
+		text += fmt.Sprintf(" [calls %s %s]", tc.Function.Name, tc.Function.Arguments)
+	}
+	return text
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n]) + "..."
+}
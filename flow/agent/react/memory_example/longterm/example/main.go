@@ -0,0 +1,93 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/cloudwego/eino/adk"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/adk/common/model"
+	"github.com/cloudwego/eino-examples/adk/common/prints"
+	"github.com/cloudwego/eino-examples/flow/agent/react/memory_example/longterm"
+	"github.com/cloudwego/eino-examples/flow/agent/react/memory_example/memory"
+)
+
+func main() {
+	ctx := context.Background()
+	cm := model.NewChatModel()
+
+	// Session history and long-term memories can share one store; memories
+	// live under their own "ltm:<user>" key.
+	store := memory.NewInMemoryStore()
+
+	mw, err := longterm.NewMiddleware(ctx, &longterm.Config{Store: store, Model: cm})
+	if err != nil {
+		log.Fatal(err)
+	}
+	agent, err := adk.NewChatModelAgent(ctx, &adk.ChatModelAgentConfig{
+		Name:        "assistant",
+		Description: "a concise assistant that remembers the user",
+		Instruction: "You are a concise assistant.",
+		Model:       cm,
+		Handlers:    []adk.ChatModelAgentMiddleware{mw},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	runner := adk.NewRunner(ctx, adk.RunnerConfig{Agent: agent})
+
+	turn := func(sessionID, input string) {
+		fmt.Printf("\n========== %s ==========\n[User Input] %s\n", sessionID, input)
+		ctx := longterm.WithSession(ctx, "alice", sessionID)
+		prev, _ := store.Read(ctx, sessionID)
+		user := schema.UserMessage(input)
+		iter := runner.Run(ctx, append(prev, user))
+		produced := []*schema.Message{user}
+		for {
+			event, ok := iter.Next()
+			if !ok {
+				break
+			}
+			if event.Err != nil {
+				log.Fatal(event.Err)
+			}
+			prints.Event(event)
+			if event.Output != nil && event.Output.MessageOutput != nil {
+				if msg, err := event.Output.MessageOutput.GetMessage(); err == nil {
+					produced = append(produced, msg)
+				}
+			}
+		}
+		_ = store.Append(ctx, sessionID, produced...)
+
+		mems, _ := longterm.List(ctx, store, "alice")
+		fmt.Printf("[Long-term memories: %d]\n", len(mems))
+		for _, m := range mems {
+			fmt.Printf("  %s [%s] %s (from %s #%d)\n", m.ID, m.Kind, m.Content, m.Source.SessionID, m.Source.Seq)
+		}
+	}
+
+	// The first session teaches the agent about the user ...
+	turn("session:1", "Hi, I'm Alice. I live in Hangzhou and I'm vegetarian.")
+	turn("session:1", "Actually I just moved to Beijing.")
+	// ... and a new session, with no shared history, still knows it.
+	turn("session:2", "Recommend a restaurant for dinner tonight.")
+}
@@ -0,0 +1,255 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package longterm gives a ChatModelAgent memory that outlives a session.
+//
+// After each successful turn the middleware asks a model to extract durable
+// facts and preferences from the turn and to consolidate them with what is
+// already remembered about the user: new memories are added, outdated ones
+// updated or deleted, and overlapping ones merged. The changes are appended to
+// a memory.MemoryStore with the session and message they came from. Before the
+// first model call of the next turn, the memories most relevant to the user's
+// message are injected into the system prompt.
+//
+// Usage:
+//
+//	mw, _ := longterm.NewMiddleware(ctx, &longterm.Config{Store: store, Model: cm})
+//	agent, _ := adk.NewChatModelAgent(ctx, &adk.ChatModelAgentConfig{..., Handlers: []adk.ChatModelAgentMiddleware{mw}})
+//	iter := runner.Run(longterm.WithSession(ctx, "alice", "session:1"), msgs)
+package longterm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/adk"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/flow/agent/react/memory_example/memory"
+)
+
+// extraInjected marks the system message that carries the recalled memories,
+// so they are injected once per run rather than once per model call.
+const extraInjected = "_long_term_memory_injected"
+
+// Config configures the middleware.
+type Config struct {
+	// Store keeps the memories. Required.
+	Store memory.MemoryStore
+	// Model extracts and consolidates memories after each turn. Required.
+	Model model.BaseChatModel
+	// TopK is the number of memories injected into the system prompt. Default 5.
+	TopK int
+	// Ranking picks the injected memories by the user's message. Default
+	// memory.RankAuto, i.e. BM25. Use memory.RankSemantic with a store created
+	// WithEmbedder to match memories that share no words with the message.
+	Ranking memory.Ranking
+	// MaxCandidates caps the existing memories shown to the model when it
+	// consolidates a turn. Default 20.
+	MaxCandidates int
+}
+
+type sessionKey struct{}
+
+type session struct {
+	userID    string
+	sessionID string
+}
+
+// WithSession scopes the memories of a run to userID and records sessionID as
+// the source of the memories extracted from it. Runs without it share the
+// memories of user "default".
+func WithSession(ctx context.Context, userID, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionKey{}, session{userID: userID, sessionID: sessionID})
+}
+
+func sessionFrom(ctx context.Context) session {
+	s, _ := ctx.Value(sessionKey{}).(session)
+	if s.userID == "" {
+		s.userID = "default"
+	}
+	return s
+}
+
+// NewMiddleware creates the long-term memory middleware.
+func NewMiddleware(_ context.Context, config *Config) (adk.ChatModelAgentMiddleware, error) {
+	if config == nil || config.Store == nil || config.Model == nil {
+		return nil, errors.New("long-term memory needs a store and a model")
+	}
+	m := &middleware{
+		BaseChatModelAgentMiddleware: &adk.BaseChatModelAgentMiddleware{},
+		store:                        config.Store,
+		model:                        config.Model,
+		topK:                         config.TopK,
+		ranking:                      config.Ranking,
+		maxCandidates:                config.MaxCandidates,
+		locks:                        make(map[string]*userLock),
+	}
+	if m.topK <= 0 {
+		m.topK = 5
+	}
+	if m.maxCandidates <= 0 {
+		m.maxCandidates = 20
+	}
+	return m, nil
+}
+
+type middleware struct {
+	*adk.BaseChatModelAgentMiddleware
+
+	store         memory.MemoryStore
+	model         model.BaseChatModel
+	topK          int
+	ranking       memory.Ranking
+	maxCandidates int
+
+	// locks serializes consolidation per user, so concurrent turns of one user
+	// do not mint the same memory IDs or consolidate against a stale view,
+	// while turns of other users go ahead.
+	mu    sync.Mutex
+	locks map[string]*userLock
+}
+
+type userLock struct {
+	sync.Mutex
+	refs int // turns holding or waiting for the lock
+}
+
+// lock locks the memories of userID and returns the function that unlocks them.
+func (m *middleware) lock(userID string) func() {
+	m.mu.Lock()
+	l := m.locks[userID]
+	if l == nil {
+		l = &userLock{}
+		m.locks[userID] = l
+	}
+	l.refs++
+	m.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		m.mu.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(m.locks, userID)
+		}
+		m.mu.Unlock()
+	}
+}
+
+// BeforeModelRewriteState injects the memories relevant to the latest user
+// message into the system prompt. Recall failures are logged; the turn runs
+// without memories.
+func (m *middleware) BeforeModelRewriteState(ctx context.Context, state *adk.ChatModelAgentState, _ *adk.ModelContext) (context.Context, *adk.ChatModelAgentState, error) {
+	sys := -1
+	for i, msg := range state.Messages {
+		if msg.Role == schema.System {
+			if _, ok := msg.Extra[extraInjected]; ok {
+				return ctx, state, nil
+			}
+			if sys < 0 {
+				sys = i
+			}
+		}
+	}
+	query, _ := lastUserMessage(state.Messages)
+	if query < 0 {
+		return ctx, state, nil
+	}
+
+	mems, err := recall(ctx, m.book(ctx), state.Messages[query].Content, m.ranking, m.topK)
+	if err != nil {
+		log.Printf("warn: recalling long-term memories failed: %v", err)
+		return ctx, state, nil
+	}
+	if len(mems) == 0 {
+		return ctx, state, nil
+	}
+
+	var prompt *schema.Message
+	msgs := make([]*schema.Message, 0, len(state.Messages)+1)
+	if sys >= 0 {
+		cp := *state.Messages[sys]
+		cp.Content = strings.TrimRight(cp.Content, "\n") + "\n\n" + formatMemories(mems)
+		prompt = &cp
+		msgs = append(msgs, state.Messages[:sys]...)
+		msgs = append(msgs, prompt)
+		msgs = append(msgs, state.Messages[sys+1:]...)
+	} else {
+		prompt = schema.SystemMessage(formatMemories(mems))
+		msgs = append(append(msgs, prompt), state.Messages...)
+	}
+	prompt.Extra = maps.Clone(prompt.Extra)
+	if prompt.Extra == nil {
+		prompt.Extra = make(map[string]any)
+	}
+	prompt.Extra[extraInjected] = true
+
+	next := *state
+	next.Messages = msgs
+	return ctx, &next, nil
+}
+
+// AfterAgent extracts memories from the finished turn and consolidates them.
+// Failures are logged rather than returned: losing a memory must not fail a
+// turn the user already got an answer for.
+func (m *middleware) AfterAgent(ctx context.Context, state *adk.ChatModelAgentState) (context.Context, error) {
+	defer m.lock(sessionFrom(ctx).userID)()
+	if err := m.consolidate(ctx, state.Messages); err != nil {
+		log.Printf("warn: long-term memories not updated: %v", err)
+	}
+	return ctx, nil
+}
+
+func (m *middleware) book(ctx context.Context) *book {
+	s := sessionFrom(ctx)
+	return &book{store: m.store, key: storeKey(s.userID)}
+}
+
+// formatMemories renders memories as a system prompt section.
+func formatMemories(mems []Memory) string {
+	var b strings.Builder
+	b.WriteString("<long_term_memory>\n")
+	b.WriteString("What you remember about the user from earlier conversations. Use it when relevant; what the user says now takes precedence.\n")
+	for _, mem := range mems {
+		fmt.Fprintf(&b, "- [%s] %s\n", mem.Kind, mem.Content)
+	}
+	b.WriteString("</long_term_memory>")
+	return b.String()
+}
+
+// lastUserMessage returns the index of the last user message and the number
+// of non-system messages before it, or -1 if there is none.
+func lastUserMessage(msgs []*schema.Message) (int, int64) {
+	idx, seq := -1, int64(0)
+	var n int64
+	for i, msg := range msgs {
+		if msg.Role == schema.System {
+			continue
+		}
+		if msg.Role == schema.User {
+			idx, seq = i, n
+		}
+		n++
+	}
+	return idx, seq
+}
@@ -0,0 +1,219 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package longterm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/adk"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/flow/agent/react/memory_example/memory"
+)
+
+// scriptedModel replies with the next canned answer and records the prompts.
+type scriptedModel struct {
+	replies []string
+	prompts []string
+}
+
+func (m *scriptedModel) Generate(_ context.Context, in []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	m.prompts = append(m.prompts, in[len(in)-1].Content)
+	if len(m.replies) == 0 {
+		return nil, errors.New("no reply scripted")
+	}
+	reply := m.replies[0]
+	m.replies = m.replies[1:]
+	return schema.AssistantMessage(reply, nil), nil
+}
+
+func (m *scriptedModel) Stream(context.Context, []*schema.Message, ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestMiddleware(t *testing.T) {
+	cli, closer, err := memory.NewMiniRedisClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closer()
+
+	for name, store := range map[string]memory.MemoryStore{
+		"inmem": memory.NewInMemoryStore(),
+		"redis": memory.NewRedisStore(cli),
+	} {
+		t.Run(name, func(t *testing.T) {
+			testMiddleware(t, store)
+		})
+	}
+}
+
+func testMiddleware(t *testing.T, store memory.MemoryStore) {
+	cm := &scriptedModel{replies: []string{
+		// Turn 1: two memories, one with an explicit source.
+		`{"operations": [
+			{"op": "add", "kind": "preference", "content": "The user is vegetarian.", "source": 0},
+			{"op": "add", "kind": "fact", "content": "The user lives in Hangzhou.", "source": 0},
+			{"op": "add", "kind": "fact", "content": "the user is   vegetarian"}
+		]}`,
+		// Turn 2: the user moved and likes spicy food; unknown IDs are dropped.
+		"```json\n" + `{"operations": [
+			{"op": "update", "id": "m1", "content": "The user lives in Beijing.", "source": 2},
+			{"op": "merge", "ids": ["m0", "m9"], "kind": "preference", "content": "The user is vegetarian and likes spicy food.", "source": 2},
+			{"op": "delete", "id": "m42"}
+		]}` + "\n```",
+		"not json",
+	}}
+	mw, err := NewMiddleware(context.Background(), &Config{Store: store, Model: cm, TopK: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := WithSession(context.Background(), "alice", "s1")
+
+	turn1 := []*schema.Message{
+		schema.SystemMessage("You are helpful."),
+		schema.UserMessage("I'm vegetarian and live in Hangzhou."),
+		schema.AssistantMessage("Noted!", nil),
+	}
+	if _, err := mw.AfterAgent(ctx, &adk.ChatModelAgentState{Messages: turn1}); err != nil {
+		t.Fatal(err)
+	}
+	mems, err := List(ctx, store, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mems) != 2 || mems[0].ID != "m1" || mems[1].ID != "m0" {
+		t.Fatalf("memories after turn 1 = %+v", mems)
+	}
+	if src := mems[1].Source; src.SessionID != "s1" || src.Seq != 0 || src.Role != "user" || !strings.Contains(src.Excerpt, "vegetarian") {
+		t.Fatalf("source = %+v", src)
+	}
+
+	turn2 := append(turn1, schema.UserMessage("I moved to Beijing and I love spicy food."), schema.AssistantMessage("Great!", nil))
+	if _, err := mw.AfterAgent(ctx, &adk.ChatModelAgentState{Messages: turn2}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(cm.prompts[1], "id=m0 [preference] The user is vegetarian.") ||
+		!strings.Contains(cm.prompts[1], "[2] user: I moved to Beijing") {
+		t.Fatalf("consolidation prompt = %s", cm.prompts[1])
+	}
+	mems, err = List(ctx, store, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]Memory)
+	for _, mem := range mems {
+		got[mem.ID] = mem
+	}
+	if len(got) != 2 || got["m1"].Content != "The user lives in Beijing." || got["m1"].Source.Seq != 2 {
+		t.Fatalf("memories after turn 2 = %+v", mems)
+	}
+	var merged Memory
+	for _, mem := range mems {
+		if mem.ID != "m1" {
+			merged = mem
+		}
+	}
+	if merged.Kind != KindPreference || len(merged.Merged) != 1 || merged.Merged[0] != "m0" {
+		t.Fatalf("merged memory = %+v", merged)
+	}
+
+	// A malformed reply leaves the memories alone and does not fail the turn.
+	if _, err := mw.AfterAgent(ctx, &adk.ChatModelAgentState{Messages: turn2}); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := List(ctx, store, "alice"); len(again) != 2 {
+		t.Fatalf("memories after a bad reply = %+v", again)
+	}
+
+	// The next turn gets the most relevant memory in its system prompt, once.
+	next := []*schema.Message{schema.SystemMessage("You are helpful."), schema.UserMessage("Which city should I search restaurants in? I live where?")}
+	_, state, err := mw.BeforeModelRewriteState(ctx, &adk.ChatModelAgentState{Messages: next}, &adk.ModelContext{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sys := state.Messages[0].Content
+	if !strings.HasPrefix(sys, "You are helpful.\n\n<long_term_memory>") || !strings.Contains(sys, "- [fact] The user lives in Beijing.") || strings.Contains(sys, "vegetarian") {
+		t.Fatalf("system prompt = %q", sys)
+	}
+	if next[0].Content != "You are helpful." {
+		t.Fatal("the input message was modified")
+	}
+	_, again, err := mw.BeforeModelRewriteState(ctx, state, &adk.ModelContext{})
+	if err != nil || again.Messages[0].Content != sys {
+		t.Fatalf("memories injected twice: %q", again.Messages[0].Content)
+	}
+
+	// Other users have their own memories.
+	other := WithSession(context.Background(), "bob", "s2")
+	_, state, _ = mw.BeforeModelRewriteState(other, &adk.ChatModelAgentState{Messages: next}, &adk.ModelContext{})
+	if state.Messages[0].Content != "You are helpful." {
+		t.Fatalf("bob got alice's memories: %q", state.Messages[0].Content)
+	}
+}
+
+// blockingModel holds the consolidation of user "slow" until release is closed.
+type blockingModel struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (m *blockingModel) Generate(_ context.Context, in []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	if strings.Contains(in[len(in)-1].Content, "slow turn") {
+		close(m.started)
+		<-m.release
+	}
+	return schema.AssistantMessage(`{"operations": []}`, nil), nil
+}
+
+func (m *blockingModel) Stream(context.Context, []*schema.Message, ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestConsolidationLockedPerUser(t *testing.T) {
+	cm := &blockingModel{started: make(chan struct{}), release: make(chan struct{})}
+	mw, err := NewMiddleware(context.Background(), &Config{Store: memory.NewInMemoryStore(), Model: cm})
+	if err != nil {
+		t.Fatal(err)
+	}
+	turn := func(user, text string) error {
+		_, err := mw.AfterAgent(WithSession(context.Background(), user, "s"), &adk.ChatModelAgentState{
+			Messages: []*schema.Message{schema.UserMessage(text), schema.AssistantMessage("ok", nil)},
+		})
+		return err
+	}
+
+	slow := make(chan error, 1)
+	go func() { slow <- turn("slow", "slow turn") }()
+	<-cm.started
+
+	// Another user's turn does not wait for the slow one.
+	if err := turn("fast", "fast turn"); err != nil {
+		t.Fatal(err)
+	}
+	close(cm.release)
+	if err := <-slow; err != nil {
+		t.Fatal(err)
+	}
+	if n := len(mw.(*middleware).locks); n != 0 {
+		t.Fatalf("%d user locks left", n)
+	}
+}
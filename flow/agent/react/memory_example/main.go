@@ -23,6 +23,7 @@ import (
 	"io"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/cloudwego/eino-ext/components/model/openai"
 	"github.com/cloudwego/eino/components/tool"
@@ -69,8 +70,7 @@ func main() {
 
 	store := memory.NewInMemoryStore()
 	sessionID := "session:demo"
-
-	verifyGobRoundTrip()
+	started := time.Now()
 
 	run := func(turn string) {
 		fmt.Println("\n========== Turn Start ==========")
@@ -138,13 +138,12 @@ func main() {
 		wg.Wait()
 
 		fmt.Printf("[Produced %d messages this turn]\n", len(produced))
-		_ = store.Write(ctx, sessionID, append(eff, produced...))
+		// Only the new messages are appended; the stored history is never rewritten.
+		_ = store.Append(ctx, sessionID, append([]*schema.Message{schema.UserMessage(turn)}, produced...)...)
 
-		hits, _ := store.Query(ctx, sessionID, "restaurant", 3)
-		fmt.Printf("[Query 'restaurant' hits=%d]\n", len(hits))
-		for i, h := range hits {
-			fmt.Printf("  hit[%d] role=%s content=%s\n", i, h.Role, truncate(h.Content, 60))
-		}
+		printHits("keyword '餐厅'", store, sessionID, memory.Query{Text: "餐厅", Limit: 3})
+		printHits("tool results", store, sessionID, memory.Query{Roles: []schema.RoleType{schema.Tool}, Limit: 3})
+		printHits("since start", store, sessionID, memory.Query{Since: started, ToolNames: []string{"query_dishes"}, Limit: 3})
 		fmt.Println("========== Turn End ==========")
 	}
 
@@ -169,20 +168,16 @@ func printMessage(idx int, m *schema.Message) {
 	}
 }
 
-func verifyGobRoundTrip() {
-	msgs := []*schema.Message{
-		schema.UserMessage("a"),
-		schema.AssistantMessage("b", nil),
-	}
-	b, err := memory.EncodeMessages(msgs)
+func printHits(name string, store memory.MemoryStore, sessionID string, q memory.Query) {
+	hits, err := store.Query(context.Background(), sessionID, q)
 	if err != nil {
-		panic(err)
+		fmt.Printf("[Query %s] error: %v\n", name, err)
+		return
 	}
-	out, err := memory.DecodeMessages(b)
-	if err != nil {
-		panic(err)
+	fmt.Printf("[Query %s hits=%d]\n", name, len(hits))
+	for i, h := range hits {
+		fmt.Printf("  hit[%d] seq=%d score=%.2f role=%s content=%s\n", i, h.Seq, h.Score, h.Message.Role, truncateRunes(h.Message.Content, 60))
 	}
-	fmt.Printf("gob_round_trip=%d\n", len(out))
 }
 
 func truncate(s string, n int) string {
@@ -18,64 +18,69 @@ package memory
 
 import (
 	"context"
-	"strings"
 	"sync"
 
 	"github.com/cloudwego/eino/schema"
 )
 
-// InMemoryStore keeps serialized messages in a process-local map.
+// InMemoryStore keeps records in a process-local map.
 // Suitable for demos/tests; not shared across processes.
 type InMemoryStore struct {
+	opts *options
+
 	mu   sync.RWMutex
-	data map[string][]byte
+	data map[string][]Record
 }
 
-func NewInMemoryStore() *InMemoryStore {
-	return &InMemoryStore{data: make(map[string][]byte)}
+func NewInMemoryStore(opts ...Option) *InMemoryStore {
+	return &InMemoryStore{opts: newOptions(opts), data: make(map[string][]Record)}
 }
 
-// Write encodes and stores messages for the given key.
-func (s *InMemoryStore) Write(ctx context.Context, sessionID string, msgs []*schema.Message) error {
-	b, err := EncodeMessages(msgs)
-	if err != nil {
-		return err
-	}
+// Append stamps and stores messages at the end of the session.
+func (s *InMemoryStore) Append(ctx context.Context, sessionID string, msgs ...*schema.Message) error {
+	records := s.opts.records(ctx, msgs)
 	s.mu.Lock()
-	s.data[sessionID] = b
-	s.mu.Unlock()
+	defer s.mu.Unlock()
+	for _, r := range records {
+		r.Seq = int64(len(s.data[sessionID]))
+		s.data[sessionID] = append(s.data[sessionID], r)
+	}
 	return nil
 }
 
-// Read returns decoded messages for the given session; returns nil if absent.
+// Read returns the messages of the session; returns nil if absent.
 func (s *InMemoryStore) Read(ctx context.Context, sessionID string) ([]*schema.Message, error) {
 	s.mu.RLock()
-	b := s.data[sessionID]
-	s.mu.RUnlock()
-	return DecodeMessages(b)
+	defer s.mu.RUnlock()
+	records := s.data[sessionID]
+	if len(records) == 0 {
+		return nil, nil
+	}
+	msgs := make([]*schema.Message, len(records))
+	for i, r := range records {
+		msgs[i] = r.Message
+	}
+	return msgs, nil
 }
 
-// Query performs a simple substring search on message contents for the session.
-func (s *InMemoryStore) Query(ctx context.Context, sessionID, text string, limit int) ([]*schema.Message, error) {
-	msgs, err := s.Read(ctx, sessionID)
-	if err != nil {
-		return nil, err
-	}
-	if len(msgs) == 0 || text == "" {
+// Tail returns the last n records of the session.
+func (s *InMemoryStore) Tail(ctx context.Context, sessionID string, n int) ([]Record, error) {
+	if n <= 0 {
 		return nil, nil
 	}
-	q := strings.ToLower(text)
-	out := make([]*schema.Message, 0, limit)
-	for _, m := range msgs {
-		if m == nil {
-			continue
-		}
-		if strings.Contains(strings.ToLower(m.Content), q) {
-			out = append(out, m)
-			if limit > 0 && len(out) >= limit {
-				break
-			}
-		}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	records := s.data[sessionID]
+	if n < len(records) {
+		records = records[len(records)-n:]
 	}
-	return out, nil
+	return append([]Record(nil), records...), nil
+}
+
+// Query searches the session's records.
+func (s *InMemoryStore) Query(ctx context.Context, sessionID string, q Query) ([]Hit, error) {
+	s.mu.RLock()
+	records := s.data[sessionID]
+	s.mu.RUnlock()
+	return search(ctx, records, q, s.opts.embedder)
 }
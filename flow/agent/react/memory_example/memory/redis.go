@@ -18,65 +18,106 @@ package memory
 
 import (
 	"context"
-	"strings"
+	"encoding/json"
+	"fmt"
 
 	miniredis "github.com/alicebob/miniredis/v2"
 	"github.com/cloudwego/eino/schema"
 	"github.com/redis/go-redis/v9"
 )
 
-// RedisStore persists serialized messages in Redis under the provided session key.
+// RedisStore keeps each session as a Redis list of JSON records under the
+// session key. Append is a single RPUSH of the new records, so the history is
+// never rewritten.
 type RedisStore struct {
-	cli *redis.Client
+	cli  *redis.Client
+	opts *options
 }
 
-func NewRedisStore(cli *redis.Client) *RedisStore {
-	return &RedisStore{cli: cli}
+func NewRedisStore(cli *redis.Client, opts ...Option) *RedisStore {
+	return &RedisStore{cli: cli, opts: newOptions(opts)}
 }
 
-// Write encodes and stores messages using Redis SET.
-func (s *RedisStore) Write(ctx context.Context, sessionID string, msgs []*schema.Message) error {
-	b, err := EncodeMessages(msgs)
-	if err != nil {
-		return err
+// Append stamps messages and pushes them onto the session list.
+func (s *RedisStore) Append(ctx context.Context, sessionID string, msgs ...*schema.Message) error {
+	records := s.opts.records(ctx, msgs)
+	if len(records) == 0 {
+		return nil
+	}
+	values := make([]any, len(records))
+	for i, r := range records {
+		b, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		values[i] = b
 	}
-	return s.cli.Set(ctx, sessionID, b, 0).Err()
+	return s.cli.RPush(ctx, sessionID, values...).Err()
 }
 
-// Read returns decoded messages from Redis GET; returns nil if not found.
+// Read returns the messages of the session; returns nil if not found.
 func (s *RedisStore) Read(ctx context.Context, sessionID string) ([]*schema.Message, error) {
-	res, err := s.cli.Get(ctx, sessionID).Bytes()
-	if err == redis.Nil {
+	records, err := s.load(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
 		return nil, nil
 	}
-	if err != nil {
+	msgs := make([]*schema.Message, len(records))
+	for i, r := range records {
+		msgs[i] = r.Message
+	}
+	return msgs, nil
+}
+
+// Tail returns the last n records of the session with LRANGE.
+func (s *RedisStore) Tail(ctx context.Context, sessionID string, n int) ([]Record, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	var (
+		length *redis.IntCmd
+		values *redis.StringSliceCmd
+	)
+	// LLEN and LRANGE run in one transaction so the sequence numbers match.
+	if _, err := s.cli.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		length = p.LLen(ctx, sessionID)
+		values = p.LRange(ctx, sessionID, int64(-n), -1)
+		return nil
+	}); err != nil {
 		return nil, err
 	}
-	return DecodeMessages(res)
+	return decodeRecords(values.Val(), length.Val()-int64(len(values.Val())))
 }
 
-func (s *RedisStore) Query(ctx context.Context, sessionID, text string, limit int) ([]*schema.Message, error) {
-	msgs, err := s.Read(ctx, sessionID)
+// Query loads the session's records and searches them.
+func (s *RedisStore) Query(ctx context.Context, sessionID string, q Query) ([]Hit, error) {
+	records, err := s.load(ctx, sessionID)
 	if err != nil {
 		return nil, err
 	}
-	if len(msgs) == 0 || text == "" {
-		return nil, nil
+	return search(ctx, records, q, s.opts.embedder)
+}
+
+func (s *RedisStore) load(ctx context.Context, sessionID string) ([]Record, error) {
+	values, err := s.cli.LRange(ctx, sessionID, 0, -1).Result()
+	if err != nil {
+		return nil, err
 	}
-	out := make([]*schema.Message, 0, limit)
-	q := strings.ToLower(text)
-	for _, m := range msgs {
-		if m == nil {
-			continue
-		}
-		if strings.Contains(strings.ToLower(m.Content), q) {
-			out = append(out, m)
-			if limit > 0 && len(out) >= limit {
-				break
-			}
+	return decodeRecords(values, 0)
+}
+
+// decodeRecords decodes list values whose first element is at position first.
+func decodeRecords(values []string, first int64) ([]Record, error) {
+	records := make([]Record, len(values))
+	for i, v := range values {
+		if err := json.Unmarshal([]byte(v), &records[i]); err != nil {
+			return nil, fmt.Errorf("decode record %d: %w", first+int64(i), err)
 		}
+		records[i].Seq = first + int64(i)
 	}
-	return out, nil
+	return records, nil
 }
 
 // NewMiniRedisClient starts an embedded Redis server for local demos/tests.
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/components/embedding"
+
+	"github.com/cloudwego/eino-examples/components/retriever/rank"
+)
+
+// search filters records by q and ranks the rest. Both stores load the
+// session's records and delegate here, so they answer queries identically.
+func search(ctx context.Context, records []Record, q Query, embedder embedding.Embedder) ([]Hit, error) {
+	var hits []Hit
+	for _, r := range records {
+		if matches(r, q) {
+			hits = append(hits, Hit{Record: r})
+		}
+	}
+
+	ranking := q.Ranking
+	if ranking == RankAuto {
+		ranking = RankRecent
+		if strings.TrimSpace(q.Text) != "" {
+			ranking = RankKeyword
+		}
+	}
+	switch ranking {
+	case RankKeyword:
+		hits = bm25(hits, q.Text)
+	case RankSemantic:
+		if embedder == nil {
+			return nil, errors.New("semantic query needs a store created WithEmbedder")
+		}
+		vectors, err := embedder.EmbedStrings(ctx, []string{q.Text})
+		if err != nil {
+			return nil, err
+		}
+		if len(vectors) != 1 {
+			return nil, errors.New("embedder returned no vector for the query")
+		}
+		hits = nearest(hits, vectors[0])
+	default:
+		sort.SliceStable(hits, func(i, j int) bool { return hits[i].Seq > hits[j].Seq })
+	}
+
+	if q.Limit > 0 && len(hits) > q.Limit {
+		hits = hits[:q.Limit]
+	}
+	return hits, nil
+}
+
+func matches(r Record, q Query) bool {
+	if !q.Since.IsZero() && r.Time.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && !r.Time.Before(q.Until) {
+		return false
+	}
+	if len(q.Roles) > 0 && !slices.Contains(q.Roles, r.Message.Role) {
+		return false
+	}
+	if len(q.ToolNames) > 0 {
+		found := slices.Contains(q.ToolNames, r.Message.ToolName)
+		for _, tc := range r.Message.ToolCalls {
+			found = found || slices.Contains(q.ToolNames, tc.Function.Name)
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// bm25 scores hits against query and keeps those sharing at least one term,
+// best first and newest first on ties.
+func bm25(hits []Hit, query string) []Hit {
+	terms := make([][]string, len(hits))
+	df := make(map[string]int)
+	total := 0
+	for i, h := range hits {
+		terms[i] = rank.Tokenize(messageText(h.Message))
+		total += len(terms[i])
+		seen := make(map[string]bool)
+		for _, t := range terms[i] {
+			if !seen[t] {
+				seen[t] = true
+				df[t]++
+			}
+		}
+	}
+	if len(hits) == 0 {
+		return nil
+	}
+	avgLen := float64(total) / float64(len(hits))
+	n := float64(len(hits))
+
+	queryTerms := slices.Compact(slices.Sorted(slices.Values(rank.Tokenize(query))))
+	var out []Hit
+	for i, h := range hits {
+		tf := make(map[string]int)
+		for _, t := range terms[i] {
+			tf[t]++
+		}
+		for _, t := range queryTerms {
+			if f := float64(tf[t]); f > 0 {
+				h.Score += rank.BM25(rank.IDF(n, float64(df[t])), f, float64(len(terms[i])), avgLen)
+			}
+		}
+		if h.Score > 0 {
+			out = append(out, h)
+		}
+	}
+	sortByScore(out)
+	return out
+}
+
+// nearest scores hits by cosine similarity to qvec. Records stored without a
+// vector are dropped.
+func nearest(hits []Hit, qvec []float64) []Hit {
+	var out []Hit
+	for _, h := range hits {
+		if len(h.Vector) == len(qvec) && len(qvec) > 0 {
+			h.Score = rank.Cosine(h.Vector, qvec)
+			out = append(out, h)
+		}
+	}
+	sortByScore(out)
+	return out
+}
+
+func sortByScore(hits []Hit) {
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Seq > hits[j].Seq
+	})
+}
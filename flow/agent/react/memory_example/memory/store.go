@@ -17,42 +17,137 @@
 package memory
 
 import (
-	"bytes"
 	"context"
-	"encoding/gob"
+	"log"
+	"strings"
+	"time"
 
+	"github.com/cloudwego/eino/components/embedding"
 	"github.com/cloudwego/eino/schema"
 )
 
-// MemoryStore persists and restores short-term conversation history.
-// Implementations are responsible for storing a slice of messages under a session key.
+// MemoryStore persists conversation history as an append-only log per session
+// and lets an agent recall relevant older messages without re-reading the
+// whole session.
 type MemoryStore interface {
-	Write(ctx context.Context, sessionID string, msgs []*schema.Message) error
+	// Append adds messages to the end of the session, stamped with the current time.
+	Append(ctx context.Context, sessionID string, msgs ...*schema.Message) error
+	// Read returns every message of the session in order.
 	Read(ctx context.Context, sessionID string) ([]*schema.Message, error)
-	Query(ctx context.Context, sessionID, text string, limit int) ([]*schema.Message, error)
+	// Tail returns the last n records of the session in order.
+	Tail(ctx context.Context, sessionID string, n int) ([]Record, error)
+	// Query returns the records matching q, best first.
+	Query(ctx context.Context, sessionID string, q Query) ([]Hit, error)
 }
 
-// Gob registrations for eino message types are provided by the framework; no manual registration needed here.
+// Record is one stored message.
+type Record struct {
+	Seq     int64           `json:"-"` // position in the session, from 0
+	Time    time.Time       `json:"time"`
+	Message *schema.Message `json:"message"`
+	Vector  []float64       `json:"vector,omitempty"` // set when the store has an embedder
+}
+
+// Hit is a query result.
+type Hit struct {
+	Record
+	Score float64 // BM25 or cosine score; 0 for RankRecent
+}
+
+// Ranking orders query results.
+type Ranking int
+
+const (
+	// RankAuto uses RankKeyword when the query has text, RankRecent otherwise.
+	RankAuto Ranking = iota
+	// RankRecent returns the newest records first.
+	RankRecent
+	// RankKeyword ranks records by BM25 over their text.
+	RankKeyword
+	// RankSemantic ranks records by cosine similarity of their embedding to
+	// the embedding of the query text. It needs a store created WithEmbedder.
+	RankSemantic
+)
+
+// Query selects and ranks records. Zero-valued filters match everything.
+type Query struct {
+	Text    string
+	Ranking Ranking
+	// Since and Until bound the record time as [Since, Until).
+	Since, Until time.Time
+	// Roles keeps records with one of these roles.
+	Roles []schema.RoleType
+	// ToolNames keeps tool calls to, and results from, one of these tools.
+	ToolNames []string
+	// Limit caps the number of hits; 0 means no limit.
+	Limit int
+}
+
+// Option configures a store.
+type Option func(*options)
+
+type options struct {
+	embedder embedding.Embedder
+	now      func() time.Time
+}
 
-// EncodeMessages serializes messages using Gob.
-func EncodeMessages(msgs []*schema.Message) ([]byte, error) {
-	var buf bytes.Buffer
-	enc := gob.NewEncoder(&buf)
-	if err := enc.Encode(msgs); err != nil {
-		return nil, err
+// WithEmbedder embeds every appended message so RankSemantic queries work.
+func WithEmbedder(e embedding.Embedder) Option {
+	return func(o *options) { o.embedder = e }
+}
+
+// WithClock replaces time.Now for record timestamps.
+func WithClock(now func() time.Time) Option {
+	return func(o *options) { o.now = now }
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{now: time.Now}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// records stamps msgs and, with an embedder, embeds them. An embedding
+// failure is logged and the messages are stored without vectors, so they are
+// still found by keyword and filters.
+func (o *options) records(ctx context.Context, msgs []*schema.Message) []Record {
+	now := o.now()
+	out := make([]Record, 0, len(msgs))
+	for _, m := range msgs {
+		if m != nil {
+			out = append(out, Record{Time: now, Message: m})
+		}
+	}
+	if o.embedder == nil || len(out) == 0 {
+		return out
+	}
+	texts := make([]string, len(out))
+	for i, r := range out {
+		texts[i] = messageText(r.Message)
+	}
+	vectors, err := o.embedder.EmbedStrings(ctx, texts)
+	if err != nil || len(vectors) != len(out) {
+		log.Printf("warn: embedding %d messages failed, storing without vectors: %v", len(out), err)
+		return out
+	}
+	for i := range out {
+		out[i].Vector = vectors[i]
 	}
-	return buf.Bytes(), nil
+	return out
 }
 
-// DecodeMessages deserializes messages previously encoded by EncodeMessages.
-func DecodeMessages(b []byte) ([]*schema.Message, error) {
-	if len(b) == 0 {
-		return nil, nil
+// messageText is the searchable text of a message: its content, plus the
+// names and arguments of the tools it calls.
+func messageText(m *schema.Message) string {
+	var b strings.Builder
+	b.WriteString(m.Content)
+	if m.ToolName != "" {
+		b.WriteString(" " + m.ToolName)
 	}
-	dec := gob.NewDecoder(bytes.NewReader(b))
-	var msgs []*schema.Message
-	if err := dec.Decode(&msgs); err != nil {
-		return nil, err
+	for _, tc := range m.ToolCalls {
+		b.WriteString(" " + tc.Function.Name + " " + tc.Function.Arguments)
 	}
-	return msgs, nil
+	return b.String()
 }
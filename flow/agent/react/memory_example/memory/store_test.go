@@ -0,0 +1,120 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+)
+
+// fakeEmbedder embeds text as counts of a few keywords.
+type fakeEmbedder struct{}
+
+func (fakeEmbedder) EmbedStrings(_ context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	out := make([][]float64, len(texts))
+	for i, text := range texts {
+		lower := strings.ToLower(text)
+		out[i] = []float64{
+			float64(strings.Count(lower, "food") + strings.Count(lower, "dish") + strings.Count(lower, "restaurant")),
+			float64(strings.Count(lower, "weather") + strings.Count(lower, "rain")),
+		}
+	}
+	return out, nil
+}
+
+func TestStores(t *testing.T) {
+	cli, closer, err := NewMiniRedisClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closer()
+
+	for name, newStore := range map[string]func(...Option) MemoryStore{
+		"inmem": func(opts ...Option) MemoryStore { return NewInMemoryStore(opts...) },
+		"redis": func(opts ...Option) MemoryStore { return NewRedisStore(cli, opts...) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			testStore(t, newStore, name+":session")
+		})
+	}
+}
+
+func testStore(t *testing.T, newStore func(...Option) MemoryStore, sessionID string) {
+	ctx := context.Background()
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	now := start
+	store := newStore(WithEmbedder(fakeEmbedder{}), WithClock(func() time.Time { return now }))
+
+	call := schema.AssistantMessage("", []schema.ToolCall{{ID: "1", Function: schema.FunctionCall{Name: "query_restaurants", Arguments: `{"city":"Beijing"}`}}})
+	turns := [][]*schema.Message{
+		{schema.UserMessage("Find me a restaurant in Beijing."), call, schema.ToolMessage(`[{"name":"Roast Duck House"}]`, "1", schema.WithToolName("query_restaurants"))},
+		{schema.UserMessage("Will it rain tomorrow?"), schema.AssistantMessage("The weather looks dry.", nil)},
+		{schema.UserMessage("What dish should I order at the first restaurant?"), schema.AssistantMessage("Try the roast duck.", nil)},
+	}
+	for _, turn := range turns {
+		if err := store.Append(ctx, sessionID, turn...); err != nil {
+			t.Fatal(err)
+		}
+		now = now.Add(time.Hour)
+	}
+
+	msgs, err := store.Read(ctx, sessionID)
+	if err != nil || len(msgs) != 7 || msgs[1].ToolCalls[0].Function.Name != "query_restaurants" {
+		t.Fatalf("read = %d messages, %v", len(msgs), err)
+	}
+
+	tail, err := store.Tail(ctx, sessionID, 2)
+	if err != nil || len(tail) != 2 || tail[0].Seq != 5 || tail[1].Message.Content != "Try the roast duck." {
+		t.Fatalf("tail = %+v, %v", tail, err)
+	}
+
+	seqs := func(q Query) []int64 {
+		t.Helper()
+		hits, err := store.Query(ctx, sessionID, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out []int64
+		for _, h := range hits {
+			out = append(out, h.Seq)
+		}
+		return out
+	}
+	check := func(name string, got []int64, want ...int64) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("%s = %v, want %v", name, got, want)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("%s = %v, want %v", name, got, want)
+			}
+		}
+	}
+
+	check("keyword", seqs(Query{Text: "roast duck", Limit: 2}), 6, 2)
+	check("recent", seqs(Query{Limit: 3}), 6, 5, 4)
+	check("window", seqs(Query{Since: start.Add(time.Hour), Until: start.Add(2 * time.Hour)}), 4, 3)
+	check("role", seqs(Query{Roles: []schema.RoleType{schema.User}, Text: "restaurant"}), 0, 5)
+	check("tool", seqs(Query{ToolNames: []string{"query_restaurants"}}), 2, 1)
+	check("semantic", seqs(Query{Text: "rainy weather", Ranking: RankSemantic, Limit: 2}), 4, 3)
+}
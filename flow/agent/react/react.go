@@ -18,9 +18,7 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"fmt"
 	"io"
 	"os"
 	"time"
@@ -35,6 +33,7 @@ import (
 	"github.com/cloudwego/eino/schema"
 	"github.com/coze-dev/cozeloop-go"
 
+	"github.com/cloudwego/eino-examples/devops/callbacklog"
 	"github.com/cloudwego/eino-examples/devops/visualize"
 	"github.com/cloudwego/eino-examples/flow/agent/react/tools"
 	"github.com/cloudwego/eino-examples/internal/logs"
@@ -142,8 +141,21 @@ func main() {
 	//	},
 	//}
 
+	// log model and tool runs to the terminal, and as JSON lines to REACT_LOG_FILE if set
+	sinks := []callbacklog.Sink{callbacklog.NewPrettySink(os.Stdout)}
+	if logFile := os.Getenv("REACT_LOG_FILE"); logFile != "" {
+		fileSink, err := callbacklog.NewRotatingSink(logFile, 10<<20, 3)
+		if err != nil {
+			logs.Errorf("failed to open log file: %v", err)
+			return
+		}
+		sinks = append(sinks, fileSink)
+	}
+	logHandler := callbacklog.NewHandler(sinks)
+	defer logHandler.Close()
+
 	opt := []agent.AgentOption{
-		agent.WithComposeOptions(compose.WithCallbacks(&LoggerCallback{})),
+		agent.WithComposeOptions(compose.WithCallbacks(logHandler)),
 		// react.WithChatModelOptions(ark.WithCache(cacheOption)),
 	}
 
@@ -196,74 +208,3 @@ func main() {
 	logs.Infof("\n\n===== finished =====\n")
 	time.Sleep(2 * time.Second)
 }
-
-type LoggerCallback struct {
-	callbacks.HandlerBuilder // 可以用 callbacks.HandlerBuilder 来辅助实现 callback
-}
-
-func (cb *LoggerCallback) OnStart(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
-	fmt.Println("==================")
-	inputStr, _ := json.MarshalIndent(input, "", "  ")
-	fmt.Printf("[OnStart] %s\n", string(inputStr))
-	return ctx
-}
-
-func (cb *LoggerCallback) OnEnd(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
-	fmt.Println("=========[OnEnd]=========")
-	outputStr, _ := json.MarshalIndent(output, "", "  ")
-	fmt.Println(string(outputStr))
-	return ctx
-}
-
-func (cb *LoggerCallback) OnError(ctx context.Context, info *callbacks.RunInfo, err error) context.Context {
-	fmt.Println("=========[OnError]=========")
-	fmt.Println(err)
-	return ctx
-}
-
-func (cb *LoggerCallback) OnEndWithStreamOutput(ctx context.Context, info *callbacks.RunInfo,
-	output *schema.StreamReader[callbacks.CallbackOutput],
-) context.Context {
-	graphInfoName := react.GraphName
-
-	go func() {
-		defer func() {
-			if err := recover(); err != nil {
-				fmt.Println("[OnEndStream] panic err:", err)
-			}
-		}()
-
-		defer output.Close() // remember to close the stream in defer
-
-		fmt.Println("=========[OnEndStream]=========")
-		for {
-			frame, err := output.Recv()
-			if errors.Is(err, io.EOF) {
-				// finish
-				break
-			}
-			if err != nil {
-				fmt.Printf("internal error: %s\n", err)
-				return
-			}
-
-			s, err := json.Marshal(frame)
-			if err != nil {
-				fmt.Printf("internal error: %s\n", err)
-				return
-			}
-
-			if info.Name == graphInfoName { // 仅打印 graph 的输出, 否则每个 stream 节点的输出都会打印一遍
-				fmt.Printf("%s: %s\n", info.Name, string(s))
-			}
-		}
-	}()
-	return ctx
-}
-
-func (cb *LoggerCallback) OnStartWithStreamInput(ctx context.Context, info *callbacks.RunInfo,
-	input *schema.StreamReader[callbacks.CallbackInput],
-) context.Context {
-	defer input.Close()
-	return ctx
-}
@@ -0,0 +1,196 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conformance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cloudwego/eino/adk"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/quickstart/chatwitheino/a2ui"
+)
+
+// Recording is a captured adk event sequence that can be replayed through the
+// a2ui streamers. Recordings use *schema.Message so they stay readable as JSON.
+//
+//	{
+//	  "session_id": "s1",
+//	  "history": [{"role":"user","content":"hi"}],
+//	  "events":   [{"message":{"role":"assistant","content":"hello"}}, {"exit":true}],
+//	  "resume":   [...]   // optional: replayed through StreamContinue afterwards
+//	}
+type Recording struct {
+	SessionID string            `json:"session_id"`
+	History   []*schema.Message `json:"history,omitempty"`
+	Events    []RecordedEvent   `json:"events,omitempty"`
+	Resume    []RecordedEvent   `json:"resume,omitempty"`
+
+	// RenderOnly replays History through RenderBranch instead of running events,
+	// with Siblings describing each history message.
+	RenderOnly bool            `json:"render_only,omitempty"`
+	Siblings   []a2ui.Siblings `json:"siblings,omitempty"`
+	// WantErr marks recordings whose event stream ends in an agent error.
+	WantErr bool `json:"want_err,omitempty"`
+}
+
+// RecordedEvent is one adk event. Exactly one of Message, Stream, Interrupt or
+// Err is normally set; Exit may accompany Message or Stream.
+type RecordedEvent struct {
+	Message   *schema.Message    `json:"message,omitempty"`
+	Stream    []*schema.Message  `json:"stream,omitempty"`
+	StreamErr string             `json:"stream_err,omitempty"` // returned by Recv after the last chunk
+	Interrupt *RecordedInterrupt `json:"interrupt,omitempty"`
+	Err       string             `json:"err,omitempty"`
+	Exit      bool               `json:"exit,omitempty"`
+}
+
+// RecordedInterrupt is the root-cause interrupt context of an interrupt event.
+type RecordedInterrupt struct {
+	ID   string `json:"id"`
+	Info string `json:"info"`
+}
+
+// ReplayResult mirrors the values returned by the a2ui streamers.
+type ReplayResult struct {
+	LastContent   string
+	Intermediates []*schema.Message
+	InterruptID   string
+	MsgIdx        int
+	Err           error
+}
+
+// LoadRecording reads a Recording from a JSON file.
+func LoadRecording(path string) (*Recording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rec Recording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("bad recording %s: %w", path, err)
+	}
+	if rec.SessionID == "" {
+		rec.SessionID = "golden"
+	}
+	return &rec, nil
+}
+
+// Iterator turns recorded events into the iterator an agent run would produce.
+func Iterator(events []RecordedEvent) *adk.AsyncIterator[*adk.TypedAgentEvent[*schema.Message]] {
+	iter, gen := adk.NewAsyncIteratorPair[*adk.TypedAgentEvent[*schema.Message]]()
+	defer gen.Close()
+	for _, ev := range events {
+		for _, e := range toAgentEvents(ev) {
+			gen.Send(e)
+		}
+	}
+	return iter
+}
+
+// Replay runs rec through StreamToWriter (or RenderBranch) and, if the first
+// pass interrupted and rec.Resume is set, through StreamContinue, writing all
+// A2UI output to w. The result reflects the last streamer that ran.
+func Replay(w io.Writer, rec *Recording) ReplayResult {
+	if rec.RenderOnly {
+		return ReplayResult{Err: a2ui.RenderBranch(w, rec.SessionID, rec.History, rec.Siblings), MsgIdx: len(rec.History)}
+	}
+
+	var res ReplayResult
+	res.LastContent, res.Intermediates, res.InterruptID, res.MsgIdx, res.Err = a2ui.StreamToWriter(
+		w, rec.SessionID, rec.History, Iterator(rec.Events),
+	)
+	if res.Err != nil || len(rec.Resume) == 0 {
+		return res
+	}
+	if res.InterruptID == "" {
+		res.Err = errors.New("recording has resume events but the first pass did not interrupt")
+		return res
+	}
+	res.LastContent, res.InterruptID, res.MsgIdx, res.Err = a2ui.StreamContinue(
+		w, rec.SessionID, res.MsgIdx, Iterator(rec.Resume),
+	)
+	return res
+}
+
+func toAgentEvents(ev RecordedEvent) []*adk.TypedAgentEvent[*schema.Message] {
+	var out []*adk.TypedAgentEvent[*schema.Message]
+	switch {
+	case ev.Err != "":
+		out = append(out, &adk.TypedAgentEvent[*schema.Message]{Err: errors.New(ev.Err)})
+	case ev.Interrupt != nil:
+		out = append(out, &adk.TypedAgentEvent[*schema.Message]{
+			Action: &adk.AgentAction{Interrupted: &adk.InterruptInfo{
+				InterruptContexts: []*adk.InterruptCtx{{
+					ID:          ev.Interrupt.ID,
+					Info:        ev.Interrupt.Info,
+					IsRootCause: true,
+				}},
+			}},
+		})
+	case ev.Message != nil:
+		out = append(out, outputEvent(&adk.TypedMessageVariant[*schema.Message]{
+			Message: ev.Message,
+			Role:    ev.Message.Role,
+		}, ev.Exit))
+	case len(ev.Stream) > 0 || ev.StreamErr != "":
+		out = append(out, outputEvent(&adk.TypedMessageVariant[*schema.Message]{
+			IsStreaming:   true,
+			MessageStream: streamOf(ev.Stream, ev.StreamErr),
+			Role:          streamRole(ev.Stream),
+		}, ev.Exit))
+	case ev.Exit:
+		out = append(out, &adk.TypedAgentEvent[*schema.Message]{Action: adk.NewExitAction()})
+	}
+	return out
+}
+
+func outputEvent(mv *adk.TypedMessageVariant[*schema.Message], exit bool) *adk.TypedAgentEvent[*schema.Message] {
+	e := &adk.TypedAgentEvent[*schema.Message]{
+		Output: &adk.TypedAgentOutput[*schema.Message]{MessageOutput: mv},
+	}
+	if exit {
+		e.Action = adk.NewExitAction()
+	}
+	return e
+}
+
+func streamOf(chunks []*schema.Message, errText string) *schema.StreamReader[*schema.Message] {
+	if errText == "" {
+		return schema.StreamReaderFromArray(chunks)
+	}
+	sr, sw := schema.Pipe[*schema.Message](len(chunks) + 1)
+	for _, c := range chunks {
+		sw.Send(c, nil)
+	}
+	sw.Send(nil, errors.New(errText))
+	sw.Close()
+	return sr
+}
+
+func streamRole(chunks []*schema.Message) schema.RoleType {
+	for _, c := range chunks {
+		if c != nil && c.Role != "" {
+			return c.Role
+		}
+	}
+	return schema.Assistant
+}
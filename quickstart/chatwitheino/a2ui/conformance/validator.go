@@ -0,0 +1,355 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package conformance checks that an A2UI JSONL stream is a valid v0.8
+// message sequence and replays recorded adk event sequences through the a2ui
+// streamers so their output can be compared against golden files.
+package conformance
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino-examples/quickstart/chatwitheino/a2ui"
+)
+
+// Rule names reported in Violation.Rule.
+const (
+	RuleEnvelope       = "envelope"        // exactly one message field is populated
+	RuleBeginRendering = "begin-rendering" // updates are preceded by beginRendering
+	RuleComponent      = "component"       // components have an ID and exactly one type
+	RuleChildRef       = "child-ref"       // every referenced child ID is defined
+	RuleCycle          = "cycle"           // the component tree reachable from root is acyclic
+	RuleDataBinding    = "data-binding"    // every dataKey has a dataModelUpdate
+	RuleDeletedSurface = "deleted-surface" // deleted surfaces receive no further updates
+	RuleInterrupt      = "interrupt"       // interruptRequest carries an interrupt ID
+)
+
+// Violation describes one way a stream breaks the A2UI sequencing rules.
+type Violation struct {
+	Line   int    // 1-based message index; 0 for end-of-stream checks
+	Rule   string // one of the Rule* constants
+	Detail string
+}
+
+func (v Violation) Error() string {
+	if v.Line == 0 {
+		return fmt.Sprintf("a2ui %s: %s", v.Rule, v.Detail)
+	}
+	return fmt.Sprintf("a2ui line %d %s: %s", v.Line, v.Rule, v.Detail)
+}
+
+// surfaceState is the renderer-side view of one surface, rebuilt from the stream.
+type surfaceState struct {
+	root       string
+	components map[string]a2ui.ComponentValue
+	data       map[string]bool
+	deleted    bool
+}
+
+// Validator consumes A2UI messages in order and records rule violations.
+// A single Validator may be fed several streams back to back (for example
+// the output of StreamToWriter followed by StreamContinue), mirroring a
+// client that keeps its component tree across requests.
+type Validator struct {
+	line       int
+	surfaces   map[string]*surfaceState
+	violations []Violation
+}
+
+// NewValidator creates an empty Validator.
+func NewValidator() *Validator {
+	return &Validator{surfaces: make(map[string]*surfaceState)}
+}
+
+// Feed checks a single message against the current stream state.
+func (v *Validator) Feed(msg a2ui.Message) {
+	v.line++
+
+	populated := 0
+	for _, set := range []bool{
+		msg.BeginRendering != nil,
+		msg.SurfaceUpdate != nil,
+		msg.DataModelUpdate != nil,
+		msg.DeleteSurface != nil,
+		msg.InterruptRequest != nil,
+	} {
+		if set {
+			populated++
+		}
+	}
+	if populated != 1 {
+		v.report(RuleEnvelope, "message has %d populated fields, want exactly 1", populated)
+		return
+	}
+
+	switch {
+	case msg.BeginRendering != nil:
+		v.beginRendering(msg.BeginRendering)
+	case msg.SurfaceUpdate != nil:
+		v.surfaceUpdate(msg.SurfaceUpdate)
+	case msg.DataModelUpdate != nil:
+		v.dataModelUpdate(msg.DataModelUpdate)
+	case msg.DeleteSurface != nil:
+		v.deleteSurface(msg.DeleteSurface)
+	case msg.InterruptRequest != nil:
+		if msg.InterruptRequest.InterruptID == "" {
+			v.report(RuleInterrupt, "interruptRequest has empty interruptId")
+		}
+	}
+}
+
+// FeedJSONL decodes and feeds every non-empty line of r. It returns an error
+// only when a line is not valid JSON; rule violations are accumulated.
+func (v *Validator) FeedJSONL(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var msg a2ui.Message
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			return fmt.Errorf("a2ui line %d: %w", v.line+1, err)
+		}
+		v.Feed(msg)
+	}
+	return scanner.Err()
+}
+
+// Finish runs the end-of-stream checks and returns every violation found so far.
+// Unresolved child references and data bindings are only reported here, since
+// the streamer may define a child or its data in a later message.
+func (v *Validator) Finish() []Violation {
+	ids := make([]string, 0, len(v.surfaces))
+	for id := range v.surfaces {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		s := v.surfaces[id]
+		if s.deleted {
+			continue
+		}
+		if _, ok := s.components[s.root]; !ok {
+			v.reportAt(0, RuleChildRef, "surface %q root %q is never defined", id, s.root)
+		}
+		for _, compID := range sortedKeys(s.components) {
+			comp := s.components[compID]
+			for _, child := range children(comp) {
+				if _, ok := s.components[child]; !ok {
+					v.reportAt(0, RuleChildRef, "surface %q component %q references undefined child %q", id, compID, child)
+				}
+			}
+			if comp.Text != nil && comp.Text.DataKey != "" && !s.data[comp.Text.DataKey] {
+				v.reportAt(0, RuleDataBinding, "surface %q component %q binds dataKey %q with no dataModelUpdate", id, compID, comp.Text.DataKey)
+			}
+		}
+		if path := findCycle(s.components, s.root); path != nil {
+			v.reportAt(0, RuleCycle, "surface %q has a cycle: %s", id, strings.Join(path, " -> "))
+		}
+	}
+
+	out := make([]Violation, len(v.violations))
+	copy(out, v.violations)
+	return out
+}
+
+// ValidateJSONL validates a complete stream and returns the violations joined
+// into a single error, or nil if the stream conforms.
+func ValidateJSONL(r io.Reader) error {
+	v := NewValidator()
+	if err := v.FeedJSONL(r); err != nil {
+		return err
+	}
+	return Join(v.Finish())
+}
+
+// Join combines violations into one error, or returns nil if there are none.
+func Join(violations []Violation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	errs := make([]error, len(violations))
+	for i, vl := range violations {
+		errs[i] = vl
+	}
+	return errors.Join(errs...)
+}
+
+func (v *Validator) beginRendering(m *a2ui.BeginRenderingMsg) {
+	if m.SurfaceID == "" {
+		v.report(RuleBeginRendering, "beginRendering has empty surfaceId")
+		return
+	}
+	if m.Root == "" {
+		v.report(RuleBeginRendering, "beginRendering for %q has empty root", m.SurfaceID)
+	}
+	// A repeated beginRendering resets the surface, as the renderer does.
+	v.surfaces[m.SurfaceID] = &surfaceState{
+		root:       m.Root,
+		components: make(map[string]a2ui.ComponentValue),
+		data:       make(map[string]bool),
+	}
+}
+
+func (v *Validator) surfaceUpdate(m *a2ui.SurfaceUpdateMsg) {
+	s := v.surface("surfaceUpdate", m.SurfaceID)
+	if s == nil {
+		return
+	}
+	seen := make(map[string]bool, len(m.Components))
+	for _, c := range m.Components {
+		if c.ID == "" {
+			v.report(RuleComponent, "surfaceUpdate for %q has a component with empty id", m.SurfaceID)
+			continue
+		}
+		if seen[c.ID] {
+			v.report(RuleComponent, "component %q is defined twice in one surfaceUpdate", c.ID)
+		}
+		seen[c.ID] = true
+		if n := typeCount(c.Component); n != 1 {
+			v.report(RuleComponent, "component %q has %d component types, want exactly 1", c.ID, n)
+			continue
+		}
+		if c.Component.Text != nil && c.Component.Text.Value != "" && c.Component.Text.DataKey != "" {
+			v.report(RuleComponent, "text component %q sets both value and dataKey", c.ID)
+		}
+		s.components[c.ID] = c.Component
+	}
+}
+
+func (v *Validator) dataModelUpdate(m *a2ui.DataModelUpdateMsg) {
+	s := v.surface("dataModelUpdate", m.SurfaceID)
+	if s == nil {
+		return
+	}
+	for _, dc := range m.Contents {
+		if dc.Key == "" {
+			v.report(RuleDataBinding, "dataModelUpdate for %q has an empty key", m.SurfaceID)
+			continue
+		}
+		s.data[dc.Key] = true
+	}
+}
+
+func (v *Validator) deleteSurface(m *a2ui.DeleteSurfaceMsg) {
+	s := v.surface("deleteSurface", m.SurfaceID)
+	if s == nil {
+		return
+	}
+	s.deleted = true
+}
+
+// surface returns the live state for id, reporting a violation and returning
+// nil if the surface was never begun or has been deleted.
+func (v *Validator) surface(kind, id string) *surfaceState {
+	s, ok := v.surfaces[id]
+	if !ok {
+		v.report(RuleBeginRendering, "%s for %q before beginRendering", kind, id)
+		return nil
+	}
+	if s.deleted {
+		v.report(RuleDeletedSurface, "%s for %q after deleteSurface", kind, id)
+		return nil
+	}
+	return s
+}
+
+func (v *Validator) report(rule, format string, args ...any) {
+	v.reportAt(v.line, rule, format, args...)
+}
+
+func (v *Validator) reportAt(line int, rule, format string, args ...any) {
+	v.violations = append(v.violations, Violation{Line: line, Rule: rule, Detail: fmt.Sprintf(format, args...)})
+}
+
+func typeCount(c a2ui.ComponentValue) int {
+	n := 0
+	for _, set := range []bool{c.Text != nil, c.Column != nil, c.Card != nil, c.Row != nil} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+func children(c a2ui.ComponentValue) []string {
+	switch {
+	case c.Column != nil:
+		return c.Column.Children
+	case c.Card != nil:
+		return c.Card.Children
+	case c.Row != nil:
+		return c.Row.Children
+	}
+	return nil
+}
+
+// findCycle walks the tree from root and returns the first cycle found as a
+// path of component IDs, or nil.
+func findCycle(components map[string]a2ui.ComponentValue, root string) []string {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int)
+	var path []string
+	var walk func(id string) []string
+	walk = func(id string) []string {
+		switch state[id] {
+		case visiting:
+			for i, p := range path {
+				if p == id {
+					return append(append([]string{}, path[i:]...), id)
+				}
+			}
+		case done:
+			return nil
+		}
+		comp, ok := components[id]
+		if !ok {
+			return nil
+		}
+		state[id] = visiting
+		path = append(path, id)
+		for _, child := range children(comp) {
+			if cycle := walk(child); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = done
+		return nil
+	}
+	return walk(root)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,189 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conformance
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino-examples/quickstart/chatwitheino/a2ui"
+)
+
+func text(id, value, dataKey string) a2ui.Component {
+	return a2ui.Component{ID: id, Component: a2ui.ComponentValue{Text: &a2ui.TextComp{Value: value, DataKey: dataKey}}}
+}
+
+func column(id string, children ...string) a2ui.Component {
+	return a2ui.Component{ID: id, Component: a2ui.ComponentValue{Column: &a2ui.ColumnComp{Children: children}}}
+}
+
+func begin(surface string) a2ui.Message {
+	return a2ui.Message{BeginRendering: &a2ui.BeginRenderingMsg{SurfaceID: surface, Root: "root"}}
+}
+
+func update(surface string, comps ...a2ui.Component) a2ui.Message {
+	return a2ui.Message{SurfaceUpdate: &a2ui.SurfaceUpdateMsg{SurfaceID: surface, Components: comps}}
+}
+
+func data(surface, key string) a2ui.Message {
+	return a2ui.Message{DataModelUpdate: &a2ui.DataModelUpdateMsg{
+		SurfaceID: surface,
+		Contents:  []a2ui.DataContent{{Key: key, ValueString: "v"}},
+	}}
+}
+
+func TestValidatorRules(t *testing.T) {
+	cases := []struct {
+		name string
+		msgs []a2ui.Message
+		want string // expected rule, "" for a conforming stream
+	}{
+		{
+			name: "valid",
+			msgs: []a2ui.Message{
+				begin("s"),
+				update("s", column("root", "a"), text("a", "", "s/a")),
+				data("s", "s/a"),
+			},
+		},
+		{
+			name: "empty envelope",
+			msgs: []a2ui.Message{{}},
+			want: RuleEnvelope,
+		},
+		{
+			name: "two fields in envelope",
+			msgs: []a2ui.Message{{
+				BeginRendering: &a2ui.BeginRenderingMsg{SurfaceID: "s", Root: "root"},
+				DeleteSurface:  &a2ui.DeleteSurfaceMsg{SurfaceID: "s"},
+			}},
+			want: RuleEnvelope,
+		},
+		{
+			name: "update before beginRendering",
+			msgs: []a2ui.Message{update("s", column("root"))},
+			want: RuleBeginRendering,
+		},
+		{
+			name: "component without type",
+			msgs: []a2ui.Message{begin("s"), update("s", column("root", "a"), a2ui.Component{ID: "a"})},
+			want: RuleComponent,
+		},
+		{
+			name: "undefined child",
+			msgs: []a2ui.Message{begin("s"), update("s", column("root", "missing"))},
+			want: RuleChildRef,
+		},
+		{
+			name: "undefined root",
+			msgs: []a2ui.Message{begin("s"), update("s", column("other"))},
+			want: RuleChildRef,
+		},
+		{
+			name: "cycle",
+			msgs: []a2ui.Message{begin("s"), update("s", column("root", "a"), column("a", "root"))},
+			want: RuleCycle,
+		},
+		{
+			name: "unresolved dataKey",
+			msgs: []a2ui.Message{begin("s"), update("s", column("root", "a"), text("a", "", "s/a"))},
+			want: RuleDataBinding,
+		},
+		{
+			name: "update after delete",
+			msgs: []a2ui.Message{
+				begin("s"),
+				update("s", column("root")),
+				{DeleteSurface: &a2ui.DeleteSurfaceMsg{SurfaceID: "s"}},
+				data("s", "s/a"),
+			},
+			want: RuleDeletedSurface,
+		},
+		{
+			name: "interrupt without id",
+			msgs: []a2ui.Message{{InterruptRequest: &a2ui.InterruptRequestMsg{Description: "approve?"}}},
+			want: RuleInterrupt,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewValidator()
+			for _, m := range tc.msgs {
+				v.Feed(m)
+			}
+			got := v.Finish()
+			if tc.want == "" {
+				if len(got) != 0 {
+					t.Fatalf("unexpected violations: %v", Join(got))
+				}
+				return
+			}
+			for _, vl := range got {
+				if vl.Rule == tc.want {
+					return
+				}
+			}
+			t.Fatalf("want a %q violation, got %v", tc.want, Join(got))
+		})
+	}
+}
+
+func TestValidatorAcrossContinuation(t *testing.T) {
+	// StreamContinue does not re-send beginRendering or the earlier cards; a
+	// validator fed both passes must treat them as one client session.
+	first := []a2ui.Message{
+		begin("s"),
+		update("s", column("root", "a"), text("a", "hi", "")),
+	}
+	second := []a2ui.Message{
+		update("s", column("root", "a", "b"), text("b", "", "s/b")),
+		data("s", "s/b"),
+	}
+
+	v := NewValidator()
+	for _, m := range append(first, second...) {
+		v.Feed(m)
+	}
+	if err := Join(v.Finish()); err != nil {
+		t.Fatalf("continuation rejected: %v", err)
+	}
+
+	alone := NewValidator()
+	for _, m := range second {
+		alone.Feed(m)
+	}
+	if Join(alone.Finish()) == nil {
+		t.Fatal("continuation without its first pass should not validate")
+	}
+}
+
+func TestValidateJSONLReportsBadJSON(t *testing.T) {
+	var buf bytes.Buffer
+	line, err := a2ui.Encode(begin("s"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf.Write(line)
+	buf.WriteString("{not json}\n")
+
+	err = ValidateJSONL(&buf)
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("want a line 2 decode error, got %v", err)
+	}
+}
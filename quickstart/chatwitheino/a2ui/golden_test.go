@@ -0,0 +1,107 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package a2ui_test
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino-examples/quickstart/chatwitheino/a2ui/conformance"
+)
+
+// Regenerate golden files with:
+//
+//	go test ./a2ui -run TestGoldenStreams -update
+var update = flag.Bool("update", false, "rewrite a2ui golden files from the current output")
+
+// TestGoldenStreams replays every recording under testdata/streams through the
+// a2ui streamers, checks the output for A2UI conformance and diffs it against
+// testdata/golden/<name>.jsonl.
+func TestGoldenStreams(t *testing.T) {
+	recordings, err := filepath.Glob(filepath.Join("testdata", "streams", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recordings) == 0 {
+		t.Fatal("no recordings found under testdata/streams")
+	}
+
+	for _, path := range recordings {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		t.Run(name, func(t *testing.T) {
+			rec, err := conformance.LoadRecording(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			res := conformance.Replay(&buf, rec)
+			if rec.WantErr && res.Err == nil {
+				t.Fatal("expected a stream error, got nil")
+			}
+			if !rec.WantErr && res.Err != nil {
+				t.Fatalf("replay: %v", res.Err)
+			}
+
+			if err := conformance.ValidateJSONL(bytes.NewReader(buf.Bytes())); err != nil {
+				t.Errorf("output is not valid A2UI:\n%v", err)
+			}
+
+			goldenPath := filepath.Join("testdata", "golden", name+".jsonl")
+			if *update {
+				if err := os.MkdirAll(filepath.Dir(goldenPath), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(goldenPath, buf.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("read golden (run with -update to create it): %v", err)
+			}
+			if diff := diffLines(string(want), buf.String()); diff != "" {
+				t.Errorf("output differs from %s (run with -update to accept):\n%s", goldenPath, diff)
+			}
+		})
+	}
+}
+
+// diffLines reports the first differing line between two JSONL documents.
+func diffLines(want, got string) string {
+	wl := strings.Split(want, "\n")
+	gl := strings.Split(got, "\n")
+	for i := 0; i < len(wl) || i < len(gl); i++ {
+		var w, g string
+		if i < len(wl) {
+			w = wl[i]
+		}
+		if i < len(gl) {
+			g = gl[i]
+		}
+		if w != g {
+			return fmt.Sprintf("line %d:\n- %s\n+ %s", i+1, w, g)
+		}
+	}
+	return ""
+}
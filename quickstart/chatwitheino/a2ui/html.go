@@ -0,0 +1,196 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package a2ui
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// htmlStyle mirrors the chat styles of static/index.html so exported pages look
+// like the live UI.
+const htmlStyle = `body { font-family: system-ui, sans-serif; background: #f5f5f5; margin: 0; padding: 20px; }
+h1 { font-size: 1.1rem; color: #1f2937; max-width: 820px; margin: 0 auto 16px; }
+.surface { max-width: 820px; margin: 0 auto; }
+.column { display: flex; flex-direction: column; gap: 14px; }
+.row { display: flex; gap: 8px; }
+.card { background: #fff; border-radius: 10px; padding: 14px 16px; box-shadow: 0 1px 3px rgba(0,0,0,.08); }
+.card .column { gap: 6px; }
+.caption { font-size: 0.72rem; font-weight: 600; text-transform: uppercase; letter-spacing: .06em; color: #6b7280; }
+.body { font-size: 0.9rem; line-height: 1.65; color: #1f2937; white-space: pre-wrap; overflow-wrap: anywhere; }
+.card[data-role="You"] { margin-left: auto; background: #eff6ff; }
+.card[data-role="You"] .caption { color: #2563eb; }
+.card[data-role="tool call"], .card[data-role="tool result"] { background: #f9fafb; border: 1px solid #e5e7eb; padding: 8px 12px; box-shadow: none; }
+.card[data-role="tool call"] .caption { color: #7c3aed; }
+.card[data-role="tool result"] .caption { color: #065f46; }
+.card[data-role="tool call"] .body, .card[data-role="tool result"] .body { font-size: 0.8rem; font-family: monospace; line-height: 1.4; }
+.card[data-role="error"] { background: #fef2f2; border: 1px solid #fca5a5; box-shadow: none; }
+.card[data-role="approval needed"] { background: #fffbeb; border: 1px solid #fcd34d; box-shadow: none; }
+.card[data-role="approval needed"] .caption { color: #d97706; }
+`
+
+// htmlSurface is the renderer state of one surface.
+type htmlSurface struct {
+	id         string
+	root       string
+	components map[string]ComponentValue
+	data       map[string]string
+	interrupts []string
+}
+
+// WriteHTML renders an A2UI JSONL stream, such as the output of RenderBranch,
+// into a self-contained HTML page. Surfaces are applied in stream order the way
+// the web UI applies them, then rendered once; surfaces deleted by the stream
+// are omitted.
+func WriteHTML(w io.Writer, title string, stream io.Reader) error {
+	var surfaces []*htmlSurface
+	byID := make(map[string]*htmlSurface)
+	get := func(id string) *htmlSurface {
+		if s, ok := byID[id]; ok {
+			return s
+		}
+		s := &htmlSurface{id: id, components: make(map[string]ComponentValue), data: make(map[string]string)}
+		byID[id] = s
+		surfaces = append(surfaces, s)
+		return s
+	}
+	var last *htmlSurface
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var msg Message
+		if err := json.Unmarshal([]byte(text), &msg); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		switch {
+		case msg.BeginRendering != nil:
+			last = get(msg.BeginRendering.SurfaceID)
+			last.root = msg.BeginRendering.Root
+		case msg.SurfaceUpdate != nil:
+			last = get(msg.SurfaceUpdate.SurfaceID)
+			for _, c := range msg.SurfaceUpdate.Components {
+				last.components[c.ID] = c.Component
+			}
+		case msg.DataModelUpdate != nil:
+			last = get(msg.DataModelUpdate.SurfaceID)
+			for _, c := range msg.DataModelUpdate.Contents {
+				last.data[c.Key] = c.ValueString
+			}
+		case msg.DeleteSurface != nil:
+			delete(byID, msg.DeleteSurface.SurfaceID)
+			last = nil
+		case msg.InterruptRequest != nil:
+			// Interrupts carry no surface; attach them to the one being rendered.
+			if last != nil {
+				last.interrupts = append(last.interrupts, msg.InterruptRequest.Description)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n<style>\n%s</style>\n</head>\n<body>\n", html.EscapeString(title), htmlStyle)
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", html.EscapeString(title))
+	for _, s := range surfaces {
+		if byID[s.id] != s || s.root == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "<div class=\"surface\" data-surface=\"%s\">\n", html.EscapeString(s.id))
+		s.render(&b, s.root, make(map[string]bool))
+		for _, desc := range s.interrupts {
+			b.WriteString("<div class=\"card\" data-role=\"approval needed\"><div class=\"column\">")
+			b.WriteString("<div class=\"caption\">approval needed</div>")
+			fmt.Fprintf(&b, "<div class=\"body\">%s</div></div></div>\n", html.EscapeString(desc))
+		}
+		b.WriteString("</div>\n")
+	}
+	b.WriteString("</body>\n</html>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// render writes component id and its children. seen guards against cycles in
+// streams that were never validated.
+func (s *htmlSurface) render(b *strings.Builder, id string, seen map[string]bool) {
+	comp, ok := s.components[id]
+	if !ok || seen[id] {
+		return
+	}
+	seen[id] = true
+	defer delete(seen, id)
+
+	children := func(ids []string) {
+		for _, child := range ids {
+			s.render(b, child, seen)
+		}
+	}
+	switch {
+	case comp.Column != nil:
+		b.WriteString("<div class=\"column\">")
+		children(comp.Column.Children)
+		b.WriteString("</div>\n")
+	case comp.Card != nil:
+		fmt.Fprintf(b, "<div class=\"card\" data-role=\"%s\">", html.EscapeString(s.cardRole(comp.Card)))
+		children(comp.Card.Children)
+		b.WriteString("</div>\n")
+	case comp.Row != nil:
+		b.WriteString("<div class=\"row\">")
+		children(comp.Row.Children)
+		b.WriteString("</div>\n")
+	case comp.Text != nil:
+		value := comp.Text.Value
+		if comp.Text.DataKey != "" {
+			value = s.data[comp.Text.DataKey]
+		}
+		class := "body"
+		if comp.Text.UsageHint == "caption" {
+			class = "caption"
+		}
+		fmt.Fprintf(b, "<div class=\"%s\">%s</div>", class, html.EscapeString(value))
+	}
+}
+
+// cardRole derives the styling role from the card's first Text child, as the
+// web UI does, dropping any " · n/m" sibling suffix.
+func (s *htmlSurface) cardRole(card *CardComp) string {
+	if len(card.Children) == 0 {
+		return ""
+	}
+	col := s.components[card.Children[0]].Column
+	if col == nil || len(col.Children) == 0 {
+		return ""
+	}
+	label := s.components[col.Children[0]].Text
+	if label == nil {
+		return ""
+	}
+	role, _, _ := strings.Cut(label.Value, " · ")
+	return role
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package a2ui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+func TestWriteHTMLRendersBranch(t *testing.T) {
+	history := []*schema.Message{
+		schema.UserMessage("what is <eino>?"),
+		schema.AssistantMessage("a framework", nil),
+	}
+	var stream bytes.Buffer
+	if err := RenderBranch(&stream, "s1", history, []Siblings{{Pos: 2, Count: 2}, {Pos: 1, Count: 1}}); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := WriteHTML(&out, "Export & share", &stream); err != nil {
+		t.Fatal(err)
+	}
+	page := out.String()
+	for _, want := range []string{
+		"<title>Export &amp; share</title>",
+		`<div class="card" data-role="You">`,
+		`<div class="caption">You · 2/2</div>`,
+		"what is &lt;eino&gt;?",
+		"a framework",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("page is missing %q", want)
+		}
+	}
+}
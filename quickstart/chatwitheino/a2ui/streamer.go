@@ -32,6 +32,20 @@ import (
 // RenderHistory writes the beginRendering + history surfaceUpdate messages to w
 // without running an agent. Used to populate the chat window when a session is selected.
 func RenderHistory[M adk.MessageType](w io.Writer, sessionID string, history []M) error {
+	return RenderBranch(w, sessionID, history, nil)
+}
+
+// Siblings is a history message's position among its alternative versions
+// (edits or regenerations). Count <= 1 means there are no alternatives.
+type Siblings struct {
+	Pos   int `json:"pos"` // 1-based
+	Count int `json:"count"`
+}
+
+// RenderBranch is RenderHistory for one branch of a conversation tree.
+// siblings[i] describes history[i]; messages with alternatives get a "2/3"
+// marker in their caption so the user knows other versions exist.
+func RenderBranch[M adk.MessageType](w io.Writer, sessionID string, history []M, siblings []Siblings) error {
 	surfaceID := "chat-" + sessionID
 	rootChildren := make([]string, 0, len(history))
 	for i := range history {
@@ -42,7 +56,7 @@ func RenderHistory[M adk.MessageType](w io.Writer, sessionID string, history []M
 	}); err != nil {
 		return err
 	}
-	return emitHistory(w, surfaceID, history, rootChildren)
+	return emitHistory(w, surfaceID, history, siblings, rootChildren)
 }
 
 // StreamToWriter converts an agent event stream into A2UI JSONL messages written to w.
@@ -63,7 +77,7 @@ func StreamToWriter[M adk.MessageType](w io.Writer, sessionID string, history []
 	}); err != nil {
 		return "", nil, "", 0, err
 	}
-	if err := emitHistory(w, surfaceID, history, rootChildren); err != nil {
+	if err := emitHistory(w, surfaceID, history, nil, rootChildren); err != nil {
 		return "", nil, "", 0, err
 	}
 
@@ -405,7 +419,7 @@ func emitToolChip(w io.Writer, surfaceID string, rootChildren *[]string, msgIdx
 	})
 }
 
-func emitHistory[M adk.MessageType](w io.Writer, surfaceID string, history []M, rootChildren []string) error {
+func emitHistory[M adk.MessageType](w io.Writer, surfaceID string, history []M, siblings []Siblings, rootChildren []string) error {
 	comps := []Component{
 		{ID: "root-col", Component: ComponentValue{Column: &ColumnComp{Children: append([]string{}, rootChildren...)}}},
 	}
@@ -424,6 +438,9 @@ func emitHistory[M adk.MessageType](w io.Writer, surfaceID string, history []M,
 			label = "tool call"
 			body = formatToolCall(calls[0])
 		}
+		if i < len(siblings) && siblings[i].Count > 1 {
+			label = fmt.Sprintf("%s · %d/%d", label, siblings[i].Pos, siblings[i].Count)
+		}
 
 		comps = append(comps,
 			Component{ID: cardID, Component: ComponentValue{Card: &CardComp{Children: []string{colID}}}},
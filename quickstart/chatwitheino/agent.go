@@ -47,7 +47,12 @@ func buildAgentTyped[M adk.MessageType](ctx context.Context) (adk.TypedResumable
 		return nil, err
 	}
 
-	ragTool, err := rag.BuildTool[M](ctx, cm)
+	embedder, err := chatmodel.NewEmbedder(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("build embedder: %w", err)
+	}
+
+	ragTool, err := rag.BuildTool[M](ctx, cm, rag.WithEmbedder(embedder))
 	if err != nil {
 		return nil, fmt.Errorf("build rag tool: %w", err)
 	}
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chatmodel
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
+	"github.com/volcengine/volcengine-go-sdk/service/arkruntime"
+	arkModel "github.com/volcengine/volcengine-go-sdk/service/arkruntime/model"
+)
+
+// NewEmbedder creates the embedder named by EMBEDDING_MODEL, or returns nil
+// when it is unset so callers fall back to keyword search. MODEL_TYPE selects
+// the provider and its API key and base URL, as for NewModel.
+func NewEmbedder(ctx context.Context) (embedding.Embedder, error) {
+	name := strings.TrimSpace(os.Getenv("EMBEDDING_MODEL"))
+	if name == "" {
+		return nil, nil
+	}
+	if strings.ToLower(os.Getenv("MODEL_TYPE")) == "ark" {
+		var opts []arkruntime.ConfigOption
+		if baseURL := os.Getenv("ARK_BASE_URL"); baseURL != "" {
+			opts = append(opts, arkruntime.WithBaseUrl(baseURL))
+		}
+		return &arkEmbedder{client: arkruntime.NewClientWithApiKey(os.Getenv("ARK_API_KEY"), opts...), model: name}, nil
+	}
+
+	if os.Getenv("OPENAI_BY_AZURE") == "true" {
+		return nil, fmt.Errorf("EMBEDDING_MODEL is not supported with OPENAI_BY_AZURE")
+	}
+	opts := []option.RequestOption{option.WithAPIKey(os.Getenv("OPENAI_API_KEY"))}
+	if baseURL := os.Getenv("OPENAI_BASE_URL"); baseURL != "" {
+		opts = append(opts, option.WithBaseURL(baseURL))
+	}
+	return &openaiEmbedder{client: openai.NewClient(opts...), model: name}, nil
+}
+
+type arkEmbedder struct {
+	client *arkruntime.Client
+	model  string
+}
+
+func (e *arkEmbedder) EmbedStrings(ctx context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	resp, err := e.client.CreateEmbeddings(ctx, arkModel.EmbeddingRequestStrings{Input: texts, Model: e.model})
+	if err != nil {
+		return nil, err
+	}
+	vectors := make([][]float64, len(texts))
+	for _, d := range resp.Data {
+		if d.Index < 0 || d.Index >= len(vectors) {
+			return nil, fmt.Errorf("embedding index %d out of range", d.Index)
+		}
+		v := make([]float64, len(d.Embedding))
+		for i, f := range d.Embedding {
+			v[i] = float64(f)
+		}
+		vectors[d.Index] = v
+	}
+	return vectors, nil
+}
+
+func (e *arkEmbedder) GetType() string { return "Ark:" + e.model }
+
+type openaiEmbedder struct {
+	client openai.Client
+	model  string
+}
+
+func (e *openaiEmbedder) EmbedStrings(ctx context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	resp, err := e.client.Embeddings.New(ctx, openai.EmbeddingNewParams{
+		Model: openai.EmbeddingModel(e.model),
+		Input: openai.EmbeddingNewParamsInputUnion{OfArrayOfStrings: texts},
+	})
+	if err != nil {
+		return nil, err
+	}
+	vectors := make([][]float64, len(texts))
+	for _, d := range resp.Data {
+		if d.Index < 0 || int(d.Index) >= len(vectors) {
+			return nil, fmt.Errorf("embedding index %d out of range", d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	return vectors, nil
+}
+
+func (e *openaiEmbedder) GetType() string { return "OpenAI:" + e.model }
@@ -128,7 +128,13 @@ Always use absolute paths when calling filesystem tools.`, projectRoot, projectR
 		os.Exit(1)
 	}
 
-	ragTool, err := rag.BuildTool[M](ctx, cm)
+	embedder, err := chatmodel.NewEmbedder(ctx)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, fmt.Errorf("build embedder: %w", err))
+		os.Exit(1)
+	}
+
+	ragTool, err := rag.BuildTool[M](ctx, cm, rag.WithEmbedder(embedder))
 	if err != nil {
 		_, _ = fmt.Fprintln(os.Stderr, fmt.Errorf("build rag tool: %w", err))
 		os.Exit(1)
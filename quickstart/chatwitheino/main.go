@@ -21,6 +21,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	clc "github.com/cloudwego/eino-ext/callbacks/cozeloop"
@@ -30,6 +31,7 @@ import (
 	"github.com/coze-dev/cozeloop-go"
 
 	adkstore "github.com/cloudwego/eino-examples/adk/common/store"
+	"github.com/cloudwego/eino-examples/quickstart/chatwitheino/chatmodel"
 	"github.com/cloudwego/eino-examples/quickstart/chatwitheino/mem"
 	"github.com/cloudwego/eino-examples/quickstart/chatwitheino/msgops"
 	"github.com/cloudwego/eino-examples/quickstart/chatwitheino/server"
@@ -83,9 +85,38 @@ func runTyped[M adk.MessageType](ctx context.Context) {
 		workspaceDir = "./data/workspace"
 	}
 
-	store, err := mem.NewStore[M](sessionDir)
-	if err != nil {
-		log.Fatalf("failed to create session store: %v", err)
+	// SESSION_DB switches session storage from JSONL files to a SQLite database,
+	// which several server instances can share.
+	var store *mem.Store[M]
+	if dbPath := os.Getenv("SESSION_DB"); dbPath != "" {
+		backend, err := mem.OpenSQLite[M](dbPath)
+		if err != nil {
+			log.Fatalf("failed to open session db: %v", err)
+		}
+		defer backend.Close()
+		store = mem.NewStoreWithBackend[M](backend)
+		log.Printf("session db: %s", dbPath)
+	} else {
+		store, err = mem.NewStore[M](sessionDir)
+		if err != nil {
+			log.Fatalf("failed to create session store: %v", err)
+		}
+	}
+
+	// SESSION_SUMMARIZE=true titles sessions with the model after their first
+	// exchange and summarizes the start of histories above SESSION_TOKEN_BUDGET.
+	var summarizer *mem.Summarizer[M]
+	if os.Getenv("SESSION_SUMMARIZE") == "true" {
+		cm, err := chatmodel.NewModel[M](ctx)
+		if err != nil {
+			log.Fatalf("failed to create summarizer model: %v", err)
+		}
+		budget, _ := strconv.Atoi(os.Getenv("SESSION_TOKEN_BUDGET"))
+		summarizer, err = mem.NewSummarizer[M](mem.SummarizerConfig[M]{Model: cm, TokenBudget: budget})
+		if err != nil {
+			log.Fatalf("failed to create summarizer: %v", err)
+		}
+		log.Printf("session summarizer: token budget %d", summarizer.TokenBudget())
 	}
 
 	port := os.Getenv("PORT")
@@ -130,6 +161,7 @@ func runTyped[M adk.MessageType](ctx context.Context) {
 		ProjectRoot:     projectRoot,
 		ExamplesDir:     examplesDir,
 		Port:            port,
+		Summarizer:      summarizer,
 	})
 
 	host := os.Getenv("HOST")
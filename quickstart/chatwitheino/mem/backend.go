@@ -0,0 +1,144 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mem
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/adk"
+
+	"github.com/cloudwego/eino-examples/quickstart/chatwitheino/msgops"
+)
+
+// ErrSessionNotFound is returned by Backend.Load when the session does not exist.
+var ErrSessionNotFound = errors.New("session not found")
+
+// Metadata keys used by Session.
+const (
+	metaPendingInterruptID = "pending_interrupt_id"
+	metaMsgIdx             = "msg_idx"
+	metaBranchForks        = "branch_forks" // JSON {"<index>": parent} for messages not following their predecessor
+	metaBranchHead         = "branch_head"  // last message of the active branch; "" means the newest message
+)
+
+// Header is the per-session data kept with the session record rather than
+// with its messages, so listing sessions never has to read message history.
+type Header struct {
+	CreatedAt time.Time
+	Title     string // generated title; "" falls back to the first user message
+	Summary   Summary
+}
+
+// ListQuery selects a page of sessions. The zero value lists every session.
+type ListQuery struct {
+	Search string // case-insensitive match against the title or any message text
+	Offset int
+	Limit  int // 0 means no limit
+}
+
+// Backend persists sessions for a Store. Implementations must be safe for
+// concurrent use and must reject sessions whose stored message_kind does not
+// match M (see msgops.ValidateKind).
+type Backend[M adk.MessageType] interface {
+	// Create persists a new empty session. Creating a session that already
+	// exists is not an error; the existing session is left untouched.
+	Create(id string, createdAt time.Time) error
+	// Load returns the session header and all of its messages, or
+	// ErrSessionNotFound.
+	Load(id string) (Header, []M, error)
+	// LoadAfter returns the messages that follow the first n.
+	LoadAfter(id string, n int) ([]M, error)
+	// Append persists one message at the end of the session and returns its
+	// index, which other writers to shared storage may have moved past the
+	// end of the caller's copy.
+	Append(id string, msg M) (int, error)
+	// List returns session metadata, newest first.
+	List(q ListQuery) ([]SessionMeta, error)
+	// Delete removes the session, its messages and its metadata.
+	Delete(id string) error
+
+	// SetTitle and SetSummary update the session header.
+	SetTitle(id, title string) error
+	SetSummary(id string, summary Summary) error
+
+	// SetMeta and GetMeta store small per-session values such as the pending
+	// interrupt ID. GetMeta returns "" for unset keys.
+	SetMeta(id, key, value string) error
+	GetMeta(id, key string) (string, error)
+
+	// Shared reports whether other processes may write to the same storage.
+	// Sessions backed by a shared backend re-read new messages before
+	// returning history instead of trusting their in-memory copy.
+	Shared() bool
+}
+
+// deriveTitle returns a display title taken from the first user message.
+func deriveTitle[M adk.MessageType](messages []M) string {
+	for _, msg := range messages {
+		if text := msgops.UserText(msg); text != "" {
+			return truncateTitle(text)
+		}
+	}
+	return "New Session"
+}
+
+func truncateTitle(text string) string {
+	if len([]rune(text)) > 60 {
+		return string([]rune(text)[:60]) + "..."
+	}
+	return text
+}
+
+// matchesSearch reports whether the title or any message text contains search.
+func matchesSearch[M adk.MessageType](title string, messages []M, search string) bool {
+	if search == "" {
+		return true
+	}
+	needle := strings.ToLower(search)
+	if strings.Contains(strings.ToLower(title), needle) {
+		return true
+	}
+	for _, msg := range messages {
+		if strings.Contains(strings.ToLower(msgops.Text(msg)), needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// paginate sorts metas newest first and applies q.Offset and q.Limit.
+func paginate(metas []SessionMeta, q ListQuery) []SessionMeta {
+	sort.SliceStable(metas, func(i, j int) bool {
+		if !metas[i].CreatedAt.Equal(metas[j].CreatedAt) {
+			return metas[i].CreatedAt.After(metas[j].CreatedAt)
+		}
+		return metas[i].ID < metas[j].ID
+	})
+	if q.Offset > 0 {
+		if q.Offset >= len(metas) {
+			return nil
+		}
+		metas = metas[q.Offset:]
+	}
+	if q.Limit > 0 && q.Limit < len(metas) {
+		metas = metas[:q.Limit]
+	}
+	return metas
+}
@@ -0,0 +1,296 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mem
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/cloudwego/eino-examples/quickstart/chatwitheino/msgops"
+)
+
+// Messages are stored append-only, so branching is layered on top: every
+// message has a parent (by default the message stored before it) and the
+// session tracks the head of the active branch. Editing or regenerating
+// forks a new sibling instead of rewriting history, and the parent links of
+// forked messages are kept in session metadata. Sessions written before
+// branching existed load as a single linear branch.
+
+// Node describes one stored message in the conversation tree.
+type Node struct {
+	Index  int    `json:"index"`
+	Parent int    `json:"parent"` // -1 for a root message
+	Role   string `json:"role"`
+	Text   string `json:"text"`
+	Active bool   `json:"active"` // on the active branch
+}
+
+// BranchEntry is one message of the active branch with its position among
+// the alternatives that share its parent.
+type BranchEntry struct {
+	Index        int
+	SiblingPos   int // 1-based
+	SiblingCount int
+}
+
+// Tree returns every stored message as a node of the conversation tree.
+func (s *Session[M]) Tree() []Node {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	active := make(map[int]bool)
+	for _, idx := range s.pathLocked(s.head) {
+		active[idx] = true
+	}
+	nodes := make([]Node, len(s.messages))
+	for i, msg := range s.messages {
+		nodes[i] = Node{
+			Index:  i,
+			Parent: s.parents[i],
+			Role:   msgops.RoleLabel(msg),
+			Text:   msgops.Text(msg),
+			Active: active[i],
+		}
+	}
+	return nodes
+}
+
+// Branch returns the messages of the active branch, as GetMessages does,
+// together with where each one sits in the tree.
+func (s *Session[M]) Branch() ([]M, []BranchEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.backend.Shared() {
+		if err := s.syncLocked(); err != nil {
+			log.Printf("warn: failed to refresh session %s: %v", s.ID, err)
+		}
+	}
+
+	path := s.pathLocked(s.head)
+	messages := make([]M, len(path))
+	entries := make([]BranchEntry, len(path))
+	for i, idx := range path {
+		messages[i] = s.messages[idx]
+		siblings := s.childrenLocked(s.parents[idx])
+		entry := BranchEntry{Index: idx, SiblingCount: len(siblings)}
+		for pos, sib := range siblings {
+			if sib == idx {
+				entry.SiblingPos = pos + 1
+			}
+		}
+		entries[i] = entry
+	}
+	return messages, entries
+}
+
+// IsUserMessage reports whether node is a user message.
+func (s *Session[M]) IsUserMessage(node int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return node >= 0 && node < len(s.messages) && msgops.UserText(s.messages[node]) != ""
+}
+
+// LastUserMessage returns the index of the last user message on the active branch.
+func (s *Session[M]) LastUserMessage() (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := s.pathLocked(s.head)
+	for i := len(path) - 1; i >= 0; i-- {
+		if msgops.UserText(s.messages[path[i]]) != "" {
+			return path[i], true
+		}
+	}
+	return -1, false
+}
+
+// Fork moves the head to node's parent, so the next Append becomes a new
+// sibling of node. Used to edit a message without losing the original.
+func (s *Session[M]) Fork(node int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkNodeLocked(node); err != nil {
+		return err
+	}
+	return s.setHeadLocked(s.parents[node])
+}
+
+// Rewind makes node the last message of the active branch. Messages after it
+// stay in storage; the next Append starts a new branch from node.
+func (s *Session[M]) Rewind(node int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkNodeLocked(node); err != nil {
+		return err
+	}
+	return s.setHeadLocked(node)
+}
+
+// SwitchBranch activates the branch through node, following the newest
+// child below it down to a leaf.
+func (s *Session[M]) SwitchBranch(node int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkNodeLocked(node); err != nil {
+		return err
+	}
+	leaf := node
+	for {
+		children := s.childrenLocked(leaf)
+		if len(children) == 0 {
+			break
+		}
+		leaf = children[len(children)-1]
+	}
+	return s.setHeadLocked(leaf)
+}
+
+// addNodeLocked records the parent of the newly appended message at idx and
+// moves the head onto it.
+func (s *Session[M]) addNodeLocked(idx, parent int) error {
+	s.parents[idx] = parent
+	if parent != idx-1 {
+		if err := s.saveForksLocked(); err != nil {
+			return err
+		}
+	}
+	if idx != len(s.messages)-1 {
+		// Other replicas appended after it, so it is not the default head.
+		return s.setHeadLocked(idx)
+	}
+	s.head = idx
+	if s.explicitHead {
+		// The new message is the newest one, which is the default head.
+		s.explicitHead = false
+		return s.backend.SetMeta(s.ID, metaBranchHead, "")
+	}
+	return nil
+}
+
+func (s *Session[M]) setHeadLocked(head int) error {
+	if err := s.backend.SetMeta(s.ID, metaBranchHead, strconv.Itoa(head)); err != nil {
+		return err
+	}
+	s.head = head
+	s.explicitHead = true
+	return nil
+}
+
+// saveForksLocked stores the parents of forked messages. Replicas sharing
+// the backend record their forks under the same key, so the stored entries
+// are merged in first rather than overwritten with this replica's view.
+func (s *Session[M]) saveForksLocked() error {
+	forks, err := s.loadForksLocked()
+	if err != nil {
+		return err
+	}
+	for i, p := range s.parents {
+		if p != i-1 {
+			forks[strconv.Itoa(i)] = p
+		}
+	}
+	data, err := json.Marshal(forks)
+	if err != nil {
+		return err
+	}
+	return s.backend.SetMeta(s.ID, metaBranchForks, string(data))
+}
+
+// loadForksLocked reads the stored parents of forked messages, keyed by index.
+func (s *Session[M]) loadForksLocked() (map[string]int, error) {
+	raw, err := s.backend.GetMeta(s.ID, metaBranchForks)
+	if err != nil {
+		return nil, err
+	}
+	forks := make(map[string]int)
+	if raw != "" {
+		if err := json.Unmarshal([]byte(raw), &forks); err != nil {
+			return nil, fmt.Errorf("bad branch metadata for session %s: %w", s.ID, err)
+		}
+	}
+	return forks, nil
+}
+
+// loadTreeLocked rebuilds parents and head from session metadata.
+func (s *Session[M]) loadTreeLocked() error {
+	s.parents = make([]int, len(s.messages))
+	for i := range s.parents {
+		s.parents[i] = i - 1
+	}
+
+	forks, err := s.loadForksLocked()
+	if err != nil {
+		return err
+	}
+	for k, p := range forks {
+		i, convErr := strconv.Atoi(k)
+		if convErr != nil || i < 0 || i >= len(s.parents) || p >= i {
+			continue
+		}
+		s.parents[i] = p
+	}
+
+	s.head = len(s.messages) - 1
+	s.explicitHead = false
+	rawHead, err := s.backend.GetMeta(s.ID, metaBranchHead)
+	if err != nil {
+		return err
+	}
+	if rawHead != "" {
+		if h, convErr := strconv.Atoi(rawHead); convErr == nil && h >= -1 && h < len(s.messages) {
+			s.head = h
+			s.explicitHead = true
+		}
+	}
+	return nil
+}
+
+// pathLocked returns the indices from the root down to head.
+func (s *Session[M]) pathLocked(head int) []int {
+	var path []int
+	for idx := head; idx >= 0; idx = s.parents[idx] {
+		path = append(path, idx)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// childrenLocked returns the messages whose parent is node, oldest first.
+func (s *Session[M]) childrenLocked(node int) []int {
+	var children []int
+	for i, p := range s.parents {
+		if p == node {
+			children = append(children, i)
+		}
+	}
+	return children
+}
+
+func (s *Session[M]) checkNodeLocked(node int) error {
+	if node < 0 || node >= len(s.messages) {
+		return fmt.Errorf("session %s has no message %d", s.ID, node)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mem
+
+import (
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+func contents(msgs []*schema.Message) []string {
+	out := make([]string, len(msgs))
+	for i, m := range msgs {
+		out[i] = m.Content
+	}
+	return out
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSessionBranching(t *testing.T) {
+	for name, backend := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			store := NewStoreWithBackend[*schema.Message](backend)
+			sess, err := store.GetOrCreate("b1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, m := range []*schema.Message{
+				schema.UserMessage("q1"),           // 0
+				schema.AssistantMessage("a1", nil), // 1
+				schema.UserMessage("q2"),           // 2
+				schema.AssistantMessage("a2", nil), // 3
+			} {
+				if err := sess.Append(m); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// Edit q2: fork it and append the replacement plus a new answer.
+			if err := sess.Fork(2); err != nil {
+				t.Fatal(err)
+			}
+			_ = sess.Append(schema.UserMessage("q2 edited"))     // 4
+			_ = sess.Append(schema.AssistantMessage("a2'", nil)) // 5
+			if got := contents(sess.GetMessages()); !equal(got, []string{"q1", "a1", "q2 edited", "a2'"}) {
+				t.Fatalf("after edit: %v", got)
+			}
+
+			// Regenerate: rewind to the last user message and append a new answer.
+			last, ok := sess.LastUserMessage()
+			if !ok || last != 4 {
+				t.Fatalf("LastUserMessage = %d, %v", last, ok)
+			}
+			_ = sess.Rewind(last)
+			_ = sess.Append(schema.AssistantMessage("a2''", nil)) // 6
+
+			_, branch := sess.Branch()
+			if got := branch[len(branch)-1]; got.Index != 6 || got.SiblingPos != 2 || got.SiblingCount != 2 {
+				t.Fatalf("regenerated entry: %+v", got)
+			}
+			if got := branch[2]; got.Index != 4 || got.SiblingPos != 2 || got.SiblingCount != 2 {
+				t.Fatalf("edited entry: %+v", got)
+			}
+
+			// Switching back to the original q2 follows it down to a2.
+			if err := sess.SwitchBranch(2); err != nil {
+				t.Fatal(err)
+			}
+			if got := contents(sess.GetMessages()); !equal(got, []string{"q1", "a1", "q2", "a2"}) {
+				t.Fatalf("after switch: %v", got)
+			}
+
+			// The tree and the active branch survive a reload.
+			reopened, err := NewStoreWithBackend[*schema.Message](backend).GetOrCreate("b1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := contents(reopened.GetMessages()); !equal(got, []string{"q1", "a1", "q2", "a2"}) {
+				t.Fatalf("after reload: %v", got)
+			}
+			if err := reopened.SwitchBranch(4); err != nil {
+				t.Fatal(err)
+			}
+			if got := contents(reopened.GetMessages()); !equal(got, []string{"q1", "a1", "q2 edited", "a2''"}) {
+				t.Fatalf("switch to edit after reload: %v", got)
+			}
+			if len(reopened.Tree()) != 7 {
+				t.Fatalf("tree has %d nodes, want 7", len(reopened.Tree()))
+			}
+		})
+	}
+}
+
+func TestSaveForksKeepsStoredForks(t *testing.T) {
+	for name, backend := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			sess, err := NewStoreWithBackend[*schema.Message](backend).GetOrCreate("f1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = sess.Append(schema.UserMessage("q1"))
+			_ = sess.Append(schema.AssistantMessage("a1", nil))
+
+			// Another replica forked a message this one has not read yet.
+			if err := backend.SetMeta("f1", metaBranchForks, `{"9":0}`); err != nil {
+				t.Fatal(err)
+			}
+			_ = sess.Rewind(0)
+			if err := sess.Append(schema.AssistantMessage("a1'", nil)); err != nil {
+				t.Fatal(err)
+			}
+
+			raw, err := backend.GetMeta("f1", metaBranchForks)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if raw != `{"2":0,"9":0}` {
+				t.Fatalf("stored forks = %s", raw)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mem
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/eino/adk"
+)
+
+// snapshotMetaKeys are the metadata keys carried by a Snapshot.
+var snapshotMetaKeys = []string{metaPendingInterruptID, metaMsgIdx, metaBranchForks, metaBranchHead}
+
+// Snapshot is a self-contained copy of a stored session: its header, every
+// message of the conversation tree in storage order, and the metadata that
+// restores its branches and any pending interrupt.
+type Snapshot[M adk.MessageType] struct {
+	Header
+	Messages []M
+	Meta     map[string]string
+}
+
+// Snapshot copies the session's stored state.
+func (s *Session[M]) Snapshot() (Snapshot[M], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.backend.Shared() {
+		if err := s.syncLocked(); err != nil {
+			return Snapshot[M]{}, err
+		}
+	}
+
+	snap := Snapshot[M]{
+		Header:   Header{CreatedAt: s.CreatedAt, Title: s.title, Summary: s.summary},
+		Messages: append([]M(nil), s.messages...),
+		Meta:     make(map[string]string),
+	}
+	for _, key := range snapshotMetaKeys {
+		v, err := s.backend.GetMeta(s.ID, key)
+		if err != nil {
+			return Snapshot[M]{}, err
+		}
+		if v != "" {
+			snap.Meta[key] = v
+		}
+	}
+	return snap, nil
+}
+
+// Restore writes snap as a new session with the given id. It fails if the
+// session already exists. Metadata keys Snapshot does not produce are ignored.
+func (s *Store[M]) Restore(id string, snap Snapshot[M]) (*Session[M], error) {
+	s.mu.Lock()
+	_, _, err := s.backend.Load(id)
+	s.mu.Unlock()
+	if err == nil {
+		return nil, fmt.Errorf("session %s already exists", id)
+	}
+	if !errors.Is(err, ErrSessionNotFound) {
+		return nil, err
+	}
+
+	createdAt := snap.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now().UTC()
+	}
+	if err := s.backend.Create(id, createdAt); err != nil {
+		return nil, err
+	}
+	for _, msg := range snap.Messages {
+		if _, err := s.backend.Append(id, msg); err != nil {
+			return nil, err
+		}
+	}
+	for _, key := range snapshotMetaKeys {
+		if v := snap.Meta[key]; v != "" {
+			if err := s.backend.SetMeta(id, key, v); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if snap.Title != "" {
+		if err := s.backend.SetTitle(id, snap.Title); err != nil {
+			return nil, err
+		}
+	}
+	if snap.Summary.Text != "" {
+		if err := s.backend.SetSummary(id, snap.Summary); err != nil {
+			return nil, err
+		}
+	}
+	return s.GetOrCreate(id)
+}
@@ -0,0 +1,406 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mem
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/adk"
+
+	"github.com/cloudwego/eino-examples/quickstart/chatwitheino/msgops"
+)
+
+// JSONLBackend stores each session as a JSONL file in a directory.
+//
+// File format:
+//
+//	{"type":"session","id":"...","created_at":"...","message_kind":"agentic"}   ← header (line 1)
+//	{"role":"user","content_blocks":[...]}                                      ← message (lines 2+)
+//
+// The header also carries the generated title and rolling summary once they
+// exist; updating them rewrites the file. Session metadata (pending interrupt,
+// branch structure) is kept next to the session file in <id>.meta.json. The
+// backend is meant for a single server process.
+type JSONLBackend[M adk.MessageType] struct {
+	dir  string
+	kind msgops.Kind
+
+	mu     sync.Mutex
+	meta   map[string]map[string]string
+	counts map[string]int // messages per session, filled on first Append
+}
+
+// NewJSONLBackend creates a JSONLBackend rooted at dir (created if absent).
+func NewJSONLBackend[M adk.MessageType](dir string) (*JSONLBackend[M], error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create session dir: %w", err)
+	}
+	return &JSONLBackend[M]{
+		dir:    dir,
+		kind:   msgops.KindOf[M](),
+		meta:   make(map[string]map[string]string),
+		counts: make(map[string]int),
+	}, nil
+}
+
+// sessionHeader is the first JSONL line in every session file.
+type sessionHeader struct {
+	Type        string      `json:"type"`
+	ID          string      `json:"id"`
+	CreatedAt   time.Time   `json:"created_at"`
+	MessageKind msgops.Kind `json:"message_kind,omitempty"`
+	Title       string      `json:"title,omitempty"`
+	Summary     *Summary    `json:"summary,omitempty"`
+}
+
+func (h sessionHeader) header() Header {
+	out := Header{CreatedAt: h.CreatedAt, Title: h.Title}
+	if h.Summary != nil {
+		out.Summary = *h.Summary
+	}
+	return out
+}
+
+func (b *JSONLBackend[M]) path(id string) string {
+	return filepath.Join(b.dir, id+".jsonl")
+}
+
+func (b *JSONLBackend[M]) Create(id string, createdAt time.Time) error {
+	header := sessionHeader{
+		Type:        "session",
+		ID:          id,
+		CreatedAt:   createdAt.UTC(),
+		MessageKind: b.kind,
+	}
+	data, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(b.path(id), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if os.IsExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+func (b *JSONLBackend[M]) Load(id string) (Header, []M, error) {
+	header, messages, err := b.load(b.path(id))
+	if os.IsNotExist(err) {
+		return Header{}, nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return Header{}, nil, err
+	}
+	return header.header(), messages, nil
+}
+
+func (b *JSONLBackend[M]) LoadAfter(id string, n int) ([]M, error) {
+	_, messages, err := b.Load(id)
+	if err != nil {
+		return nil, err
+	}
+	if n >= len(messages) {
+		return nil, nil
+	}
+	return messages[n:], nil
+}
+
+func (b *JSONLBackend[M]) Append(id string, msg M) (int, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return 0, err
+	}
+
+	// Appends hold the lock so they cannot land in a file that a header
+	// rewrite is about to replace.
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	idx, ok := b.counts[id]
+	if !ok {
+		_, messages, err := b.load(b.path(id))
+		if err != nil {
+			return 0, err
+		}
+		idx = len(messages)
+	}
+
+	f, err := os.OpenFile(b.path(id), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	if _, err := fmt.Fprintf(f, "%s\n", data); err != nil {
+		return 0, err
+	}
+	b.counts[id] = idx + 1
+	return idx, nil
+}
+
+func (b *JSONLBackend[M]) SetTitle(id, title string) error {
+	return b.rewriteHeader(id, func(h *sessionHeader) { h.Title = title })
+}
+
+func (b *JSONLBackend[M]) SetSummary(id string, summary Summary) error {
+	return b.rewriteHeader(id, func(h *sessionHeader) {
+		if summary.Text == "" {
+			h.Summary = nil
+		} else {
+			h.Summary = &summary
+		}
+	})
+}
+
+// rewriteHeader replaces the header line, copying the messages unchanged into
+// a temporary file that is renamed over the session file.
+func (b *JSONLBackend[M]) rewriteHeader(id string, update func(*sessionHeader)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	data, err := os.ReadFile(b.path(id))
+	if os.IsNotExist(err) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return err
+	}
+	first, rest, _ := bytes.Cut(data, []byte("\n"))
+	var header sessionHeader
+	if err := json.Unmarshal(first, &header); err != nil {
+		return fmt.Errorf("bad session header in %s: %w", b.path(id), err)
+	}
+	update(&header)
+	line, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+
+	tmp := b.path(id) + ".tmp"
+	out := make([]byte, 0, len(line)+1+len(rest))
+	out = append(append(append(out, line...), '\n'), rest...)
+	if err := os.WriteFile(tmp, out, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, b.path(id))
+}
+
+func (b *JSONLBackend[M]) List(q ListQuery) ([]SessionMeta, error) {
+	entries, err := os.ReadDir(b.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var metas []SessionMeta
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".jsonl") {
+			continue
+		}
+		path := filepath.Join(b.dir, e.Name())
+		var (
+			header  sessionHeader
+			title   string
+			loadErr error
+		)
+		if q.Search == "" {
+			// Only the header (and, for untitled sessions, the first user
+			// message) is needed to list a session.
+			header, title, loadErr = b.loadListing(path)
+		} else {
+			var messages []M
+			header, messages, loadErr = b.load(path)
+			title = truncateTitle(header.Title)
+			if title == "" {
+				title = deriveTitle(messages)
+			}
+			if loadErr == nil && !matchesSearch(title, messages, q.Search) {
+				continue
+			}
+		}
+		if loadErr != nil {
+			continue
+		}
+		metas = append(metas, SessionMeta{
+			ID:        strings.TrimSuffix(e.Name(), ".jsonl"),
+			Title:     title,
+			CreatedAt: header.CreatedAt,
+		})
+	}
+	return paginate(metas, q), nil
+}
+
+func (b *JSONLBackend[M]) Delete(id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, p := range []string{b.path(id), b.metaPath(id)} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	delete(b.meta, id)
+	delete(b.counts, id)
+	return nil
+}
+
+func (b *JSONLBackend[M]) SetMeta(id, key, value string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, err := b.metaLocked(id)
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		delete(m, key)
+	} else {
+		m[key] = value
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	tmp := b.metaPath(id) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, b.metaPath(id))
+}
+
+func (b *JSONLBackend[M]) GetMeta(id, key string) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, err := b.metaLocked(id)
+	if err != nil {
+		return "", err
+	}
+	return m[key], nil
+}
+
+func (b *JSONLBackend[M]) metaPath(id string) string {
+	return filepath.Join(b.dir, id+".meta.json")
+}
+
+// metaLocked returns the cached metadata for id, reading the sidecar file on first use.
+func (b *JSONLBackend[M]) metaLocked(id string) (map[string]string, error) {
+	if m, ok := b.meta[id]; ok {
+		return m, nil
+	}
+	m := make(map[string]string)
+	data, err := os.ReadFile(b.metaPath(id))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("bad session metadata for %s: %w", id, err)
+		}
+	}
+	b.meta[id] = m
+	return m, nil
+}
+
+func (b *JSONLBackend[M]) Shared() bool { return false }
+
+// loadListing reads the header of a session file and returns its display
+// title, scanning messages only until the first user message when the header
+// has no title.
+func (b *JSONLBackend[M]) loadListing(filePath string) (sessionHeader, string, error) {
+	var header sessionHeader
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return header, "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	if !scanner.Scan() {
+		return header, "", fmt.Errorf("empty session file: %s", filePath)
+	}
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return header, "", fmt.Errorf("bad session header in %s: %w", filePath, err)
+	}
+	if err := msgops.ValidateKind(header.MessageKind, b.kind, true); err != nil {
+		return header, "", err
+	}
+	if header.Title != "" {
+		return header, truncateTitle(header.Title), nil
+	}
+	for scanner.Scan() {
+		msg, err := msgops.UnmarshalMessage[M](scanner.Bytes())
+		if err != nil {
+			continue
+		}
+		if text := msgops.UserText(msg); text != "" {
+			return header, truncateTitle(text), nil
+		}
+	}
+	return header, deriveTitle[M](nil), scanner.Err()
+}
+
+func (b *JSONLBackend[M]) load(filePath string) (sessionHeader, []M, error) {
+	var header sessionHeader
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return header, nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	// First line: header
+	if !scanner.Scan() {
+		return header, nil, fmt.Errorf("empty session file: %s", filePath)
+	}
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return header, nil, fmt.Errorf("bad session header in %s: %w", filePath, err)
+	}
+	if err := msgops.ValidateKind(header.MessageKind, b.kind, true); err != nil {
+		return header, nil, fmt.Errorf("cannot load session %s: %w", filePath, err)
+	}
+
+	// Remaining lines: messages
+	messages := make([]M, 0)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		msg, err := msgops.UnmarshalMessage[M]([]byte(line))
+		if err != nil {
+			continue // skip malformed lines
+		}
+		messages = append(messages, msgops.NormalizeForSession(msg))
+	}
+
+	return header, messages, scanner.Err()
+}
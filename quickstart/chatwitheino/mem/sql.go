@@ -0,0 +1,359 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mem
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/adk"
+	_ "modernc.org/sqlite" // registers the "sqlite" database/sql driver
+
+	"github.com/cloudwego/eino-examples/quickstart/chatwitheino/msgops"
+)
+
+// Dialect selects the placeholder style used by SQLBackend. The schema and
+// statements otherwise stick to SQL understood by both SQLite and Postgres.
+type Dialect int
+
+const (
+	DialectSQLite   Dialect = iota // ? placeholders
+	DialectPostgres                // $1, $2, ... placeholders
+)
+
+// timeLayout is fixed-width so created_at sorts correctly as text.
+const timeLayout = "2006-01-02T15:04:05.000000000Z"
+
+// Header fields other than created_at live in the metadata table. The
+// generated title is also copied into sessions.title so List reads one table.
+const (
+	metaHeaderTitle   = "header_title"
+	metaHeaderSummary = "header_summary"
+)
+
+var sqlSchema = []string{
+	`CREATE TABLE IF NOT EXISTS sessions (
+		id           TEXT PRIMARY KEY,
+		created_at   TEXT NOT NULL,
+		message_kind TEXT NOT NULL DEFAULT '',
+		title        TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE TABLE IF NOT EXISTS messages (
+		session_id TEXT    NOT NULL,
+		seq        INTEGER NOT NULL,
+		data       TEXT    NOT NULL,
+		text       TEXT    NOT NULL DEFAULT '',
+		PRIMARY KEY (session_id, seq)
+	)`,
+	`CREATE TABLE IF NOT EXISTS metadata (
+		session_id TEXT NOT NULL,
+		key        TEXT NOT NULL,
+		value      TEXT NOT NULL,
+		PRIMARY KEY (session_id, key)
+	)`,
+	`CREATE INDEX IF NOT EXISTS sessions_created_at ON sessions (created_at)`,
+}
+
+// SQLBackend stores sessions in the sessions, messages and metadata tables of a
+// SQL database. Every write is a single statement, so several server processes
+// may share one database.
+type SQLBackend[M adk.MessageType] struct {
+	db      *sql.DB
+	dialect Dialect
+	kind    msgops.Kind
+}
+
+// OpenSQLite opens (or creates) a SQLite database file and returns a backend on it.
+// WAL mode and a busy timeout let several processes write to the same file.
+func OpenSQLite[M adk.MessageType](path string) (*SQLBackend[M], error) {
+	dsn := "file:" + path + "?_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)&_txlock=immediate"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open session db: %w", err)
+	}
+	return NewSQLBackend[M](db, DialectSQLite)
+}
+
+// NewSQLBackend creates the tables if needed and returns a backend on db.
+func NewSQLBackend[M adk.MessageType](db *sql.DB, dialect Dialect) (*SQLBackend[M], error) {
+	b := &SQLBackend[M]{db: db, dialect: dialect, kind: msgops.KindOf[M]()}
+	for _, stmt := range sqlSchema {
+		if _, err := db.Exec(stmt); err != nil {
+			return nil, fmt.Errorf("failed to create session tables: %w", err)
+		}
+	}
+	return b, nil
+}
+
+// Close closes the underlying database.
+func (b *SQLBackend[M]) Close() error {
+	return b.db.Close()
+}
+
+func (b *SQLBackend[M]) Create(id string, createdAt time.Time) error {
+	_, err := b.db.Exec(b.rebind(
+		`INSERT INTO sessions (id, created_at, message_kind) VALUES (?, ?, ?) ON CONFLICT (id) DO NOTHING`),
+		id, createdAt.UTC().Format(timeLayout), string(b.kind))
+	return err
+}
+
+func (b *SQLBackend[M]) Load(id string) (Header, []M, error) {
+	var createdAt, kind string
+	err := b.db.QueryRow(b.rebind(`SELECT created_at, message_kind FROM sessions WHERE id = ?`), id).
+		Scan(&createdAt, &kind)
+	if err == sql.ErrNoRows {
+		return Header{}, nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return Header{}, nil, err
+	}
+	if err := msgops.ValidateKind(msgops.Kind(kind), b.kind, true); err != nil {
+		return Header{}, nil, fmt.Errorf("cannot load session %s: %w", id, err)
+	}
+	var header Header
+	if header.CreatedAt, err = time.Parse(timeLayout, createdAt); err != nil {
+		return Header{}, nil, fmt.Errorf("bad created_at for session %s: %w", id, err)
+	}
+	if header.Title, err = b.GetMeta(id, metaHeaderTitle); err != nil {
+		return Header{}, nil, err
+	}
+	summary, err := b.GetMeta(id, metaHeaderSummary)
+	if err != nil {
+		return Header{}, nil, err
+	}
+	if summary != "" {
+		if err := json.Unmarshal([]byte(summary), &header.Summary); err != nil {
+			return Header{}, nil, fmt.Errorf("bad summary for session %s: %w", id, err)
+		}
+	}
+	messages, err := b.LoadAfter(id, 0)
+	if err != nil {
+		return Header{}, nil, err
+	}
+	return header, messages, nil
+}
+
+func (b *SQLBackend[M]) LoadAfter(id string, n int) ([]M, error) {
+	query := `SELECT data FROM messages WHERE session_id = ? ORDER BY seq OFFSET ?`
+	if b.dialect == DialectSQLite {
+		query = `SELECT data FROM messages WHERE session_id = ? ORDER BY seq LIMIT -1 OFFSET ?`
+	}
+	rows, err := b.db.Query(b.rebind(query), id, n)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := make([]M, 0)
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		msg, err := msgops.UnmarshalMessage[M]([]byte(data))
+		if err != nil {
+			continue // skip malformed rows, as the JSONL backend skips malformed lines
+		}
+		messages = append(messages, msgops.NormalizeForSession(msg))
+	}
+	return messages, rows.Err()
+}
+
+func (b *SQLBackend[M]) Append(id string, msg M) (int, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return 0, err
+	}
+	seq, err := b.insertMessage(id, string(data), msgops.Text(msg))
+	if err != nil {
+		return 0, err
+	}
+	if text := msgops.UserText(msg); text != "" {
+		if _, err := b.db.Exec(b.rebind(`UPDATE sessions SET title = ? WHERE id = ? AND title = ''`),
+			truncateTitle(text), id); err != nil {
+			return 0, err
+		}
+	}
+	// Sequence numbers start at 1 and have no gaps, so seq-1 is the index
+	// LoadAfter reads the message at.
+	return seq - 1, nil
+}
+
+// appendAttempts bounds how often Append retries after losing a race for the
+// next sequence number.
+const appendAttempts = 10
+
+// insertMessage stores a message under the session's next sequence number
+// and returns that number. The number is computed inside the INSERT, which
+// SQLite serializes. Postgres runs concurrent INSERTs side by side, so two
+// writers may compute the same number; the loser inserts nothing and tries
+// again with the new maximum.
+func (b *SQLBackend[M]) insertMessage(id, data, text string) (int, error) {
+	for i := 0; i < appendAttempts; i++ {
+		var seq int
+		err := b.db.QueryRow(b.rebind(
+			`INSERT INTO messages (session_id, seq, data, text)
+			 SELECT ?, COALESCE(MAX(seq), 0) + 1, ?, ? FROM messages WHERE session_id = ?
+			 ON CONFLICT (session_id, seq) DO NOTHING
+			 RETURNING seq`),
+			id, data, text, id).Scan(&seq)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		return seq, err
+	}
+	return 0, fmt.Errorf("failed to append to session %s: sequence number contended %d times", id, appendAttempts)
+}
+
+func (b *SQLBackend[M]) SetTitle(id, title string) error {
+	tx, err := b.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.Exec(b.rebind(
+		`INSERT INTO metadata (session_id, key, value) VALUES (?, ?, ?)
+		 ON CONFLICT (session_id, key) DO UPDATE SET value = excluded.value`),
+		id, metaHeaderTitle, title); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(b.rebind(`UPDATE sessions SET title = ? WHERE id = ?`), truncateTitle(title), id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (b *SQLBackend[M]) SetSummary(id string, summary Summary) error {
+	var value string
+	if summary.Text != "" {
+		data, err := json.Marshal(summary)
+		if err != nil {
+			return err
+		}
+		value = string(data)
+	}
+	return b.SetMeta(id, metaHeaderSummary, value)
+}
+
+func (b *SQLBackend[M]) List(q ListQuery) ([]SessionMeta, error) {
+	query := `SELECT id, created_at, title FROM sessions s WHERE (message_kind = ? OR (message_kind = '' AND ?))`
+	args := []any{string(b.kind), b.kind == msgops.KindMessage}
+	if q.Search != "" {
+		pattern := "%" + escapeLike(strings.ToLower(q.Search)) + "%"
+		query += ` AND (LOWER(title) LIKE ? ESCAPE '\' OR EXISTS (
+			SELECT 1 FROM messages m WHERE m.session_id = s.id AND LOWER(m.text) LIKE ? ESCAPE '\'))`
+		args = append(args, pattern, pattern)
+	}
+	query += ` ORDER BY created_at DESC, id`
+	if q.Limit > 0 {
+		query += ` LIMIT ` + strconv.Itoa(q.Limit)
+	}
+	if q.Offset > 0 {
+		if q.Limit <= 0 && b.dialect == DialectSQLite {
+			query += ` LIMIT -1`
+		}
+		query += ` OFFSET ` + strconv.Itoa(q.Offset)
+	}
+
+	rows, err := b.db.Query(b.rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var metas []SessionMeta
+	for rows.Next() {
+		var meta SessionMeta
+		var createdAt string
+		if err := rows.Scan(&meta.ID, &createdAt, &meta.Title); err != nil {
+			return nil, err
+		}
+		if meta.CreatedAt, err = time.Parse(timeLayout, createdAt); err != nil {
+			return nil, fmt.Errorf("bad created_at for session %s: %w", meta.ID, err)
+		}
+		if meta.Title == "" {
+			meta.Title = "New Session"
+		}
+		metas = append(metas, meta)
+	}
+	return metas, rows.Err()
+}
+
+func (b *SQLBackend[M]) Delete(id string) error {
+	tx, err := b.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	for _, stmt := range []string{
+		`DELETE FROM messages WHERE session_id = ?`,
+		`DELETE FROM metadata WHERE session_id = ?`,
+		`DELETE FROM sessions WHERE id = ?`,
+	} {
+		if _, err := tx.Exec(b.rebind(stmt), id); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (b *SQLBackend[M]) SetMeta(id, key, value string) error {
+	_, err := b.db.Exec(b.rebind(
+		`INSERT INTO metadata (session_id, key, value) VALUES (?, ?, ?)
+		 ON CONFLICT (session_id, key) DO UPDATE SET value = excluded.value`),
+		id, key, value)
+	return err
+}
+
+func (b *SQLBackend[M]) GetMeta(id, key string) (string, error) {
+	var value string
+	err := b.db.QueryRow(b.rebind(`SELECT value FROM metadata WHERE session_id = ? AND key = ?`), id, key).
+		Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return value, err
+}
+
+func (b *SQLBackend[M]) Shared() bool { return true }
+
+// rebind rewrites ? placeholders for dialects that number them.
+func (b *SQLBackend[M]) rebind(query string) string {
+	if b.dialect != DialectPostgres {
+		return query
+	}
+	var sb strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			sb.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -17,12 +17,9 @@
 package mem
 
 import (
-	"bufio"
-	"encoding/json"
-	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
+	"errors"
+	"log"
+	"strconv"
 	"sync"
 	"time"
 
@@ -43,114 +40,166 @@ type Session[M adk.MessageType] struct {
 	ID        string
 	CreatedAt time.Time
 
-	filePath           string
-	mu                 sync.Mutex
-	messages           []M
-	pendingInterruptID string // non-empty while the agent is paused awaiting human approval
-	msgIdx             int    // A2UI component slot index at the point of last interrupt
+	backend  Backend[M]
+	mu       sync.Mutex
+	messages []M     // every stored message, in storage order
+	title    string  // generated title, "" until a Summarizer names the session
+	summary  Summary // rolling summary of the start of the active branch
+
+	// Conversation tree over messages: parents[i] is the index of the message
+	// that i follows (-1 for a root), and head is the last message of the
+	// active branch (-1 when the active branch is empty).
+	parents      []int
+	head         int
+	explicitHead bool // head was set by Fork/Rewind/SwitchBranch and is persisted
 }
 
 // SetPendingInterruptID saves the interrupt ID so the approve endpoint can resume it.
 func (s *Session[M]) SetPendingInterruptID(id string) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.pendingInterruptID = id
+	if err := s.backend.SetMeta(s.ID, metaPendingInterruptID, id); err != nil {
+		log.Printf("warn: failed to persist pending interrupt for session %s: %v", s.ID, err)
+	}
 }
 
 // GetPendingInterruptID returns the stored interrupt ID, or "" if none is pending.
 func (s *Session[M]) GetPendingInterruptID() string {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	return s.pendingInterruptID
+	id, err := s.backend.GetMeta(s.ID, metaPendingInterruptID)
+	if err != nil {
+		log.Printf("warn: failed to read pending interrupt for session %s: %v", s.ID, err)
+	}
+	return id
 }
 
 // SetMsgIdx stores the A2UI component slot counter so a resume can continue from it.
 func (s *Session[M]) SetMsgIdx(idx int) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.msgIdx = idx
+	if err := s.backend.SetMeta(s.ID, metaMsgIdx, strconv.Itoa(idx)); err != nil {
+		log.Printf("warn: failed to persist msgIdx for session %s: %v", s.ID, err)
+	}
 }
 
 // GetMsgIdx returns the stored component slot counter.
 func (s *Session[M]) GetMsgIdx() int {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	return s.msgIdx
+	v, err := s.backend.GetMeta(s.ID, metaMsgIdx)
+	if err != nil {
+		log.Printf("warn: failed to read msgIdx for session %s: %v", s.ID, err)
+	}
+	idx, _ := strconv.Atoi(v)
+	return idx
 }
 
-// Append adds a message to memory and persists it to disk.
+// Append adds a message to the end of the active branch and persists it
+// through the backend.
 func (s *Session[M]) Append(msg M) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	msg = msgops.NormalizeForSession(msg)
-	s.messages = append(s.messages, msg)
+	if s.backend.Shared() {
+		// Another replica may have appended since our last read; pick up its
+		// messages first so our index matches storage.
+		if err := s.syncLocked(); err != nil {
+			return err
+		}
+	}
 
-	data, err := json.Marshal(msg)
+	msg = msgops.NormalizeForSession(msg)
+	parent := s.head
+	idx, err := s.backend.Append(s.ID, msg)
 	if err != nil {
 		return err
 	}
-
-	f, err := os.OpenFile(s.filePath, os.O_APPEND|os.O_WRONLY, 0o644)
-	if err != nil {
+	if idx == len(s.messages) {
+		s.messages = append(s.messages, msg)
+		s.parents = append(s.parents, idx-1)
+	} else if err := s.syncLocked(); err != nil {
+		// Another replica appended between our sync and our insert; reload
+		// so our message lands at the index storage gave it.
 		return err
 	}
-	defer f.Close()
-
-	_, err = fmt.Fprintf(f, "%s\n", data)
-	return err
+	return s.addNodeLocked(idx, parent)
 }
 
-// GetMessages returns a snapshot of all messages.
+// GetMessages returns a snapshot of the messages on the active branch.
 func (s *Session[M]) GetMessages() []M {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	result := make([]M, len(s.messages))
-	copy(result, s.messages)
+	if s.backend.Shared() {
+		if err := s.syncLocked(); err != nil {
+			log.Printf("warn: failed to refresh session %s: %v", s.ID, err)
+		}
+	}
+
+	path := s.pathLocked(s.head)
+	result := make([]M, len(path))
+	for i, idx := range path {
+		result[i] = s.messages[idx]
+	}
 	return result
 }
 
-// Title derives a display title from the first user message.
+// Title returns the generated title, or one derived from the first user
+// message if the session has not been titled yet.
 func (s *Session[M]) Title() string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	if s.title != "" {
+		return truncateTitle(s.title)
+	}
+	return deriveTitle(s.messages)
+}
 
-	for _, msg := range s.messages {
-		if text := msgops.UserText(msg); text != "" {
-			title := text
-			if len([]rune(title)) > 60 {
-				title = string([]rune(title)[:60]) + "..."
-			}
-			return title
-		}
+// HasGeneratedTitle reports whether SetTitle has named the session.
+func (s *Session[M]) HasGeneratedTitle() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.title != ""
+}
+
+// SetTitle stores a generated title in the session header.
+func (s *Session[M]) SetTitle(title string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.backend.SetTitle(s.ID, title); err != nil {
+		return err
+	}
+	s.title = title
+	return nil
+}
+
+// syncLocked appends messages written to the backend since the last read
+// and reloads the branch structure that goes with them.
+func (s *Session[M]) syncLocked() error {
+	fresh, err := s.backend.LoadAfter(s.ID, len(s.messages))
+	if err != nil {
+		return err
 	}
-	return "New Session"
+	s.messages = append(s.messages, fresh...)
+	return s.loadTreeLocked()
 }
 
-// Store manages persisted sessions backed by JSONL files.
-//
-// File format:
-//
-//	{"type":"session","id":"...","created_at":"...","message_kind":"agentic"}   ← header (line 1)
-//	{"role":"user","content_blocks":[...]}                                      ← message (lines 2+)
+// Store manages persisted sessions on top of a Backend, caching the Session
+// objects it hands out.
 type Store[M adk.MessageType] struct {
-	dir   string
-	kind  msgops.Kind
-	mu    sync.Mutex
-	cache map[string]*Session[M]
+	backend Backend[M]
+	mu      sync.Mutex
+	cache   map[string]*Session[M]
 }
 
-// NewStore creates a new Store backed by the given directory (created if absent).
+// NewStore creates a new Store backed by JSONL files in the given directory (created if absent).
 func NewStore[M adk.MessageType](dir string) (*Store[M], error) {
-	if err := os.MkdirAll(dir, 0o755); err != nil {
-		return nil, fmt.Errorf("failed to create session dir: %w", err)
+	backend, err := NewJSONLBackend[M](dir)
+	if err != nil {
+		return nil, err
 	}
+	return NewStoreWithBackend[M](backend), nil
+}
+
+// NewStoreWithBackend creates a Store that persists sessions through backend.
+func NewStoreWithBackend[M adk.MessageType](backend Backend[M]) *Store[M] {
 	return &Store[M]{
-		dir:   dir,
-		kind:  msgops.KindOf[M](),
-		cache: make(map[string]*Session[M]),
-	}, nil
+		backend: backend,
+		cache:   make(map[string]*Session[M]),
+	}
 }
 
 // GetOrCreate returns the session for id, creating it if it does not exist.
@@ -162,139 +211,52 @@ func (s *Store[M]) GetOrCreate(id string) (*Session[M], error) {
 		return sess, nil
 	}
 
-	filePath := filepath.Join(s.dir, id+".jsonl")
-
-	var (
-		sess *Session[M]
-		err  error
-	)
-	if _, statErr := os.Stat(filePath); os.IsNotExist(statErr) {
-		sess, err = createSession[M](id, filePath)
-	} else {
-		sess, err = loadSession[M](filePath)
+	header, messages, err := s.backend.Load(id)
+	if errors.Is(err, ErrSessionNotFound) {
+		if err = s.backend.Create(id, time.Now().UTC()); err != nil {
+			return nil, err
+		}
+		// Reload rather than trusting our own timestamp: a concurrent writer
+		// may have created the session first.
+		header, messages, err = s.backend.Load(id)
 	}
 	if err != nil {
 		return nil, err
 	}
 
+	sess := &Session[M]{
+		ID:        id,
+		CreatedAt: header.CreatedAt,
+		backend:   s.backend,
+		messages:  messages,
+		title:     header.Title,
+		summary:   header.Summary,
+	}
+	if err := sess.loadTreeLocked(); err != nil {
+		return nil, err
+	}
 	s.cache[id] = sess
 	return sess, nil
 }
 
-// List returns metadata for all known sessions.
+// List returns metadata for all known sessions, newest first.
 func (s *Store[M]) List() ([]SessionMeta, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	entries, err := os.ReadDir(s.dir)
-	if err != nil {
-		return nil, err
-	}
-
-	var metas []SessionMeta
-	for _, e := range entries {
-		if e.IsDir() || !strings.HasSuffix(e.Name(), ".jsonl") {
-			continue
-		}
-		id := strings.TrimSuffix(e.Name(), ".jsonl")
-
-		if sess, ok := s.cache[id]; ok {
-			metas = append(metas, SessionMeta{ID: id, Title: sess.Title(), CreatedAt: sess.CreatedAt})
-			continue
-		}
+	return s.Search(ListQuery{})
+}
 
-		sess, loadErr := loadSession[M](filepath.Join(s.dir, e.Name()))
-		if loadErr != nil {
-			continue
-		}
-		metas = append(metas, SessionMeta{ID: id, Title: sess.Title(), CreatedAt: sess.CreatedAt})
-	}
-	return metas, nil
+// Search returns one page of session metadata matching q, newest first.
+func (s *Store[M]) Search(q ListQuery) ([]SessionMeta, error) {
+	return s.backend.List(q)
 }
 
-// Delete removes the session file and evicts it from the cache.
+// Delete removes the session from the backend and evicts it from the cache.
 func (s *Store[M]) Delete(id string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	filePath := filepath.Join(s.dir, id+".jsonl")
-	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+	if err := s.backend.Delete(id); err != nil {
 		return err
 	}
 	delete(s.cache, id)
 	return nil
 }
-
-// sessionHeader is the first JSONL line in every session file.
-type sessionHeader struct {
-	Type        string      `json:"type"`
-	ID          string      `json:"id"`
-	CreatedAt   time.Time   `json:"created_at"`
-	MessageKind msgops.Kind `json:"message_kind,omitempty"`
-}
-
-func createSession[M adk.MessageType](id, filePath string) (*Session[M], error) {
-	header := sessionHeader{
-		Type:        "session",
-		ID:          id,
-		CreatedAt:   time.Now().UTC(),
-		MessageKind: msgops.KindOf[M](),
-	}
-	data, err := json.Marshal(header)
-	if err != nil {
-		return nil, err
-	}
-	if err := os.WriteFile(filePath, append(data, '\n'), 0o644); err != nil {
-		return nil, err
-	}
-	return &Session[M]{
-		ID:        id,
-		CreatedAt: header.CreatedAt,
-		filePath:  filePath,
-		messages:  make([]M, 0),
-	}, nil
-}
-
-func loadSession[M adk.MessageType](filePath string) (*Session[M], error) {
-	f, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
-	}
-	defer f.Close()
-
-	scanner := bufio.NewScanner(f)
-
-	// First line: header
-	if !scanner.Scan() {
-		return nil, fmt.Errorf("empty session file: %s", filePath)
-	}
-	var header sessionHeader
-	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
-		return nil, fmt.Errorf("bad session header in %s: %w", filePath, err)
-	}
-	if err := msgops.ValidateKind(header.MessageKind, msgops.KindOf[M](), true); err != nil {
-		return nil, fmt.Errorf("cannot load session %s: %w", filePath, err)
-	}
-
-	sess := &Session[M]{
-		ID:        header.ID,
-		CreatedAt: header.CreatedAt,
-		filePath:  filePath,
-		messages:  make([]M, 0),
-	}
-
-	// Remaining lines: messages
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
-		msg, err := msgops.UnmarshalMessage[M]([]byte(line))
-		if err != nil {
-			continue // skip malformed lines
-		}
-		sess.messages = append(sess.messages, msgops.NormalizeForSession(msg))
-	}
-
-	return sess, scanner.Err()
-}
@@ -0,0 +1,267 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mem
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// backends returns a fresh instance of every Backend implementation.
+func backends(t *testing.T) map[string]Backend[*schema.Message] {
+	t.Helper()
+	jsonl, err := NewJSONLBackend[*schema.Message](t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlite, err := OpenSQLite[*schema.Message](filepath.Join(t.TempDir(), "sessions.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlite.Close() })
+	return map[string]Backend[*schema.Message]{"jsonl": jsonl, "sqlite": sqlite}
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	for name, backend := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			store := NewStoreWithBackend[*schema.Message](backend)
+			sess, err := store.GetOrCreate("s1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := sess.Append(schema.UserMessage("how do I build a graph?")); err != nil {
+				t.Fatal(err)
+			}
+			if err := sess.Append(schema.AssistantMessage("use compose.NewGraph", nil)); err != nil {
+				t.Fatal(err)
+			}
+			sess.SetPendingInterruptID("interrupt-1")
+			sess.SetMsgIdx(7)
+
+			// A second store over the same backend sees the persisted state.
+			reopened, err := NewStoreWithBackend[*schema.Message](backend).GetOrCreate("s1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			msgs := reopened.GetMessages()
+			if len(msgs) != 2 || msgs[1].Content != "use compose.NewGraph" {
+				t.Fatalf("unexpected messages: %+v", msgs)
+			}
+			if got := reopened.Title(); got != "how do I build a graph?" {
+				t.Fatalf("title = %q", got)
+			}
+			if got := reopened.GetPendingInterruptID(); got != "interrupt-1" {
+				t.Fatalf("pending interrupt = %q", got)
+			}
+			if got := reopened.GetMsgIdx(); got != 7 {
+				t.Fatalf("msgIdx = %d", got)
+			}
+
+			if err := store.Delete("s1"); err != nil {
+				t.Fatal(err)
+			}
+			if _, _, err := backend.Load("s1"); err != ErrSessionNotFound {
+				t.Fatalf("load after delete: %v", err)
+			}
+		})
+	}
+}
+
+func TestStoreSearchAndPagination(t *testing.T) {
+	for name, backend := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			store := NewStoreWithBackend[*schema.Message](backend)
+			base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			for i := 0; i < 5; i++ {
+				id := fmt.Sprintf("s%d", i)
+				if err := backend.Create(id, base.Add(time.Duration(i)*time.Minute)); err != nil {
+					t.Fatal(err)
+				}
+				sess, err := store.GetOrCreate(id)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := sess.Append(schema.UserMessage(fmt.Sprintf("question %d", i))); err != nil {
+					t.Fatal(err)
+				}
+			}
+			sess, _ := store.GetOrCreate("s2")
+			if err := sess.Append(schema.AssistantMessage("the answer mentions 100%_Checkpoint", nil)); err != nil {
+				t.Fatal(err)
+			}
+
+			page, err := store.Search(ListQuery{Offset: 1, Limit: 2})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(page) != 2 || page[0].ID != "s3" || page[1].ID != "s2" {
+				t.Fatalf("unexpected page: %+v", page)
+			}
+
+			byTitle, err := store.Search(ListQuery{Search: "QUESTION 4"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(byTitle) != 1 || byTitle[0].ID != "s4" {
+				t.Fatalf("title search: %+v", byTitle)
+			}
+
+			byContent, err := store.Search(ListQuery{Search: "100%_checkpoint"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(byContent) != 1 || byContent[0].ID != "s2" {
+				t.Fatalf("content search: %+v", byContent)
+			}
+		})
+	}
+}
+
+func TestStoreRejectsOtherMessageKind(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.db")
+	agentic, err := OpenSQLite[*schema.AgenticMessage](path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer agentic.Close()
+	if _, err := NewStoreWithBackend[*schema.AgenticMessage](agentic).GetOrCreate("a1"); err != nil {
+		t.Fatal(err)
+	}
+
+	legacy, err := OpenSQLite[*schema.Message](path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer legacy.Close()
+	if _, err := NewStoreWithBackend[*schema.Message](legacy).GetOrCreate("a1"); err == nil {
+		t.Fatal("expected a message_kind error")
+	}
+	metas, err := legacy.List(ListQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(metas) != 0 {
+		t.Fatalf("agentic session listed for message kind: %+v", metas)
+	}
+}
+
+func TestSQLiteConcurrentWriters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.db")
+
+	// Two stores on the same file stand in for two server replicas.
+	var stores []*Store[*schema.Message]
+	for i := 0; i < 2; i++ {
+		backend, err := OpenSQLite[*schema.Message](path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = backend.Close() })
+		stores = append(stores, NewStoreWithBackend[*schema.Message](backend))
+	}
+
+	const perWriter = 20
+	var wg sync.WaitGroup
+	for i, store := range stores {
+		sess, err := store.GetOrCreate("shared")
+		if err != nil {
+			t.Fatal(err)
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < perWriter; j++ {
+				if err := sess.Append(schema.AssistantMessage(fmt.Sprintf("w%d-%d", i, j), nil)); err != nil {
+					t.Errorf("append: %v", err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	_, stored, err := stores[0].backend.Load("shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := contents(stored)
+	seen := make(map[string]bool)
+	for _, text := range want {
+		seen[text] = true
+	}
+	if len(want) != 2*perWriter || len(seen) != 2*perWriter {
+		t.Fatalf("storage holds %d messages, %d distinct, want %d", len(want), len(seen), 2*perWriter)
+	}
+	for i, store := range stores {
+		sess, _ := store.GetOrCreate("shared")
+		sess.GetMessages() // picks up the other replica's last appends
+		var got []string
+		for _, node := range sess.Tree() {
+			got = append(got, node.Text)
+		}
+		if !equal(got, want) {
+			t.Fatalf("store %d holds %v, storage holds %v", i, got, want)
+		}
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	for name, backend := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			store := NewStoreWithBackend[*schema.Message](backend)
+			src, err := store.GetOrCreate("src")
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = src.Append(schema.UserMessage("q1"))
+			_ = src.Append(schema.AssistantMessage("a1", nil))
+			_ = src.Rewind(0)
+			_ = src.Append(schema.AssistantMessage("a1'", nil))
+			src.SetPendingInterruptID("interrupt-1")
+
+			snap, err := src.Snapshot()
+			if err != nil {
+				t.Fatal(err)
+			}
+			dst, err := store.Restore("dst", snap)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := contents(dst.GetMessages()); !equal(got, []string{"q1", "a1'"}) {
+				t.Fatalf("restored branch: %v", got)
+			}
+			if len(dst.Tree()) != 3 {
+				t.Fatalf("restored tree has %d nodes, want 3", len(dst.Tree()))
+			}
+			if got := dst.GetPendingInterruptID(); got != "interrupt-1" {
+				t.Fatalf("pending interrupt = %q", got)
+			}
+			if !dst.CreatedAt.Equal(src.CreatedAt) {
+				t.Fatalf("created_at = %v, want %v", dst.CreatedAt, src.CreatedAt)
+			}
+
+			if _, err := store.Restore("dst", snap); err == nil {
+				t.Fatal("restoring over an existing session should fail")
+			}
+		})
+	}
+}
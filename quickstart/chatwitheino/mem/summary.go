@@ -0,0 +1,302 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/adk"
+	einomodel "github.com/cloudwego/eino/components/model"
+
+	"github.com/cloudwego/eino-examples/quickstart/chatwitheino/msgops"
+)
+
+// Summary is a rolling summary of the start of a session's active branch.
+type Summary struct {
+	Text    string `json:"text"`
+	Through int    `json:"through"` // index of the last stored message the summary covers
+}
+
+// Summary returns the session's rolling summary; Text is "" if there is none.
+func (s *Session[M]) Summary() Summary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.summary
+}
+
+// SetSummary stores a rolling summary in the session header.
+func (s *Session[M]) SetSummary(summary Summary) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.backend.SetSummary(s.ID, summary); err != nil {
+		return err
+	}
+	s.summary = summary
+	return nil
+}
+
+// History returns the active branch for model input. Once the branch is
+// estimated above budget tokens and a summary of its start exists, the
+// summarized messages are replaced by a single message carrying the summary.
+// A summary written for another branch is ignored.
+func (s *Session[M]) History(budget int) []M {
+	messages, entries := s.Branch()
+	if budget <= 0 || estimateTokens(messages) <= budget {
+		return messages
+	}
+
+	summary := s.Summary()
+	if summary.Text == "" {
+		return messages
+	}
+	for i, e := range entries {
+		if e.Index == summary.Through {
+			out := make([]M, 0, len(messages)-i)
+			out = append(out, msgops.NewUser[M]("[Summary of the earlier conversation]\n"+summary.Text))
+			return append(out, messages[i+1:]...)
+		}
+	}
+	return messages
+}
+
+// estimateTokens is a deliberately rough token count: about four characters
+// per token, which is close enough to decide when to summarize.
+func estimateTokens[M adk.MessageType](messages []M) int {
+	n := 0
+	for _, msg := range messages {
+		n += len([]rune(msgops.Text(msg)))
+		for _, tc := range msgops.ToolCalls(msg) {
+			n += len([]rune(tc.Args))
+		}
+		for _, tr := range msgops.ToolResults(msg) {
+			n += len([]rune(tr.Content))
+		}
+	}
+	return n / 4
+}
+
+const (
+	titlePrompt = "Write a short title (at most six words) for the conversation below. " +
+		"Reply with the title only, without quotes or trailing punctuation."
+	summaryPrompt = "Summarize the conversation below for an assistant that will continue it. " +
+		"Keep the user's goals, decisions, facts, file paths and open questions; drop small talk. " +
+		"If an earlier summary is given, merge it with the new messages. Reply with the summary only."
+)
+
+// SummarizerConfig configures a Summarizer.
+type SummarizerConfig[M adk.MessageType] struct {
+	// Model generates titles and summaries. Required.
+	Model einomodel.BaseModel[M]
+	// TokenBudget is the estimated history size above which the start of the
+	// active branch is summarized. Default 8000.
+	TokenBudget int
+	// KeepRecent is the minimum number of recent messages that stay verbatim
+	// after the summary. Default 6.
+	KeepRecent int
+	// Timeout bounds each model call. Default 1 minute.
+	Timeout time.Duration
+}
+
+// Summarizer names sessions after their first exchange and keeps a rolling
+// summary of long sessions, both stored in the session header.
+type Summarizer[M adk.MessageType] struct {
+	cfg SummarizerConfig[M]
+
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+// NewSummarizer creates a Summarizer.
+func NewSummarizer[M adk.MessageType](cfg SummarizerConfig[M]) (*Summarizer[M], error) {
+	if cfg.Model == nil {
+		return nil, errors.New("summarizer requires a model")
+	}
+	if cfg.TokenBudget <= 0 {
+		cfg.TokenBudget = 8000
+	}
+	if cfg.KeepRecent <= 0 {
+		cfg.KeepRecent = 6
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = time.Minute
+	}
+	return &Summarizer[M]{cfg: cfg, running: make(map[string]bool)}, nil
+}
+
+// TokenBudget returns the budget to pass to Session.History.
+func (z *Summarizer[M]) TokenBudget() int {
+	return z.cfg.TokenBudget
+}
+
+// Observe runs Update for sess in the background. It is meant to be called
+// after every turn; calls made while an update for the same session is still
+// running are dropped.
+func (z *Summarizer[M]) Observe(sess *Session[M]) {
+	z.mu.Lock()
+	if z.running[sess.ID] {
+		z.mu.Unlock()
+		return
+	}
+	z.running[sess.ID] = true
+	z.mu.Unlock()
+
+	go func() {
+		defer func() {
+			z.mu.Lock()
+			delete(z.running, sess.ID)
+			z.mu.Unlock()
+		}()
+		if err := z.Update(context.Background(), sess); err != nil {
+			log.Printf("warn: failed to summarize session %s: %v", sess.ID, err)
+		}
+	}()
+}
+
+// Update titles sess if it has no generated title and its first exchange is
+// complete, then refreshes its summary if the active branch is over budget.
+func (z *Summarizer[M]) Update(ctx context.Context, sess *Session[M]) error {
+	messages, entries := sess.Branch()
+	if !sess.HasGeneratedTitle() {
+		if exchange := firstExchange(messages); exchange != nil {
+			title, err := z.generate(ctx, titlePrompt, transcript(exchange))
+			if err != nil {
+				return fmt.Errorf("title: %w", err)
+			}
+			if title = cleanTitle(title); title != "" {
+				if err := sess.SetTitle(title); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if estimateTokens(messages) <= z.cfg.TokenBudget {
+		return nil
+	}
+	cut := summaryCut(messages, z.cfg.KeepRecent)
+	if cut < 0 {
+		return nil
+	}
+
+	// Extend the current summary if it covers an earlier part of this branch;
+	// otherwise summarize the branch from its start.
+	prev := sess.Summary()
+	from := 0
+	prevText := ""
+	for i, e := range entries {
+		if prev.Text != "" && e.Index == prev.Through {
+			if i >= cut {
+				return nil // already summarized up to the cut
+			}
+			from, prevText = i+1, prev.Text
+			break
+		}
+	}
+
+	var input strings.Builder
+	if prevText != "" {
+		input.WriteString("Earlier summary:\n" + prevText + "\n\nNew messages:\n")
+	}
+	input.WriteString(transcript(messages[from : cut+1]))
+	text, err := z.generate(ctx, summaryPrompt, input.String())
+	if err != nil {
+		return fmt.Errorf("summary: %w", err)
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	return sess.SetSummary(Summary{Text: text, Through: entries[cut].Index})
+}
+
+func (z *Summarizer[M]) generate(ctx context.Context, prompt, input string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, z.cfg.Timeout)
+	defer cancel()
+	out, err := z.cfg.Model.Generate(ctx, []M{msgops.NewSystem[M](prompt), msgops.NewUser[M](input)})
+	if err != nil {
+		return "", err
+	}
+	return msgops.AssistantText(out), nil
+}
+
+// firstExchange returns the messages up to the first assistant text reply
+// that follows a user message, or nil if the exchange is not complete.
+func firstExchange[M adk.MessageType](messages []M) []M {
+	sawUser := false
+	for i, msg := range messages {
+		if msgops.UserText(msg) != "" {
+			sawUser = true
+			continue
+		}
+		if sawUser && msgops.RoleLabel(msg) == "Agent" && msgops.AssistantText(msg) != "" {
+			return messages[:i+1]
+		}
+	}
+	return nil
+}
+
+// summaryCut returns the position of the last message to summarize: the
+// latest message followed by a user message that leaves at least keep
+// messages after it, so tool calls and their results are never split. It
+// returns -1 if there is no such position.
+func summaryCut[M adk.MessageType](messages []M, keep int) int {
+	for i := len(messages) - keep - 1; i >= 0; i-- {
+		if i+1 < len(messages) && msgops.UserText(messages[i+1]) != "" {
+			return i
+		}
+	}
+	return -1
+}
+
+// transcript renders messages as plain text for the summarizer model.
+func transcript[M adk.MessageType](messages []M) string {
+	var b strings.Builder
+	for _, msg := range messages {
+		if results := msgops.ToolResults(msg); len(results) > 0 {
+			for _, r := range results {
+				fmt.Fprintf(&b, "Tool result (%s): %s\n", r.Name, clip(r.Content, 2000))
+			}
+			continue
+		}
+		if text := msgops.Text(msg); text != "" {
+			fmt.Fprintf(&b, "%s: %s\n", msgops.RoleLabel(msg), text)
+		}
+		for _, tc := range msgops.ToolCalls(msg) {
+			fmt.Fprintf(&b, "%s called %s(%s)\n", msgops.RoleLabel(msg), tc.Name, clip(tc.Args, 500))
+		}
+	}
+	return b.String()
+}
+
+func clip(text string, n int) string {
+	if r := []rune(text); len(r) > n {
+		return string(r[:n]) + "…"
+	}
+	return text
+}
+
+func cleanTitle(title string) string {
+	title, _, _ = strings.Cut(strings.TrimSpace(title), "\n")
+	title = strings.Trim(strings.TrimSpace(title), "\"'`*#")
+	return strings.TrimRight(title, ".。")
+}
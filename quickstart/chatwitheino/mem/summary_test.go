@@ -0,0 +1,119 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mem
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	einomodel "github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// fakeModel answers title prompts with a fixed title and summary prompts with
+// the number of transcript lines it was given.
+type fakeModel struct {
+	calls int
+}
+
+func (m *fakeModel) Generate(_ context.Context, input []*schema.Message, _ ...einomodel.Option) (*schema.Message, error) {
+	m.calls++
+	if strings.HasPrefix(input[0].Content, "Write a short title") {
+		return schema.AssistantMessage(`"Building Eino graphs."`, nil), nil
+	}
+	lines := strings.Count(input[1].Content, "\n")
+	return schema.AssistantMessage(strings.Repeat("s", lines), nil), nil
+}
+
+func (m *fakeModel) Stream(context.Context, []*schema.Message, ...einomodel.Option) (*schema.StreamReader[*schema.Message], error) {
+	panic("not used")
+}
+
+func TestSummarizer(t *testing.T) {
+	for name, backend := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			model := &fakeModel{}
+			z, err := NewSummarizer(SummarizerConfig[*schema.Message]{Model: model, TokenBudget: 50, KeepRecent: 2})
+			if err != nil {
+				t.Fatal(err)
+			}
+			store := NewStoreWithBackend[*schema.Message](backend)
+			sess, _ := store.GetOrCreate("z1")
+			ctx := context.Background()
+
+			_ = sess.Append(schema.UserMessage("hi"))
+			if err := z.Update(ctx, sess); err != nil {
+				t.Fatal(err)
+			}
+			if sess.HasGeneratedTitle() || model.calls != 0 {
+				t.Fatal("titled before the first exchange completed")
+			}
+
+			_ = sess.Append(schema.AssistantMessage("hello, what are we building?", nil))
+			if err := z.Update(ctx, sess); err != nil {
+				t.Fatal(err)
+			}
+			if got := sess.Title(); got != "Building Eino graphs" {
+				t.Fatalf("title = %q", got)
+			}
+			metas, _ := store.List()
+			if len(metas) != 1 || metas[0].Title != "Building Eino graphs" {
+				t.Fatalf("listed titles: %+v", metas)
+			}
+
+			// Grow the branch past the budget: 2 + 6 long messages.
+			long := strings.Repeat("x", 100)
+			for i := 0; i < 3; i++ {
+				_ = sess.Append(schema.UserMessage(long))
+				_ = sess.Append(schema.AssistantMessage(long, nil))
+			}
+			if err := z.Update(ctx, sess); err != nil {
+				t.Fatal(err)
+			}
+			summary := sess.Summary()
+			// The cut keeps the last exchange (2 messages) verbatim.
+			if summary.Through != 5 || summary.Text != "ssssss" {
+				t.Fatalf("summary = %+v", summary)
+			}
+
+			history := sess.History(z.TokenBudget())
+			if len(history) != 3 || !strings.Contains(history[0].Content, "ssssss") || history[1].Content != long {
+				t.Fatalf("history = %v", contents(history))
+			}
+			if got := len(sess.History(0)); got != 8 {
+				t.Fatalf("History(0) returned %d messages, want the full branch", got)
+			}
+
+			// Title and summary come back from the header after a reload.
+			reopened, err := NewStoreWithBackend[*schema.Message](backend).GetOrCreate("z1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reopened.Title() != "Building Eino graphs" || reopened.Summary() != summary {
+				t.Fatalf("reloaded header: %q %+v", reopened.Title(), reopened.Summary())
+			}
+
+			// A summary of another branch is not used.
+			_ = reopened.Fork(2)
+			_ = reopened.Append(schema.UserMessage(long + long + long))
+			if got := reopened.History(z.TokenBudget()); len(got) != 3 || got[0].Content != "hi" {
+				t.Fatalf("history on another branch = %v", contents(got))
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rag
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+
+	"github.com/cloudwego/eino-examples/components/document/transformer/chunker"
+	"github.com/cloudwego/eino-examples/components/retriever/rank"
+)
+
+// indexVersion is bumped whenever the cached index format or chunking changes.
+const indexVersion = 2
+
+// chunk is a contiguous slice of the document with its offsets and, for
+// Markdown, its heading breadcrumb.
+type chunk = chunker.Chunk
+
+// rrfK is the reciprocal-rank-fusion constant.
+const rrfK = 60
+
+// embedBatchSize keeps embedding requests under common provider limits.
+const embedBatchSize = 16
+
+// docIndex is the retrieval index of one document: its chunks, a BM25
+// inverted index over them and, when an embedder is configured, one vector
+// per chunk. It is cached as JSON next to the document.
+type docIndex struct {
+	Version   int    `json:"version"`
+	Hash      string `json:"hash"` // sha256 of the document content
+	ChunkSize int    `json:"chunk_size"`
+	Embedder  string `json:"embedder,omitempty"` // type of the embedder that produced Vectors
+
+	Chunks   []chunk          `json:"chunks"`
+	Postings map[string][]int `json:"postings"` // term → per-chunk pairs of (chunk index, term frequency)
+	Lengths  []int            `json:"lengths"`  // tokens per chunk
+	AvgLen   float64          `json:"avg_len"`
+	Vectors  [][]float64      `json:"vectors,omitempty"`
+}
+
+// indexPath returns where the index of the document at path is cached: a
+// hidden .rag directory in the same (session workspace) directory.
+func indexPath(path string) string {
+	return filepath.Join(filepath.Dir(path), ".rag", filepath.Base(path)+".index.json")
+}
+
+// loadOrBuildIndex returns the cached index for the document at path, or builds
+// and caches a new one if the document, chunk size or embedder changed.
+func loadOrBuildIndex(ctx context.Context, path string, o *options) (*docIndex, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %q: %w", path, err)
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	embedder := embedderName(o.embedder)
+
+	if cached, err := os.ReadFile(indexPath(path)); err == nil {
+		var ix docIndex
+		if json.Unmarshal(cached, &ix) == nil && ix.Version == indexVersion &&
+			ix.Hash == hash && ix.ChunkSize == o.chunkSize && ix.Embedder == embedder {
+			return &ix, nil
+		}
+	}
+
+	ix := buildIndex(o.chunker.Split(string(data), chunker.FormatOf(path)), o.chunkSize)
+	ix.Hash = hash
+	if o.embedder != nil {
+		if err := ix.embed(ctx, o.embedder); err != nil {
+			// Retrieval still works on BM25 alone; the next call retries embedding.
+			log.Printf("warn: embedding %s failed, using BM25 only: %v", path, err)
+		} else {
+			ix.Embedder = embedder
+		}
+	}
+
+	if err := saveIndex(indexPath(path), ix); err != nil {
+		log.Printf("warn: failed to cache index for %s: %v", path, err)
+	}
+	return ix, nil
+}
+
+func saveIndex(path string, ix *docIndex) error {
+	data, err := json.Marshal(ix)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// buildIndex builds the BM25 inverted index over chunks.
+func buildIndex(chunks []chunk, chunkSize int) *docIndex {
+	ix := &docIndex{
+		Version:   indexVersion,
+		ChunkSize: chunkSize,
+		Chunks:    chunks,
+		Postings:  make(map[string][]int),
+	}
+	ix.Lengths = make([]int, len(ix.Chunks))
+	total := 0
+	for i, c := range ix.Chunks {
+		tf := make(map[string]int)
+		for _, term := range rank.Tokenize(indexText(c)) {
+			tf[term]++
+			ix.Lengths[i]++
+		}
+		total += ix.Lengths[i]
+		for term, n := range tf {
+			ix.Postings[term] = append(ix.Postings[term], i, n)
+		}
+	}
+	if len(ix.Chunks) > 0 {
+		ix.AvgLen = float64(total) / float64(len(ix.Chunks))
+	}
+	return ix
+}
+
+func (ix *docIndex) embed(ctx context.Context, e embedding.Embedder) error {
+	vectors := make([][]float64, 0, len(ix.Chunks))
+	for i := 0; i < len(ix.Chunks); i += embedBatchSize {
+		batch := make([]string, 0, embedBatchSize)
+		for _, c := range ix.Chunks[i:min(i+embedBatchSize, len(ix.Chunks))] {
+			batch = append(batch, indexText(c))
+		}
+		out, err := e.EmbedStrings(ctx, batch)
+		if err != nil {
+			return err
+		}
+		if len(out) != len(batch) {
+			return fmt.Errorf("embedder returned %d vectors for %d texts", len(out), len(batch))
+		}
+		vectors = append(vectors, out...)
+	}
+	ix.Vectors = vectors
+	return nil
+}
+
+// indexText is what gets indexed for c: its text prefixed with its heading
+// breadcrumb, so a chunk deep in a section still matches the section title.
+func indexText(c chunk) string {
+	if len(c.Headings) == 0 {
+		return c.Text
+	}
+	return strings.Join(c.Headings, " > ") + "\n" + c.Text
+}
+
+// bm25 returns the chunks that share at least one term with query, best first.
+func (ix *docIndex) bm25(query string) []int {
+	n := float64(len(ix.Chunks))
+	scores := make(map[int]float64)
+	seen := make(map[string]bool)
+	for _, term := range rank.Tokenize(query) {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		postings := ix.Postings[term]
+		df := float64(len(postings) / 2)
+		if df == 0 {
+			continue
+		}
+		idf := rank.IDF(n, df)
+		for p := 0; p < len(postings); p += 2 {
+			i, tf := postings[p], float64(postings[p+1])
+			scores[i] += rank.BM25(idf, tf, float64(ix.Lengths[i]), ix.AvgLen)
+		}
+	}
+	return rankByScore(scores)
+}
+
+// nearest returns every chunk ordered by cosine similarity to qvec.
+func (ix *docIndex) nearest(qvec []float64) []int {
+	scores := make(map[int]float64, len(ix.Vectors))
+	for i, v := range ix.Vectors {
+		scores[i] = rank.Cosine(qvec, v)
+	}
+	return rankByScore(scores)
+}
+
+// search fuses the BM25 ranking and, if qvec is set, the vector ranking with
+// reciprocal rank fusion and returns up to n chunks. If neither ranking finds
+// anything, the first n chunks are returned so the reranker still sees the
+// start of the document.
+func (ix *docIndex) search(query string, qvec []float64, n int) []chunk {
+	fused := make(map[int]float64)
+	for pos, i := range ix.bm25(query) {
+		fused[i] += 1.0 / float64(rrfK+pos+1)
+	}
+	if len(qvec) > 0 && len(ix.Vectors) == len(ix.Chunks) {
+		for pos, i := range ix.nearest(qvec) {
+			fused[i] += 1.0 / float64(rrfK+pos+1)
+		}
+	}
+
+	ranked := rankByScore(fused)
+	if len(ranked) == 0 {
+		for i := range ix.Chunks {
+			ranked = append(ranked, i)
+		}
+	}
+	if len(ranked) > n {
+		ranked = ranked[:n]
+	}
+	out := make([]chunk, len(ranked))
+	for k, i := range ranked {
+		out[k] = ix.Chunks[i]
+	}
+	return out
+}
+
+// rankByScore returns the keys of scores ordered by descending score, ties by key.
+func rankByScore(scores map[int]float64) []int {
+	ranked := make([]int, 0, len(scores))
+	for i := range scores {
+		ranked = append(ranked, i)
+	}
+	sort.Slice(ranked, func(a, b int) bool {
+		if scores[ranked[a]] != scores[ranked[b]] {
+			return scores[ranked[a]] > scores[ranked[b]]
+		}
+		return ranked[a] < ranked[b]
+	})
+	return ranked
+}
+
+func embedderName(e embedding.Embedder) string {
+	if e == nil {
+		return ""
+	}
+	if name, ok := components.GetType(e); ok {
+		return name
+	}
+	return fmt.Sprintf("%T", e)
+}
@@ -0,0 +1,176 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	einomodel "github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+)
+
+// testDoc has one paragraph about checkpoints among many unrelated ones.
+func testDoc() string {
+	var b strings.Builder
+	for i := 0; i < 40; i++ {
+		fmt.Fprintf(&b, "Paragraph %d talks about graph nodes and edges in general terms.\n\n", i)
+		if i == 25 {
+			b.WriteString("Checkpoints persist interrupted runs.\nResume reads the checkpoint store by ID.\n\n")
+		}
+	}
+	return b.String()
+}
+
+// fakeEmbedder embeds text as counts of a few keywords.
+type fakeEmbedder struct{ calls atomic.Int32 }
+
+func (e *fakeEmbedder) EmbedStrings(_ context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	e.calls.Add(1)
+	out := make([][]float64, len(texts))
+	for i, text := range texts {
+		lower := strings.ToLower(text)
+		out[i] = []float64{
+			float64(strings.Count(lower, "checkpoint") + strings.Count(lower, "resume") + strings.Count(lower, "persist")),
+			float64(strings.Count(lower, "graph")),
+			1,
+		}
+	}
+	return out, nil
+}
+
+func TestIndexSearchAndCache(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "doc.md")
+	if err := os.WriteFile(path, []byte(testDoc()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	emb := &fakeEmbedder{}
+	o, err := newOptions(ctx, WithEmbedder(emb), WithTopN(3), WithChunkSize(200))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ix, err := loadOrBuildIndex(ctx, path, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ix.Vectors) != len(ix.Chunks) {
+		t.Fatalf("%d vectors for %d chunks", len(ix.Vectors), len(ix.Chunks))
+	}
+	top := ix.search("how do I resume from a checkpoint?", []float64{1, 0, 0}, 3)
+	if len(top) != 3 || !strings.Contains(top[0].Text, "Checkpoints persist") {
+		t.Fatalf("top chunk = %q", top[0].Text)
+	}
+
+	// A second load reuses the cache without embedding again.
+	calls := emb.calls.Load()
+	if _, err := loadOrBuildIndex(ctx, path, o); err != nil {
+		t.Fatal(err)
+	}
+	if emb.calls.Load() != calls {
+		t.Fatal("cached index was rebuilt")
+	}
+
+	// Changing the document invalidates the cache.
+	if err := os.WriteFile(path, []byte("Only one paragraph about checkpoints."), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ix, err = loadOrBuildIndex(ctx, path, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ix.Chunks) != 1 {
+		t.Fatalf("stale index: %d chunks", len(ix.Chunks))
+	}
+}
+
+// rerankModel scores chunks mentioning checkpoints and answers with a fixed text.
+type rerankModel struct{ scored atomic.Int32 }
+
+func (m *rerankModel) Generate(_ context.Context, input []*schema.Message, _ ...einomodel.Option) (*schema.Message, error) {
+	prompt := input[len(input)-1].Content
+	if strings.HasPrefix(prompt, "Rate how relevant") {
+		m.scored.Add(1)
+		if strings.Contains(prompt, "Checkpoints persist") {
+			return schema.AssistantMessage(`{"score": 9, "excerpt": "Resume reads the checkpoint store by ID."}`, nil), nil
+		}
+		return schema.AssistantMessage(`{"score": 0, "excerpt": ""}`, nil), nil
+	}
+	return schema.AssistantMessage("Resume looks the checkpoint up by ID [1].", nil), nil
+}
+
+func (m *rerankModel) Stream(context.Context, []*schema.Message, ...einomodel.Option) (*schema.StreamReader[*schema.Message], error) {
+	panic("not used")
+}
+
+func TestToolReranksRetrievedChunks(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "doc.md")
+	doc := testDoc()
+	if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cm := &rerankModel{}
+	bt, err := BuildTool[*schema.Message](ctx, cm, WithTopN(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	args, _ := json.Marshal(Input{FilePath: path, Question: "How does resume find the checkpoint?"})
+	raw, err := bt.(tool.InvokableTool).InvokableRun(ctx, string(args))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out Output
+	if err := json.Unmarshal([]byte(raw), &out); err != nil {
+		t.Fatal(err)
+	}
+	// Only one chunk shares terms with the question, so only it is reranked.
+	if got := cm.scored.Load(); got != 1 {
+		t.Fatalf("model scored %d chunks, want 1", got)
+	}
+	// A question matching every chunk is still capped at the top N.
+	cm.scored.Store(0)
+	args, _ = json.Marshal(Input{FilePath: path, Question: "graph nodes and edges"})
+	if _, err := bt.(tool.InvokableTool).InvokableRun(ctx, string(args)); err != nil {
+		t.Fatal(err)
+	}
+	if got := cm.scored.Load(); got != 4 {
+		t.Fatalf("model scored %d chunks, want 4", got)
+	}
+
+	if len(out.Citations) != 1 {
+		t.Fatalf("citations = %+v", out.Citations)
+	}
+	c := out.Citations[0]
+	if doc[c.Start:c.End] != "Resume reads the checkpoint store by ID." {
+		t.Fatalf("citation points at %q", doc[c.Start:c.End])
+	}
+	if want := strings.Count(doc[:c.Start], "\n") + 1; c.StartLine != want {
+		t.Fatalf("citation line = %d, want %d", c.StartLine, want)
+	}
+}
@@ -17,15 +17,17 @@
 // Package rag provides an answer_from_document tool backed by a compose.Workflow.
 //
 // The workflow uses field mapping to share the user's question across non-adjacent
-// nodes (score, answer) without threading it through every intermediate output type:
+// nodes (retrieve, score, answer) without threading it through every intermediate
+// output type:
 //
 //	START{FilePath, Question}
 //	  │ (data via WithNoDirectDependency)──────────────────────────────────────────┐
 //	  ▼                                                                            │ Question
-//	[load]  os.ReadFile → []*schema.Document                                       │
-//	  ▼                                                                            │
-//	[chunk] paragraph splitter → []*schema.Document                               │
-//	  ▼  Chunks ─────────────────────────────────────────────────────────────► [score]
+//	[index] chunks + BM25 (+ embeddings), cached in the workspace → *docIndex      │
+//	  ▼  Index ───────────────────────────────────────────────────────────► [retrieve]
+//	                                                                               │ top-N chunks (hybrid)
+//	                                                                               ▼
+//	                                                                           [score] ◄─ Question (START)
 //	                                                                               │ []scoredChunk
 //	                                                                               ▼
 //	                                                                           [filter]  top-k
@@ -36,25 +38,29 @@
 //	                                                                               │
 //	                                                                              END
 //
-// The [score] node wraps a BatchNode whose inner workflow scores each chunk with
-// a ChatModel call in parallel (MaxConcurrency=5).
+// The [index] node is built once per document: it is cached under .rag/ next to
+// the file and rebuilt only when the content, chunk size or embedder changes.
+// [retrieve] fuses the BM25 ranking with the embedding ranking (when an embedder
+// is configured) and keeps the top N chunks. Only those are reranked by [score],
+// which wraps a BatchNode whose inner workflow scores each chunk with a
+// ChatModel call in parallel (MaxConcurrency=5).
 package rag
 
 import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 	"sort"
 	"strings"
 
 	"github.com/cloudwego/eino/adk"
+	"github.com/cloudwego/eino/components/embedding"
 	"github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/compose"
-	"github.com/cloudwego/eino/schema"
 
 	"github.com/cloudwego/eino-examples/adk/common/tool/graphtool"
+	"github.com/cloudwego/eino-examples/components/document/transformer/chunker"
 	"github.com/cloudwego/eino-examples/compose/batch/batch"
 	"github.com/cloudwego/eino-examples/quickstart/chatwitheino/msgops"
 )
@@ -68,29 +74,90 @@ type Input struct {
 
 // Output is the structured result returned by the tool.
 type Output struct {
-	Answer  string   `json:"answer"`
-	Sources []string `json:"sources"` // key excerpts used to produce the answer
+	Answer    string     `json:"answer"`
+	Sources   []string   `json:"sources"`   // key excerpts used to produce the answer
+	Citations []Citation `json:"citations"` // where each source is in the file, in the same order
+}
+
+// Citation locates a source in the document. Start and End are byte offsets
+// of the excerpt when it appears verbatim in its chunk, otherwise of the chunk.
+type Citation struct {
+	Source    int `json:"source"` // the [n] used in Answer
+	Start     int `json:"start"`
+	End       int `json:"end"`
+	StartLine int `json:"start_line"`
+	EndLine   int `json:"end_line"`
+}
+
+// Option configures the retrieval stage of the tool.
+type Option func(*options)
+
+type options struct {
+	embedder  embedding.Embedder
+	topN      int
+	chunkSize int
+	chunker   *chunker.Chunker
+}
+
+func newOptions(ctx context.Context, opts ...Option) (*options, error) {
+	o := &options{topN: 8, chunkSize: 800}
+	for _, opt := range opts {
+		opt(o)
+	}
+	var err error
+	o.chunker, err = chunker.NewChunker(ctx, &chunker.Config{ChunkSize: o.chunkSize, Overlap: o.chunkSize / 8})
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// WithEmbedder adds an embedding index next to the BM25 index. Retrieval
+// fuses both rankings; without an embedder it uses BM25 alone.
+func WithEmbedder(e embedding.Embedder) Option {
+	return func(o *options) { o.embedder = e }
+}
+
+// WithChunkSize sets the maximum chunk size in bytes (default 800). Chunks
+// follow the document structure: Markdown headings, code declarations, or
+// paragraphs and sentences of prose.
+func WithChunkSize(n int) Option {
+	return func(o *options) { o.chunkSize = n }
+}
+
+// WithTopN sets how many retrieved chunks the model reranks (default 8).
+func WithTopN(n int) Option {
+	return func(o *options) { o.topN = n }
 }
 
 // scoreTask is the per-chunk input fed into the inner BatchNode workflow.
 type scoreTask struct {
-	Text     string
+	Chunk    chunk
 	Question string
 }
 
 // scoredChunk is the per-chunk result produced by the inner BatchNode workflow.
 type scoredChunk struct {
-	Text    string
+	Chunk   chunk
 	Score   int    // 0–10 relevance to the question
 	Excerpt string // most relevant sentence or phrase from this chunk
 }
 
+// retrieveIn is the input to the "retrieve" Lambda node.
+// It is assembled by field mapping from two sources:
+//   - Index: full output of "index" node (*docIndex)
+//   - Question: Question field of START (Input)
+type retrieveIn struct {
+	Index    *docIndex
+	Question string
+}
+
 // scoreIn is the input to the outer "score" Lambda node.
 // It is assembled by field mapping from two sources:
-//   - Chunks: full output of "chunk" node ([]*schema.Document)
+//   - Chunks: full output of "retrieve" node ([]chunk)
 //   - Question: Question field of START (Input)
 type scoreIn struct {
-	Chunks   []*schema.Document
+	Chunks   []chunk
 	Question string
 }
 
@@ -106,10 +173,13 @@ type synthIn struct {
 // BuildTool constructs the answer_from_document tool backed by the RAG workflow.
 // It uses graphtool.NewInvokableGraphTool, which compiles the workflow per invocation
 // and supports interrupt/resume via a built-in checkpoint store.
-func BuildTool[M adk.MessageType](ctx context.Context, cm model.BaseModel[M]) (tool.BaseTool, error) {
-	wf := buildWorkflow(cm)
+func BuildTool[M adk.MessageType](ctx context.Context, cm model.BaseModel[M], opts ...Option) (tool.BaseTool, error) {
+	o, err := newOptions(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
 	return graphtool.NewInvokableGraphTool[Input, Output](
-		wf,
+		workflowFactory(func() *compose.Workflow[Input, Output] { return buildWorkflow(cm, o) }),
 		"answer_from_document",
 		"Search a large uploaded document for content relevant to a question and synthesize a "+
 			"cited answer from the most relevant passages. "+
@@ -117,9 +187,17 @@ func BuildTool[M adk.MessageType](ctx context.Context, cm model.BaseModel[M]) (t
 	)
 }
 
+// workflowFactory builds a fresh workflow for every Compile call.
+// graphtool.NewInvokableGraphTool compiles once per invocation, and a
+// compose.Workflow with field mappings cannot be compiled twice.
+type workflowFactory func() *compose.Workflow[Input, Output]
+
+func (f workflowFactory) Compile(ctx context.Context, opts ...compose.GraphCompileOption) (compose.Runnable[Input, Output], error) {
+	return f().Compile(ctx, opts...)
+}
+
 // buildWorkflow constructs the RAG compose.Workflow (uncompiled).
-// graphtool.NewInvokableGraphTool compiles it per invocation.
-func buildWorkflow[M adk.MessageType](cm model.BaseModel[M]) *compose.Workflow[Input, Output] {
+func buildWorkflow[M adk.MessageType](cm model.BaseModel[M], o *options) *compose.Workflow[Input, Output] {
 	scoreWF := newScoreWorkflow(cm)
 	scorer := batch.NewBatchNode(&batch.NodeConfig[scoreTask, scoredChunk]{
 		Name:           "ChunkScorer",
@@ -129,42 +207,48 @@ func buildWorkflow[M adk.MessageType](cm model.BaseModel[M]) *compose.Workflow[I
 
 	wf := compose.NewWorkflow[Input, Output]()
 
-	// load: read file from disk, emit a single Document.
-	wf.AddLambdaNode("load", compose.InvokableLambda(
-		func(ctx context.Context, in Input) ([]*schema.Document, error) {
-			data, err := os.ReadFile(in.FilePath)
-			if err != nil {
-				return nil, fmt.Errorf("read %q: %w", in.FilePath, err)
-			}
-			return []*schema.Document{{Content: string(data)}}, nil
+	// index: load the document's cached index, or chunk and index it.
+	wf.AddLambdaNode("index", compose.InvokableLambda(
+		func(ctx context.Context, in Input) (*docIndex, error) {
+			return loadOrBuildIndex(ctx, in.FilePath, o)
 		},
 	)).AddInput(compose.START)
 
-	// chunk: split each Document into ~800-char pieces.
-	wf.AddLambdaNode("chunk", compose.InvokableLambda(
-		func(ctx context.Context, docs []*schema.Document) ([]*schema.Document, error) {
-			var out []*schema.Document
-			for _, d := range docs {
-				out = append(out, splitIntoChunks(d.Content, 800)...)
+	// retrieve: pick the top-N chunks by BM25, fused with embedding similarity
+	// when the index has vectors. Index comes from "index"; Question from START.
+	wf.AddLambdaNode("retrieve", compose.InvokableLambda(
+		func(ctx context.Context, in retrieveIn) ([]chunk, error) {
+			var qvec []float64
+			if o.embedder != nil && len(in.Index.Vectors) > 0 {
+				vecs, err := o.embedder.EmbedStrings(ctx, []string{in.Question})
+				if err == nil && len(vecs) == 1 {
+					qvec = vecs[0]
+				}
 			}
-			return out, nil
+			return in.Index.search(in.Question, qvec, o.topN), nil
 		},
-	)).AddInput("load")
+	)).
+		AddInputWithOptions("index",
+			[]*compose.FieldMapping{compose.ToField("Index")},
+			compose.WithNoDirectDependency()).
+		AddInputWithOptions(compose.START,
+			[]*compose.FieldMapping{compose.MapFields("Question", "Question")},
+			compose.WithNoDirectDependency())
 
-	// score: score each chunk against the question in parallel via BatchNode.
-	// Chunks comes from "chunk"; Question comes directly from START.
+	// score: rerank the retrieved chunks against the question in parallel via BatchNode.
+	// Chunks comes from "retrieve"; Question comes directly from START.
 	// Both use WithNoDirectDependency because the execution order is already
-	// established by the direct edges START→load→chunk→score.
+	// established by the direct edges START→index→retrieve→score.
 	wf.AddLambdaNode("score", compose.InvokableLambda(
 		func(ctx context.Context, in scoreIn) ([]scoredChunk, error) {
 			tasks := make([]scoreTask, len(in.Chunks))
 			for i, c := range in.Chunks {
-				tasks[i] = scoreTask{Text: c.Content, Question: in.Question}
+				tasks[i] = scoreTask{Chunk: c, Question: in.Question}
 			}
 			return scorer.Invoke(ctx, tasks)
 		},
 	)).
-		AddInputWithOptions("chunk",
+		AddInputWithOptions("retrieve",
 			[]*compose.FieldMapping{compose.ToField("Chunks")},
 			compose.WithNoDirectDependency()).
 		AddInputWithOptions(compose.START,
@@ -247,12 +331,12 @@ Reply with JSON only — no explanation, no markdown fences:
 {"score": <0-10>, "excerpt": "<most relevant sentence or phrase, empty string if score is 0>"}
 
 Score guide: 0=completely irrelevant, 3=tangentially related, 7=clearly relevant, 10=directly answers the question.`,
-		t.Question, t.Text)
+		t.Question, indexText(t.Chunk))
 
 	resp, err := cm.Generate(ctx, []M{msgops.NewUser[M](prompt)})
 	if err != nil {
 		// treat model error as irrelevant rather than aborting the batch
-		return scoredChunk{Text: t.Text, Score: 0}, nil
+		return scoredChunk{Chunk: t.Chunk, Score: 0}, nil
 	}
 
 	content := strings.TrimSpace(msgops.Text(resp))
@@ -267,9 +351,9 @@ Score guide: 0=completely irrelevant, 3=tangentially related, 7=clearly relevant
 		Excerpt string `json:"excerpt"`
 	}
 	if err := json.Unmarshal([]byte(content), &sr); err != nil {
-		return scoredChunk{Text: t.Text, Score: 0}, nil
+		return scoredChunk{Chunk: t.Chunk, Score: 0}, nil
 	}
-	return scoredChunk{Text: t.Text, Score: sr.Score, Excerpt: sr.Excerpt}, nil
+	return scoredChunk{Chunk: t.Chunk, Score: sr.Score, Excerpt: sr.Excerpt}, nil
 }
 
 // synthesize builds a prompt from the top-k chunks and generates a cited answer.
@@ -281,12 +365,14 @@ func synthesize[M adk.MessageType](ctx context.Context, cm model.BaseModel[M], i
 	sb.WriteString("\n\nDocument excerpts:\n")
 
 	sources := make([]string, len(in.TopK))
+	citations := make([]Citation, len(in.TopK))
 	for i, c := range in.TopK {
 		excerpt := c.Excerpt
 		if excerpt == "" {
-			excerpt = c.Text
+			excerpt = c.Chunk.Text
 		}
 		sources[i] = excerpt
+		citations[i] = cite(i+1, c.Chunk, excerpt)
 		fmt.Fprintf(&sb, "[%d] %s\n\n", i+1, excerpt)
 	}
 	sb.WriteString("Provide a clear, concise answer. Cite excerpt numbers like [1] when referencing sources.")
@@ -295,53 +381,18 @@ func synthesize[M adk.MessageType](ctx context.Context, cm model.BaseModel[M], i
 	if err != nil {
 		return Output{}, fmt.Errorf("synthesize: %w", err)
 	}
-	return Output{Answer: msgops.Text(resp), Sources: sources}, nil
+	return Output{Answer: msgops.Text(resp), Sources: sources, Citations: citations}, nil
 }
 
-// splitIntoChunks splits text into chunks of at most chunkSize characters,
-// breaking on paragraph boundaries (\n\n) where possible, then on newlines.
-func splitIntoChunks(text string, chunkSize int) []*schema.Document {
-	var chunks []*schema.Document
-	var buf strings.Builder
-
-	flush := func() {
-		s := strings.TrimSpace(buf.String())
-		if s != "" {
-			chunks = append(chunks, &schema.Document{Content: s})
-		}
-		buf.Reset()
-	}
-
-	for _, para := range strings.Split(text, "\n\n") {
-		para = strings.TrimSpace(para)
-		if para == "" {
-			continue
-		}
-		if buf.Len()+len(para)+2 > chunkSize && buf.Len() > 0 {
-			flush()
-		}
-		// paragraph itself exceeds chunkSize: split by line
-		if len(para) > chunkSize {
-			for _, line := range strings.Split(para, "\n") {
-				line = strings.TrimSpace(line)
-				if line == "" {
-					continue
-				}
-				if buf.Len()+len(line)+1 > chunkSize && buf.Len() > 0 {
-					flush()
-				}
-				if buf.Len() > 0 {
-					buf.WriteByte('\n')
-				}
-				buf.WriteString(line)
-			}
-		} else {
-			if buf.Len() > 0 {
-				buf.WriteString("\n\n")
-			}
-			buf.WriteString(para)
-		}
+// cite locates excerpt inside c, falling back to the whole chunk when the
+// model paraphrased instead of quoting.
+func cite(source int, c chunk, excerpt string) Citation {
+	cit := Citation{Source: source, Start: c.Start, End: c.End, StartLine: c.StartLine, EndLine: c.EndLine}
+	if i := strings.Index(c.Text, excerpt); i >= 0 && excerpt != "" {
+		cit.Start = c.Start + i
+		cit.End = cit.Start + len(excerpt)
+		cit.StartLine = c.StartLine + strings.Count(c.Text[:i], "\n")
+		cit.EndLine = cit.StartLine + strings.Count(excerpt, "\n")
 	}
-	flush()
-	return chunks
+	return cit
 }
@@ -0,0 +1,319 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/google/uuid"
+
+	"github.com/cloudwego/eino/adk"
+
+	"github.com/cloudwego/eino-examples/quickstart/chatwitheino/a2ui"
+	"github.com/cloudwego/eino-examples/quickstart/chatwitheino/mem"
+	"github.com/cloudwego/eino-examples/quickstart/chatwitheino/msgops"
+)
+
+const (
+	bundleFormat  = "chatwitheino.session"
+	bundleVersion = 1
+)
+
+// SessionBundle is the portable JSON export of a session. It carries the whole
+// conversation tree, the uploaded workspace documents and, when the session is
+// waiting for approval, the checkpoint needed to resume it on another machine.
+type SessionBundle struct {
+	Format     string    `json:"format"`
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+	SessionID  string    `json:"session_id"`
+	Title      string    `json:"title"`
+	// GeneratedTitle and Summary are the session header written by a
+	// mem.Summarizer, if any.
+	GeneratedTitle string            `json:"generated_title,omitempty"`
+	Summary        *mem.Summary      `json:"summary,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+	MessageKind    msgops.Kind       `json:"message_kind"`
+	Messages       []json.RawMessage `json:"messages"`
+	Meta           map[string]string `json:"meta,omitempty"`
+	Documents      []BundleDocument  `json:"documents,omitempty"`
+	Checkpoint     []byte            `json:"checkpoint,omitempty"` // base64 in JSON
+}
+
+// BundleDocument is one file from the session workspace.
+type BundleDocument struct {
+	Name string `json:"name"`
+	Data []byte `json:"data"` // base64 in JSON
+}
+
+// handleExport returns the session as ?format=md, html or json (the default).
+func (s *Server[M]) handleExport(ctx context.Context, c *app.RequestContext) {
+	id := c.Param("id")
+	sess, err := s.cfg.Store.GetOrCreate(id)
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+
+	var (
+		buf         bytes.Buffer
+		contentType string
+		ext         string
+	)
+	switch format := c.Query("format"); format {
+	case "md", "markdown":
+		contentType, ext = "text/markdown; charset=utf-8", "md"
+		err = writeMarkdown(&buf, sess.ID, sess.Title(), sess.CreatedAt, sess.GetMessages())
+	case "html":
+		contentType, ext = "text/html; charset=utf-8", "html"
+		err = s.writeHTML(&buf, sess)
+	case "", "json":
+		contentType, ext = "application/json", "json"
+		var bundle *SessionBundle
+		if bundle, err = s.exportBundle(ctx, sess); err == nil {
+			err = json.NewEncoder(&buf).Encode(bundle)
+		}
+	default:
+		c.JSON(consts.StatusBadRequest, map[string]string{"error": "unknown format " + format})
+		return
+	}
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+
+	log.Printf("[export] session=%s format=%s bytes=%d", id, ext, buf.Len())
+	c.Response.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", id+"."+ext))
+	c.Data(consts.StatusOK, contentType, buf.Bytes())
+}
+
+// handleImport restores a JSON bundle into a new session and returns its ID.
+func (s *Server[M]) handleImport(ctx context.Context, c *app.RequestContext) {
+	body, _ := c.Body()
+	var bundle SessionBundle
+	if err := json.Unmarshal(body, &bundle); err != nil {
+		c.JSON(consts.StatusBadRequest, map[string]string{"error": "invalid bundle: " + err.Error()})
+		return
+	}
+
+	id, err := s.importBundle(ctx, &bundle)
+	if err != nil {
+		c.JSON(consts.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	log.Printf("[import] session=%s from=%s messages=%d docs=%d", id, bundle.SessionID, len(bundle.Messages), len(bundle.Documents))
+	c.JSON(consts.StatusOK, map[string]string{"id": id})
+}
+
+// exportBundle collects everything needed to reproduce sess elsewhere.
+func (s *Server[M]) exportBundle(ctx context.Context, sess *mem.Session[M]) (*SessionBundle, error) {
+	snap, err := sess.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &SessionBundle{
+		Format:      bundleFormat,
+		Version:     bundleVersion,
+		ExportedAt:  time.Now().UTC(),
+		SessionID:   sess.ID,
+		Title:       sess.Title(),
+		CreatedAt:   snap.CreatedAt,
+		MessageKind: msgops.KindOf[M](),
+		Messages:    make([]json.RawMessage, 0, len(snap.Messages)),
+		Meta:        snap.Meta,
+
+		GeneratedTitle: snap.Title,
+	}
+	if snap.Summary.Text != "" {
+		bundle.Summary = &snap.Summary
+	}
+	for _, msg := range snap.Messages {
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return nil, err
+		}
+		bundle.Messages = append(bundle.Messages, data)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(s.cfg.WorkspaceDir, sess.ID))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.cfg.WorkspaceDir, sess.ID, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		bundle.Documents = append(bundle.Documents, BundleDocument{Name: e.Name(), Data: data})
+	}
+
+	// The checkpoint only matters while an interrupt is waiting for approval.
+	if sess.GetPendingInterruptID() != "" && s.cfg.CheckPointStore != nil {
+		data, ok, err := s.cfg.CheckPointStore.Get(ctx, sess.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+		}
+		if ok {
+			bundle.Checkpoint = data
+		}
+	}
+	return bundle, nil
+}
+
+// importBundle restores bundle under a freshly generated session ID.
+func (s *Server[M]) importBundle(ctx context.Context, bundle *SessionBundle) (string, error) {
+	if bundle.Format != bundleFormat {
+		return "", fmt.Errorf("not a session bundle (format %q)", bundle.Format)
+	}
+	if bundle.Version > bundleVersion {
+		return "", fmt.Errorf("bundle version %d is newer than supported version %d", bundle.Version, bundleVersion)
+	}
+	if err := msgops.ValidateKind(bundle.MessageKind, msgops.KindOf[M](), false); err != nil {
+		return "", fmt.Errorf("cannot import bundle: %w", err)
+	}
+
+	snap := mem.Snapshot[M]{
+		Header:   mem.Header{CreatedAt: bundle.CreatedAt, Title: bundle.GeneratedTitle},
+		Messages: make([]M, 0, len(bundle.Messages)),
+		Meta:     bundle.Meta,
+	}
+	if bundle.Summary != nil {
+		snap.Summary = *bundle.Summary
+	}
+	for i, raw := range bundle.Messages {
+		msg, err := msgops.UnmarshalMessage[M](raw)
+		if err != nil {
+			return "", fmt.Errorf("bad message %d: %w", i, err)
+		}
+		snap.Messages = append(snap.Messages, msg)
+	}
+	for _, doc := range bundle.Documents {
+		// Documents are written into the new workspace as plain file names only.
+		if name := filepath.Base(doc.Name); name != doc.Name || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+			return "", fmt.Errorf("invalid document name %q", doc.Name)
+		}
+	}
+
+	id := uuid.New().String()
+	if len(bundle.Documents) > 0 {
+		workDir := filepath.Join(s.cfg.WorkspaceDir, id)
+		if err := os.MkdirAll(workDir, 0o755); err != nil {
+			return "", err
+		}
+		for _, doc := range bundle.Documents {
+			if err := os.WriteFile(filepath.Join(workDir, doc.Name), doc.Data, 0o644); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	sess, err := s.cfg.Store.Restore(id, snap)
+	if err != nil {
+		return "", err
+	}
+	if sess.GetPendingInterruptID() != "" {
+		if len(bundle.Checkpoint) == 0 || s.cfg.CheckPointStore == nil {
+			// Without its checkpoint the interrupt can never be approved.
+			sess.SetPendingInterruptID("")
+		} else if err := s.cfg.CheckPointStore.Set(ctx, id, bundle.Checkpoint); err != nil {
+			return "", fmt.Errorf("failed to restore checkpoint: %w", err)
+		}
+	}
+	return id, nil
+}
+
+// writeHTML renders the active branch through A2UI into a standalone page.
+func (s *Server[M]) writeHTML(w io.Writer, sess *mem.Session[M]) error {
+	history, entries := sess.Branch()
+	siblings := make([]a2ui.Siblings, len(entries))
+	for i, e := range entries {
+		siblings[i] = a2ui.Siblings{Pos: e.SiblingPos, Count: e.SiblingCount}
+	}
+	var stream bytes.Buffer
+	if err := a2ui.RenderBranch(&stream, sess.ID, history, siblings); err != nil {
+		return err
+	}
+	if id := sess.GetPendingInterruptID(); id != "" {
+		data, _ := a2ui.Encode(a2ui.Message{InterruptRequest: &a2ui.InterruptRequestMsg{
+			InterruptID: id,
+			Description: "The agent is waiting for approval.",
+		}})
+		stream.Write(data)
+	}
+	return a2ui.WriteHTML(w, sess.Title(), &stream)
+}
+
+// writeMarkdown writes history as a Markdown transcript.
+func writeMarkdown[M adk.MessageType](w io.Writer, id, title string, createdAt time.Time, history []M) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	fmt.Fprintf(&b, "- Session: `%s`\n- Created: %s\n", id, createdAt.UTC().Format(time.RFC3339))
+
+	for _, msg := range history {
+		if results := msgops.ToolResults(msg); len(results) > 0 {
+			for _, r := range results {
+				fmt.Fprintf(&b, "\n## Tool result: %s\n\n%s\n", r.Name, fenced(r.Content))
+			}
+			continue
+		}
+		text := msgops.Text(msg)
+		calls := msgops.ToolCalls(msg)
+		if text == "" && len(calls) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s\n", msgops.RoleLabel(msg))
+		if text != "" {
+			fmt.Fprintf(&b, "\n%s\n", text)
+		}
+		for _, tc := range calls {
+			fmt.Fprintf(&b, "\n**Tool call: %s**\n\n%s\n", tc.Name, fenced(tc.Args))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// fenced wraps text in a code fence longer than any backtick run inside it.
+func fenced(text string) string {
+	longest, run := 0, 0
+	for _, r := range text {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", max(3, longest+1))
+	return fence + "\n" + strings.TrimRight(text, "\n") + "\n" + fence
+}
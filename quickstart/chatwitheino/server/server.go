@@ -25,6 +25,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -55,6 +56,15 @@ type ChatItem struct {
 	Query          string                     // user message text (empty for approval items)
 	ApprovalResult *commontool.ApprovalResult // non-nil when this item carries an approval decision
 	InterruptID    string                     // which interrupt this approval resolves
+
+	Edit       bool // Query replaces the user message at EditNode on a new branch
+	EditNode   int
+	Regenerate bool // re-answer the last user message on a new branch
+}
+
+// startsTurn reports whether the item carries user input that GenInput turns into an agent run.
+func (item *ChatItem) startsTurn() bool {
+	return item.Query != "" || item.Regenerate
 }
 
 // errInterrupted is returned by OnAgentEvents when the agent is interrupted
@@ -70,6 +80,10 @@ type Config[M adk.MessageType] struct {
 	ProjectRoot     string // root of the codebase the agent can explore
 	ExamplesDir     string // root of the eino-examples repo (for example searches)
 	Port            string
+
+	// Summarizer, if set, titles sessions after their first exchange and
+	// replaces the start of long histories with a rolling summary.
+	Summarizer *mem.Summarizer[M]
 }
 
 // Server wraps a Hertz HTTP server with the chat-with-doc routes.
@@ -206,7 +220,11 @@ func (s *Server[M]) Spin() {
 	})
 
 	h.GET("/sessions", func(ctx context.Context, c *app.RequestContext) {
-		metas, err := s.cfg.Store.List()
+		// Optional ?q=<search>&offset=<n>&limit=<n> narrow the list.
+		q := mem.ListQuery{Search: c.Query("q")}
+		q.Offset, _ = strconv.Atoi(c.Query("offset"))
+		q.Limit, _ = strconv.Atoi(c.Query("limit"))
+		metas, err := s.cfg.Store.Search(q)
 		if err != nil {
 			c.JSON(consts.StatusInternalServerError, map[string]string{"error": err.Error()})
 			return
@@ -256,6 +274,30 @@ func (s *Server[M]) Spin() {
 		s.handleUpload(ctx, c)
 	})
 
+	h.GET("/sessions/:id/export", func(ctx context.Context, c *app.RequestContext) {
+		s.handleExport(ctx, c)
+	})
+
+	h.POST("/sessions/import", func(ctx context.Context, c *app.RequestContext) {
+		s.handleImport(ctx, c)
+	})
+
+	h.GET("/sessions/:id/tree", func(ctx context.Context, c *app.RequestContext) {
+		s.handleTree(ctx, c)
+	})
+
+	h.POST("/sessions/:id/messages/:node/edit", func(ctx context.Context, c *app.RequestContext) {
+		s.handleEdit(ctx, c)
+	})
+
+	h.POST("/sessions/:id/regenerate", func(ctx context.Context, c *app.RequestContext) {
+		s.handleRegenerate(ctx, c)
+	})
+
+	h.POST("/sessions/:id/branch", func(ctx context.Context, c *app.RequestContext) {
+		s.handleSwitchBranch(ctx, c)
+	})
+
 	h.Spin()
 }
 
@@ -263,6 +305,10 @@ type chatRequest struct {
 	Message string `json:"message"`
 }
 
+type branchRequest struct {
+	Node int `json:"node"`
+}
+
 type approveRequest struct {
 	Approved bool   `json:"approved"`
 	Reason   string `json:"reason,omitempty"`
@@ -275,16 +321,106 @@ func (s *Server[M]) handleRender(_ context.Context, c *app.RequestContext) {
 		c.JSON(consts.StatusInternalServerError, map[string]string{"error": err.Error()})
 		return
 	}
+	s.renderBranch(c, id, sess)
+}
+
+// renderBranch writes the session's active branch as A2UI JSONL.
+func (s *Server[M]) renderBranch(c *app.RequestContext, id string, sess *mem.Session[M]) {
+	history, entries := sess.Branch()
+	siblings := make([]a2ui.Siblings, len(entries))
+	for i, e := range entries {
+		siblings[i] = a2ui.Siblings{Pos: e.SiblingPos, Count: e.SiblingCount}
+	}
 	var buf bytes.Buffer
-	if err := a2ui.RenderHistory(&buf, id, sess.GetMessages()); err != nil {
+	if err := a2ui.RenderBranch(&buf, id, history, siblings); err != nil {
 		c.JSON(consts.StatusInternalServerError, map[string]string{"error": err.Error()})
 		return
 	}
 	c.Data(consts.StatusOK, "application/x-ndjson", buf.Bytes())
 }
 
+// handleTree returns every stored message of the session as a tree node.
+func (s *Server[M]) handleTree(_ context.Context, c *app.RequestContext) {
+	id := c.Param("id")
+	sess, err := s.cfg.Store.GetOrCreate(id)
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	c.JSON(consts.StatusOK, sess.Tree())
+}
+
+// handleSwitchBranch activates the branch through the given message and
+// returns the re-rendered history.
+func (s *Server[M]) handleSwitchBranch(_ context.Context, c *app.RequestContext) {
+	id := c.Param("id")
+	sess, err := s.cfg.Store.GetOrCreate(id)
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	body, _ := c.Body()
+	var req branchRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		c.JSON(consts.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		return
+	}
+	if err := sess.SwitchBranch(req.Node); err != nil {
+		c.JSON(consts.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	log.Printf("[branch] session=%s node=%d", id, req.Node)
+	s.renderBranch(c, id, sess)
+}
+
+// handleEdit replaces an earlier user message on a new branch and runs a turn for it.
+func (s *Server[M]) handleEdit(ctx context.Context, c *app.RequestContext) {
+	id := c.Param("id")
+	node, err := strconv.Atoi(c.Param("node"))
+	if err != nil {
+		c.JSON(consts.StatusBadRequest, map[string]string{"error": "invalid message index"})
+		return
+	}
+
+	body, _ := c.Body()
+	var req chatRequest
+	if err := json.Unmarshal(body, &req); err != nil || req.Message == "" {
+		c.JSON(consts.StatusBadRequest, map[string]string{"error": "message is required"})
+		return
+	}
+
+	sess, err := s.cfg.Store.GetOrCreate(id)
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	if !sess.IsUserMessage(node) {
+		c.JSON(consts.StatusBadRequest, map[string]string{"error": "only user messages can be edited"})
+		return
+	}
+
+	log.Printf("[edit] session=%s node=%d msg=%q", id, node, req.Message)
+	s.runTurn(ctx, c, id, sess, &ChatItem{Query: req.Message, Edit: true, EditNode: node})
+}
+
+// handleRegenerate re-answers the last user message on a new branch.
+func (s *Server[M]) handleRegenerate(ctx context.Context, c *app.RequestContext) {
+	id := c.Param("id")
+	sess, err := s.cfg.Store.GetOrCreate(id)
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	if _, ok := sess.LastUserMessage(); !ok {
+		c.JSON(consts.StatusBadRequest, map[string]string{"error": "nothing to regenerate"})
+		return
+	}
+
+	log.Printf("[regenerate] session=%s", id)
+	s.runTurn(ctx, c, id, sess, &ChatItem{Regenerate: true})
+}
+
 // handleChat handles a new chat message. It creates or reuses a TurnLoop for the session.
-// If a loop is already running (busy), it pushes with preempt to cancel the current turn.
 func (s *Server[M]) handleChat(ctx context.Context, c *app.RequestContext) {
 	id := c.Param("id")
 
@@ -303,8 +439,13 @@ func (s *Server[M]) handleChat(ctx context.Context, c *app.RequestContext) {
 		return
 	}
 
-	item := &ChatItem{Query: req.Message}
+	s.runTurn(ctx, c, id, sess, &ChatItem{Query: req.Message})
+}
 
+// runTurn pushes item into the session's TurnLoop and streams the resulting
+// agent run to the client. If a loop is already running (busy), it pushes with
+// preempt to cancel the current turn.
+func (s *Server[M]) runTurn(_ context.Context, c *app.RequestContext, id string, sess *mem.Session[M], item *ChatItem) {
 	ts := s.getTurnState(id)
 
 	// Each handler gets its own local iterReady channel reference and a
@@ -596,7 +737,7 @@ func (s *Server[M]) makeGenInput(sess *mem.Session[M], sessionID string) func(ct
 		var remaining []*ChatItem
 		var queryItem *ChatItem
 		for _, item := range items {
-			if queryItem == nil && item.Query != "" {
+			if queryItem == nil && item.startsTurn() {
 				queryItem = item
 				consumed = append(consumed, item)
 			} else {
@@ -614,13 +755,31 @@ func (s *Server[M]) makeGenInput(sess *mem.Session[M], sessionID string) func(ct
 
 		// Persist the user message NOW — GenInput fires only after any previous
 		// turn's OnAgentEvents has finished persisting its intermediates, so the
-		// session history order is guaranteed correct.
-		userMsg := msgops.NewUser[M](queryItem.Query)
-		if appendErr := sess.Append(userMsg); appendErr != nil {
-			log.Printf("warn: failed to persist user message: %v", appendErr)
+		// session history order is guaranteed correct. Edits and regenerations
+		// move the branch head first so the new messages land on a new branch.
+		switch {
+		case queryItem.Regenerate:
+			if node, ok := sess.LastUserMessage(); ok {
+				if err := sess.Rewind(node); err != nil {
+					log.Printf("warn: failed to rewind for regenerate: %v", err)
+				}
+			}
+		case queryItem.Edit:
+			if err := sess.Fork(queryItem.EditNode); err != nil {
+				log.Printf("warn: failed to fork for edit: %v", err)
+			}
+		}
+		if queryItem.Query != "" {
+			userMsg := msgops.NewUser[M](queryItem.Query)
+			if appendErr := sess.Append(userMsg); appendErr != nil {
+				log.Printf("warn: failed to persist user message: %v", appendErr)
+			}
 		}
 
 		history := sess.GetMessages()
+		if s.cfg.Summarizer != nil {
+			history = sess.History(s.cfg.Summarizer.TokenBudget())
+		}
 		runMessages := s.buildRunMessages(sessionID, history)
 
 		log.Printf("[genInput] session=%s query=%q messages=%d", sessionID, queryItem.Query, len(runMessages))
@@ -684,6 +843,9 @@ func (s *Server[M]) makeOnAgentEvents(sess *mem.Session[M], sessionID string) fu
 				log.Printf("warn: failed to persist intermediate message: %v", appendErr)
 			}
 		}
+		if s.cfg.Summarizer != nil {
+			s.cfg.Summarizer.Observe(sess)
+		}
 		if result.interruptID != "" {
 			sess.SetPendingInterruptID(result.interruptID)
 			sess.SetMsgIdx(result.msgIdx)
@@ -17,8 +17,12 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
@@ -591,3 +595,68 @@ func TestServerRouteRegistration(t *testing.T) {
 	// Just verify the server can be constructed without error.
 	_ = srv
 }
+
+func TestExportImportBundle(t *testing.T) {
+	srv, _, cleanup := newTestServer(t, simpleReplyAgent("test"))
+	defer cleanup()
+	ctx := context.Background()
+
+	sessionID := createSession(t, srv)
+	sess, _ := srv.cfg.Store.GetOrCreate(sessionID)
+	_ = sess.Append(schema.UserMessage("summarize notes.md"))
+	_ = sess.Append(schema.AssistantMessage("it lists ```three``` tasks", nil))
+	sess.SetPendingInterruptID("interrupt-1")
+	if err := srv.cfg.CheckPointStore.Set(ctx, sessionID, []byte("checkpoint state")); err != nil {
+		t.Fatal(err)
+	}
+	workDir := filepath.Join(srv.cfg.WorkspaceDir, sessionID)
+	_ = os.MkdirAll(workDir, 0o755)
+	if err := os.WriteFile(filepath.Join(workDir, "notes.md"), []byte("- a\n- b\n- c\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	bundle, err := srv.exportBundle(ctx, sess)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	// Round-trip through JSON as the HTTP routes do.
+	data, _ := json.Marshal(bundle)
+	var decoded SessionBundle
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	newID, err := srv.importBundle(ctx, &decoded)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if newID == sessionID {
+		t.Fatal("import should create a new session ID")
+	}
+	imported, _ := srv.cfg.Store.GetOrCreate(newID)
+	if msgs := imported.GetMessages(); len(msgs) != 2 || msgs[0].Content != "summarize notes.md" {
+		t.Fatalf("imported messages: %+v", msgs)
+	}
+	if got := imported.GetPendingInterruptID(); got != "interrupt-1" {
+		t.Fatalf("pending interrupt = %q", got)
+	}
+	if cp, ok, _ := srv.cfg.CheckPointStore.Get(ctx, newID); !ok || string(cp) != "checkpoint state" {
+		t.Fatalf("checkpoint not restored: %q %v", cp, ok)
+	}
+	if doc, err := os.ReadFile(filepath.Join(srv.cfg.WorkspaceDir, newID, "notes.md")); err != nil || string(doc) != "- a\n- b\n- c\n" {
+		t.Fatalf("document not restored: %q %v", doc, err)
+	}
+
+	decoded.Documents = []BundleDocument{{Name: "../escape.txt", Data: []byte("x")}}
+	if _, err := srv.importBundle(ctx, &decoded); err == nil {
+		t.Fatal("expected a document path outside the workspace to be rejected")
+	}
+
+	var md bytes.Buffer
+	if err := writeMarkdown(&md, sess.ID, sess.Title(), sess.CreatedAt, sess.GetMessages()); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(md.String(), "## You\n\nsummarize notes.md\n") {
+		t.Errorf("markdown transcript:\n%s", md.String())
+	}
+}
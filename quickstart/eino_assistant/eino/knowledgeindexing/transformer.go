@@ -19,20 +19,20 @@ package knowledgeindexing
 import (
 	"context"
 
-	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/markdown"
 	"github.com/cloudwego/eino/components/document"
+
+	"github.com/cloudwego/eino-examples/components/document/transformer/chunker"
 )
 
 // newDocumentTransformer component initialization function of node 'MarkdownSplitter' in graph 'KnowledgeIndexing'
 func newDocumentTransformer(ctx context.Context) (tfr document.Transformer, err error) {
-	// TODO Modify component configuration here.
-	config := &markdown.HeaderConfig{
-		Headers: map[string]string{
-			"#": "title",
-		},
-		TrimHeaders: false,
+	// Split by Markdown headings, keeping the heading breadcrumb in the chunk
+	// metadata; long sections are split further by paragraphs and sentences.
+	config := &chunker.Config{
+		ChunkSize: 1500,
+		Overlap:   150,
 	}
-	tfr, err = markdown.NewHeaderSplitter(ctx, config)
+	tfr, err = chunker.NewChunker(ctx, config)
 	if err != nil {
 		return nil, err
 	}